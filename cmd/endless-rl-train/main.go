@@ -3,11 +3,16 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/unng-lab/endless/pkg/endless"
+	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
+	"github.com/unng-lab/endless/pkg/replay"
 	"github.com/unng-lab/endless/pkg/rl"
 )
 
@@ -32,7 +37,13 @@ func main() {
 	trainLearningRate := float64(linearQStubDefaults.LearningRate)
 	trainDiscount := float64(linearQStubDefaults.Discount)
 	trainModelOutputPath := ""
-	flag.StringVar(&mode, "mode", "collect", "launcher mode: collect, evaluate, compare, export, export-sequences, inspect-batches or train-stub")
+	desyncWorkers := "1,4"
+	replayOutputPath := ""
+	renderConfig := endless.CaptureConfig{}
+	renderFormat := string(endless.CaptureFormatGIF)
+	renderReplayPath := ""
+	renderModelPath := ""
+	flag.StringVar(&mode, "mode", "collect", "launcher mode: collect, evaluate, compare, desync, record-replay, render, export, export-sequences, inspect-batches or train-stub; render opens a window and needs a display, use xvfb-run on headless machines")
 	flag.StringVar(&policyName, "policy", rl.PolicyLeadAndStrafe, "shooter policy: lead_strafe or random")
 	flag.StringVar(&policySuite, "policy-suite", policySuite, "comma-separated policy list for compare mode")
 	flag.StringVar(&compareBaselinePolicy, "compare-baseline-policy", "", "optional baseline policy inside compare mode; defaults to the first policy from -policy-suite")
@@ -43,6 +54,9 @@ func main() {
 	flag.IntVar(&config.WorldRows, "world-rows", 256, "world row count for duel episodes")
 	flag.Float64Var(&config.TileSize, "tile-size", 16, "world tile size for duel episodes")
 	flag.StringVar(&config.Scenario, "scenario", rl.DuelScenarioOpen, "duel scenario: duel_open or duel_with_cover")
+	flag.IntVar(&config.ShooterLives, "shooter-lives", 0, "respawns the shooter gets per episode; 0 ends the episode on its first death")
+	flag.IntVar(&config.TargetLives, "target-lives", 0, "respawns the target gets per episode; 0 ends the episode on its first death")
+	flag.Int64Var(&config.RespawnDelayTicks, "respawn-delay", 30, "ticks a killed duellist waits before it respawns in multi-life episodes")
 	flag.StringVar(&exportFormat, "export-format", string(rl.TransitionExportFormatJSONL), "transition export format: jsonl or json")
 	flag.StringVar(&exportOutputPath, "export-output", "-", "transition export destination path or - for stdout")
 	flag.StringVar(&exportScenario, "export-scenario", "", "optional scenario filter for transition export")
@@ -57,6 +71,17 @@ func main() {
 	flag.Float64Var(&trainLearningRate, "train-learning-rate", float64(linearQStubDefaults.LearningRate), "learning rate for train-stub mode")
 	flag.Float64Var(&trainDiscount, "train-discount", float64(linearQStubDefaults.Discount), "discount factor for train-stub mode")
 	flag.StringVar(&trainModelOutputPath, "train-model-output", "", "optional filesystem path where train-stub writes the trained linear q stub artifact as JSON")
+	flag.StringVar(&replayOutputPath, "replay-output", "", "replay file written by record-replay mode for the first episode derived from -seed")
+	flag.StringVar(&renderConfig.Output, "render-output", "", "render mode destination: a .gif file or a directory for numbered PNG frames")
+	flag.StringVar(&renderFormat, "render-format", renderFormat, "render mode output format: gif or png")
+	flag.IntVar(&renderConfig.FrameEvery, "render-every", endless.DefaultCaptureFrameEvery, "simulation ticks between two rendered frames")
+	flag.IntVar(&renderConfig.Width, "render-width", endless.DefaultCaptureWidth, "rendered frame width in pixels")
+	flag.IntVar(&renderConfig.Height, "render-height", endless.DefaultCaptureHeight, "rendered frame height in pixels")
+	flag.Float64Var(&renderConfig.Scale, "render-zoom", 0, "fixed camera zoom for render mode; 0 frames both duellists at the start")
+	flag.StringVar(&renderConfig.Overlays, "render-overlays", "", "comma-separated debug overlays for render mode, e.g. route,shots,policy; off hides all, empty keeps the defaults")
+	flag.StringVar(&renderReplayPath, "render-replay", "", "optional replay file rendered instead of a live episode in render mode")
+	flag.StringVar(&renderModelPath, "render-model-path", "", "optional runtime model artifact driving the shooter in render mode; overrides -policy")
+	flag.StringVar(&desyncWorkers, "desync-workers", desyncWorkers, "comma-separated unit update worker counts for desync mode; the first count is the reference")
 	flag.Parse()
 
 	ctx := context.Background()
@@ -350,6 +375,96 @@ func main() {
 		return
 	}
 
+	if mode == "desync" {
+		workerCounts, err := parseWorkerCountList(desyncWorkers)
+		if err != nil {
+			log.Fatalf("parse -desync-workers: %v", err)
+		}
+
+		report, err := rl.RunDuelDesyncCheck(ctx, config, policyName, workerCounts)
+		if err != nil {
+			log.Fatalf("run duel desync check: %v", err)
+		}
+
+		for _, run := range report.Runs {
+			log.Printf(
+				"[rl-desync-run] run=%s workers=%d ticks=%d final_hash=%016x",
+				run.Label,
+				run.UpdateWorkers,
+				run.TicksExecuted,
+				run.FinalHash,
+			)
+		}
+		if report.Diverged {
+			log.Fatalf(
+				"[rl-desync] diverged run=%s episode=%d seed=%d tick=%d reference_hash=%016x candidate_hash=%016x",
+				report.DivergedRun,
+				report.DivergedEpisode,
+				report.DivergedSeed,
+				report.DivergedTick,
+				report.ReferenceHash,
+				report.CandidateHash,
+			)
+		}
+		log.Printf("[rl-desync] episodes=%d runs=%d diverged=false", report.EpisodesChecked, len(report.Runs))
+		return
+	}
+
+	if mode == "record-replay" {
+		episodeSeed := rl.FirstDuelEpisodeSeed(config.Seed)
+		recorded, err := rl.RecordDuelReplay(config, policyName, episodeSeed)
+		if err != nil {
+			log.Fatalf("record duel replay: %v", err)
+		}
+		if err := replay.Save(replayOutputPath, recorded); err != nil {
+			log.Fatalf("save duel replay: %v", err)
+		}
+
+		log.Printf(
+			"[rl-replay] output=%s scenario=%s episode_seed=%d ticks=%d commands=%d",
+			replayOutputPath,
+			recorded.Scenario,
+			episodeSeed,
+			recorded.Ticks,
+			len(recorded.Commands),
+		)
+		return
+	}
+
+	if mode == "render" {
+		// The live episode uses the layout seed of the first episode derived from -seed, the
+		// same one record-replay records.
+		episodeSeed := rl.FirstDuelEpisodeSeed(config.Seed)
+		renderConfig.Format = endless.CaptureFormat(renderFormat)
+		renderConfig.MaxTicks = config.MaxTicksPerEpisode
+		renderConfig.Game = endless.GameConfig{
+			Mode: gamescenario.ModeRLDuel,
+			RLDuel: rl.VisualDuelScenarioConfig{
+				Scenario:  config.Scenario,
+				Policy:    policyName,
+				Seed:      episodeSeed,
+				ModelPath: renderModelPath,
+				MaxTicks:  config.MaxTicksPerEpisode,
+			},
+			ReplayPath: renderReplayPath,
+		}
+		summary, err := endless.RunCapture(renderConfig)
+		if err != nil {
+			log.Fatalf("render duel episode: %v", err)
+		}
+
+		log.Printf(
+			"[rl-render] output=%s format=%s replay=%q episode_seed=%d frames=%d ticks=%d",
+			summary.Output,
+			summary.Format,
+			renderReplayPath,
+			episodeSeed,
+			summary.Frames,
+			summary.Ticks,
+		)
+		return
+	}
+
 	policy, err := rl.NewPolicyByName(policyName, config.Seed)
 	if err != nil {
 		log.Fatalf("create policy %q: %v", policyName, err)
@@ -376,7 +491,7 @@ func main() {
 		return
 	}
 	if mode != "collect" {
-		log.Fatalf("unsupported mode %q; use collect, evaluate, compare, export, export-sequences, inspect-batches or train-stub", mode)
+		log.Fatalf("unsupported mode %q; use collect, evaluate, compare, desync, record-replay, render, export, export-sequences, inspect-batches or train-stub", mode)
 	}
 
 	clickhouseConfig := rl.LoadClickHouseConfigFromEnv(os.Getenv)
@@ -405,3 +520,29 @@ func openTransitionExportDestination(path string) (*os.File, func(), error) {
 		_ = file.Close()
 	}, nil
 }
+
+// parseWorkerCountList turns the comma-separated -desync-workers value into positive worker
+// counts while keeping the user-provided order, because the first entry is the reference run.
+func parseWorkerCountList(value string) ([]int, error) {
+	workerCounts := make([]int, 0)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		workerCount, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		if workerCount <= 0 {
+			return nil, fmt.Errorf("worker count must be positive, got %d", workerCount)
+		}
+		workerCounts = append(workerCounts, workerCount)
+	}
+	if len(workerCounts) == 0 {
+		return nil, fmt.Errorf("at least one worker count is required")
+	}
+
+	return workerCounts, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/unng-lab/endless/cmd/internal/launcher"
+	"github.com/unng-lab/endless/pkg/endless"
+	"github.com/unng-lab/endless/pkg/endless/headless"
+	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
+)
+
+// benchConfig groups the benchmark flags of the stress launcher. A positive tick count runs the
+// benchmark instead of the window; diff mode only compares two existing reports.
+type benchConfig struct {
+	Ticks        int64
+	Seed         int64
+	OutputPath   string
+	BaselinePath string
+	Threshold    float64
+	Diff         bool
+}
+
+// runStressBenchmark runs the stress scene headlessly with a fixed seed, writes the JSON report
+// and, when a baseline is given, fails on regressions so batch jobs can gate on the result.
+func runStressBenchmark(config benchConfig, updateWorkers int) error {
+	runner, err := endless.NewHeadlessRunner(endless.GameConfig{
+		Mode: gamescenario.ModeStress,
+		Seed: config.Seed,
+	}, updateWorkers)
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+	launcher.PublishManagerMetrics(runner.Manager())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Printf("[bench] scenario=%s seed=%d ticks=%d", gamescenario.ModeStress, config.Seed, config.Ticks)
+	report, err := headless.RunBenchmark(ctx, runner, headless.BenchmarkConfig{
+		Scenario:      string(gamescenario.ModeStress),
+		Seed:          config.Seed,
+		Ticks:         config.Ticks,
+		UpdateWorkers: updateWorkers,
+	})
+	if err != nil {
+		return fmt.Errorf("run stress benchmark: %w", err)
+	}
+	log.Printf(
+		"[bench] ticks_per_second=%.1f p50=%s p99=%s allocs_per_tick=%.0f jobs_completed=%d jobs_failed=%d",
+		report.TicksPerSecond,
+		report.TickTime.P50,
+		report.TickTime.P99,
+		report.AllocationsPerTick,
+		report.JobCompletedCount,
+		report.JobFailedCount,
+	)
+
+	if config.OutputPath != "" {
+		if err := headless.SaveBenchmarkReport(config.OutputPath, report); err != nil {
+			return err
+		}
+		log.Printf("[bench] report written: %s", config.OutputPath)
+	} else {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("encode benchmark report: %w", err)
+		}
+	}
+
+	if config.BaselinePath == "" {
+		return nil
+	}
+	baseline, err := headless.LoadBenchmarkReport(config.BaselinePath)
+	if err != nil {
+		return err
+	}
+	return printBenchmarkDiff(baseline, report, config.Threshold)
+}
+
+// diffStressBenchmarks compares two saved reports given as positional arguments.
+func diffStressBenchmarks(args []string, threshold float64) error {
+	if len(args) != 2 {
+		return fmt.Errorf("-bench-diff needs exactly two report paths, got %d", len(args))
+	}
+
+	base, err := headless.LoadBenchmarkReport(args[0])
+	if err != nil {
+		return err
+	}
+	head, err := headless.LoadBenchmarkReport(args[1])
+	if err != nil {
+		return err
+	}
+	return printBenchmarkDiff(base, head, threshold)
+}
+
+func printBenchmarkDiff(base, head headless.BenchmarkReport, threshold float64) error {
+	diff, err := headless.DiffBenchmarkReports(base, head, threshold)
+	if err != nil {
+		return err
+	}
+	if err := diff.WriteText(os.Stderr); err != nil {
+		return err
+	}
+	if regressions := diff.Regressions(); len(regressions) > 0 {
+		return fmt.Errorf("%d benchmark metric(s) regressed beyond %.1f%%", len(regressions), threshold*100)
+	}
+	return nil
+}
@@ -1,21 +1,45 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"os"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/unng-lab/endless/cmd/internal/launcher"
 	"github.com/unng-lab/endless/pkg/endless"
+	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
 )
 
 func main() {
 	startedAt := time.Now()
 	log.Printf("[startup] launcher: stress process started")
 
+	bench := benchConfig{Seed: 1, Threshold: 0.05}
+	flag.Int64Var(&bench.Ticks, "bench-ticks", 0, "run the stress benchmark for this many ticks instead of opening a window")
+	flag.Int64Var(&bench.Seed, "bench-seed", bench.Seed, "scene seed for the stress benchmark")
+	flag.StringVar(&bench.OutputPath, "bench-output", "", "write the benchmark JSON report to this file instead of stdout")
+	flag.StringVar(&bench.BaselinePath, "bench-baseline", "", "compare the new benchmark report against this saved report")
+	flag.Float64Var(&bench.Threshold, "bench-threshold", bench.Threshold, "relative change treated as a regression, for example 0.05 for five percent")
+	flag.BoolVar(&bench.Diff, "bench-diff", false, "compare two saved benchmark reports given as arguments: base.json head.json")
+
 	flagsStartedAt := time.Now()
 	runConfig := launcher.ParseRunConfig()
 	log.Printf("[startup] launcher: command-line flags parsed in %s", time.Since(flagsStartedAt))
+	if runConfig.PrintBindings {
+		if err := launcher.PrintDefaultBindings(os.Stdout); err != nil {
+			log.Fatalf("print bindings: %v", err)
+		}
+		return
+	}
+
+	if bench.Diff {
+		if err := diffStressBenchmarks(flag.Args(), bench.Threshold); err != nil {
+			log.Fatalf("diff stress benchmarks: %v", err)
+		}
+		return
+	}
 
 	profilerStartedAt := time.Now()
 	profilerSession, err := launcher.StartProfiler(runConfig.Profiling)
@@ -29,6 +53,19 @@ func main() {
 		}
 	}()
 
+	if bench.Ticks > 0 {
+		if err := runStressBenchmark(bench, runConfig.Headless.UpdateWorkers); err != nil {
+			log.Fatalf("stress benchmark: %v", err)
+		}
+		return
+	}
+	if runConfig.Headless.Enabled() {
+		if err := launcher.RunHeadless(endless.GameConfig{Mode: gamescenario.ModeStress}, runConfig.Headless); err != nil {
+			log.Fatalf("run headless stress: %v", err)
+		}
+		return
+	}
+
 	windowStartedAt := time.Now()
 	ebiten.SetWindowTitle("Endless Stress")
 	ebiten.SetWindowSize(endless.DefaultScreenWidth, endless.DefaultScreenHeight)
@@ -38,14 +75,23 @@ func main() {
 	log.Printf("[startup] launcher: window configured in %s", time.Since(windowStartedAt))
 
 	gameStartedAt := time.Now()
-	game, err := endless.NewStressGame()
+	game, err := endless.NewGameWithConfig(endless.GameConfig{
+		Mode:         gamescenario.ModeStress,
+		SettingsPath: runConfig.SettingsPath,
+		BindingsPath: runConfig.BindingsPath,
+		ZoomInertia:  runConfig.ZoomInertia,
+		AssetDevDir:  runConfig.AssetDevDir,
+	})
 	if err != nil {
 		log.Fatalf("create stress game: %v", err)
 	}
-	log.Printf("[startup] launcher: NewStressGame completed in %s", time.Since(gameStartedAt))
+	log.Printf("[startup] launcher: stress NewGameWithConfig completed in %s", time.Since(gameStartedAt))
+	launcher.PublishManagerMetrics(game.UnitManager())
 	log.Printf("[startup] launcher: entering ebiten.RunGame after %s total startup prep", time.Since(startedAt))
 
-	if err := ebiten.RunGame(game); err != nil {
-		log.Fatalf("run endless stress: %v", err)
+	runErr := ebiten.RunGame(game)
+	game.Close()
+	if runErr != nil {
+		log.Fatalf("run endless stress: %v", runErr)
 	}
 }
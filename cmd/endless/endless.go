@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"log"
+	"os"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -22,16 +23,26 @@ func main() {
 	rlSeed := int64(1)
 	rlModelPath := ""
 	rlMaxTicks := int64(1200)
+	replayPath := ""
+	recordReplayPath := ""
 	flag.StringVar(&sceneMode, "scene", sceneMode, "scene bootstrap mode: basic or rl_duel")
 	flag.StringVar(&rlScenario, "rl-scenario", rlScenario, "visual rl duel layout: duel_open or duel_with_cover")
 	flag.StringVar(&rlPolicy, "rl-policy", rlPolicy, "visual rl duel shooter policy: lead_strafe or random")
 	flag.Int64Var(&rlSeed, "rl-seed", rlSeed, "seed for visual rl duel layout and stochastic policies")
 	flag.StringVar(&rlModelPath, "rl-model-path", "", "optional path to a saved runtime model artifact; accepts train-stub JSON, GoMLX manifest JSON, GoMLX checkpoint JSON/.bin, or a GoMLX checkpoint directory, and overrides -rl-policy")
 	flag.Int64Var(&rlMaxTicks, "rl-max-ticks", rlMaxTicks, "tick budget for one visual rl duel episode before it times out")
+	flag.StringVar(&replayPath, "replay", "", "optional replay file to play back instead of running -scene")
+	flag.StringVar(&recordReplayPath, "record-replay", "", "optional path where the session command stream is written as a replay file on exit")
 
 	flagsStartedAt := time.Now()
 	runConfig := launcher.ParseRunConfig()
 	log.Printf("[startup] launcher: command-line flags parsed in %s", time.Since(flagsStartedAt))
+	if runConfig.PrintBindings {
+		if err := launcher.PrintDefaultBindings(os.Stdout); err != nil {
+			log.Fatalf("print bindings: %v", err)
+		}
+		return
+	}
 
 	profilerStartedAt := time.Now()
 	profilerSession, err := launcher.StartProfiler(runConfig.Profiling)
@@ -45,6 +56,29 @@ func main() {
 		}
 	}()
 
+	gameConfig := endless.GameConfig{
+		Mode: gamescenario.Mode(sceneMode),
+		RLDuel: rl.VisualDuelScenarioConfig{
+			Scenario:  rlScenario,
+			Policy:    rlPolicy,
+			Seed:      rlSeed,
+			ModelPath: rlModelPath,
+			MaxTicks:  rlMaxTicks,
+		},
+		ReplayPath:       replayPath,
+		RecordReplayPath: recordReplayPath,
+		SettingsPath:     runConfig.SettingsPath,
+		BindingsPath:     runConfig.BindingsPath,
+		ZoomInertia:      runConfig.ZoomInertia,
+		AssetDevDir:      runConfig.AssetDevDir,
+	}
+	if runConfig.Headless.Enabled() {
+		if err := launcher.RunHeadless(gameConfig, runConfig.Headless); err != nil {
+			log.Fatalf("run headless: %v", err)
+		}
+		return
+	}
+
 	windowStartedAt := time.Now()
 	ebiten.SetWindowTitle("Endless")
 	ebiten.SetWindowSize(endless.DefaultScreenWidth, endless.DefaultScreenHeight)
@@ -54,23 +88,20 @@ func main() {
 	log.Printf("[startup] launcher: window configured in %s", time.Since(windowStartedAt))
 
 	gameStartedAt := time.Now()
-	game, err := endless.NewGameWithConfig(endless.GameConfig{
-		Mode: gamescenario.Mode(sceneMode),
-		RLDuel: rl.VisualDuelScenarioConfig{
-			Scenario:  rlScenario,
-			Policy:    rlPolicy,
-			Seed:      rlSeed,
-			ModelPath: rlModelPath,
-			MaxTicks:  rlMaxTicks,
-		},
-	})
+	game, err := endless.NewGameWithConfig(gameConfig)
 	if err != nil {
 		log.Fatalf("create game: %v", err)
 	}
 	log.Printf("[startup] launcher: NewGame completed in %s", time.Since(gameStartedAt))
+	launcher.PublishManagerMetrics(game.UnitManager())
 	log.Printf("[startup] launcher: entering ebiten.RunGame after %s total startup prep", time.Since(startedAt))
 
-	if err := ebiten.RunGame(game); err != nil {
-		log.Fatalf("run endless: %v", err)
+	runErr := ebiten.RunGame(game)
+	game.Close()
+	if err := game.SaveReplayRecording(); err != nil {
+		log.Printf("save replay: %v", err)
+	}
+	if runErr != nil {
+		log.Fatalf("run endless: %v", runErr)
 	}
 }
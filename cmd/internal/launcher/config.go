@@ -1,11 +1,28 @@
 package launcher
 
-import "flag"
+import (
+	"flag"
+	"io"
+
+	"github.com/unng-lab/endless/pkg/endless"
+	"github.com/unng-lab/endless/pkg/endless/input"
+)
 
 // RunConfig groups every command-line toggle shared by the desktop launchers so each cmd can
 // choose its own game scenario while still exposing the same profiling surface.
 type RunConfig struct {
 	Profiling ProfilingConfig
+	Headless  HeadlessConfig
+	// SettingsPath is the per-user settings file of the desktop window; empty disables it.
+	SettingsPath string
+	// BindingsPath is the per-user input bindings file; empty or missing keeps the defaults.
+	BindingsPath string
+	// PrintBindings asks the launcher to print the default bindings file and exit.
+	PrintBindings bool
+	// AssetDevDir reads and hot-reloads assets from a source checkout; empty uses the embedded ones.
+	AssetDevDir string
+	// ZoomInertia makes wheel zoom glide in the desktop window.
+	ZoomInertia bool
 }
 
 // ParseRunConfig binds and parses the shared profiling flags exactly once for the current
@@ -16,7 +33,27 @@ func ParseRunConfig() RunConfig {
 	flag.StringVar(&config.Profiling.CPUProfilePath, "cpuprofile", "", "write CPU profile to file")
 	flag.StringVar(&config.Profiling.HeapProfilePath, "memprofile", "", "write heap profile to file on shutdown")
 	flag.StringVar(&config.Profiling.TracePath, "traceprofile", "", "write runtime trace to file")
-	flag.StringVar(&config.Profiling.PprofAddress, "pprof", "", "serve net/http/pprof on address, for example 127.0.0.1:6060")
+	flag.StringVar(&config.Profiling.PprofAddress, "pprof", "", "serve net/http/pprof and Prometheus /metrics on address, for example 127.0.0.1:6060")
+	flag.Int64Var(&config.Headless.Ticks, "headless-ticks", 0, "run the scene without a window for this many ticks, print a JSON report and exit")
+	flag.IntVar(&config.Headless.UpdateWorkers, "headless-workers", 0, "unit update workers for -headless-ticks; 0 uses the manager default")
+	flag.Int64Var(&config.Headless.ProgressInterval, "headless-progress", 0, "log headless progress every N ticks; 0 disables progress lines")
+	flag.StringVar(&config.Headless.ReportPath, "headless-report", "", "write the headless JSON report to this file instead of stdout")
+	flag.StringVar(&config.SettingsPath, "settings", endless.DefaultSettingsPath(), "file that keeps camera bookmarks between sessions; empty disables persistence")
+	flag.StringVar(&config.BindingsPath, "bindings", endless.DefaultBindingsPath(), "input bindings file that overrides the default keys, buttons and gamepad controls per action")
+	flag.BoolVar(&config.PrintBindings, "print-bindings", false, "print the default input bindings as a bindings file template and exit")
+	flag.StringVar(&config.AssetDevDir, "asset-dev", "", "assets directory of a source checkout, such as pkg/assets, to read images and the manifest from and reload them when they change")
+	flag.BoolVar(&config.ZoomInertia, "zoom-inertia", false, "let mouse wheel zoom keep gliding briefly after each notch")
 	flag.Parse()
 	return config
 }
+
+// PrintDefaultBindings writes the complete default bindings file, which players can save as
+// -bindings and trim down to the actions they want to change.
+func PrintDefaultBindings(w io.Writer) error {
+	payload, err := input.MarshalBindings(endless.DefaultInputBindings())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(payload)
+	return err
+}
@@ -0,0 +1,78 @@
+package launcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/unng-lab/endless/pkg/endless"
+	"github.com/unng-lab/endless/pkg/endless/headless"
+)
+
+// HeadlessConfig describes the window-less batch mode shared by the desktop launchers. A zero
+// tick count keeps the regular Ebiten window.
+type HeadlessConfig struct {
+	Ticks            int64
+	UpdateWorkers    int
+	ProgressInterval int64
+	ReportPath       string
+}
+
+// Enabled reports whether the launcher should skip the window and run the scene headlessly.
+func (config HeadlessConfig) Enabled() bool {
+	return config.Ticks > 0
+}
+
+// RunHeadless simulates the configured scene without opening a window and writes the run report
+// as indented JSON. Interrupting the process stops the run early and still writes the partial
+// report, which keeps long batch jobs inspectable.
+func RunHeadless(gameConfig endless.GameConfig, config HeadlessConfig) error {
+	runner, err := endless.NewHeadlessRunner(gameConfig, config.UpdateWorkers)
+	if err != nil {
+		return err
+	}
+	defer runner.Close()
+	runner.SetProgressInterval(config.ProgressInterval)
+	PublishManagerMetrics(runner.Manager())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Printf("[headless] scene=%s ticks=%d", gameConfig.Mode, config.Ticks)
+	report, runErr := runner.Run(ctx, config.Ticks)
+	if err := writeHeadlessReport(config.ReportPath, report); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return fmt.Errorf("headless run stopped after %d ticks: %w", report.Ticks, runErr)
+	}
+
+	log.Printf(
+		"[headless] done ticks=%d elapsed=%s ticks_per_second=%.1f p99=%s",
+		report.Ticks,
+		report.Elapsed,
+		report.TicksPerSecond,
+		report.TickTime.P99,
+	)
+	return nil
+}
+
+func writeHeadlessReport(path string, report headless.Report) error {
+	payload, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode headless report: %w", err)
+	}
+	payload = append(payload, '\n')
+
+	if path == "" {
+		_, err = os.Stdout.Write(payload)
+		return err
+	}
+	if err := os.WriteFile(path, payload, 0o644); err != nil {
+		return fmt.Errorf("write headless report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package launcher
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/unng-lab/endless/pkg/unit"
+)
+
+var (
+	// metricsManager is the simulation whose metrics the pprof server exposes at /metrics. It is
+	// published after the game or headless runner is built, which happens after the profiler
+	// started, so scrapes in between get a 503 instead of an empty registry.
+	metricsManager   atomic.Pointer[unit.Manager]
+	metricsRouteOnce sync.Once
+)
+
+// PublishManagerMetrics selects the unit manager served at /metrics on the -pprof server. It
+// is a no-op for the scrape side while -pprof is not set.
+func PublishManagerMetrics(manager *unit.Manager) {
+	metricsManager.Store(manager)
+}
+
+func registerMetricsRoute() {
+	metricsRouteOnce.Do(func() {
+		http.HandleFunc("/metrics", serveMetrics)
+	})
+}
+
+// serveMetrics writes the last published tick metrics in the Prometheus text format.
+func serveMetrics(w http.ResponseWriter, _ *http.Request) {
+	manager := metricsManager.Load()
+	if manager == nil {
+		http.Error(w, "no simulation is running yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := manager.Metrics().WritePrometheus(w); err != nil {
+		log.Printf("[metrics] write scrape response: %v", err)
+	}
+}
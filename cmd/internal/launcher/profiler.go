@@ -135,14 +135,16 @@ func (s *ProfilerSession) Stop() error {
 
 // startPprofServer launches the standard-library diagnostics handlers in the background so the
 // running Ebiten process can be sampled with go tool pprof while the window stays interactive.
+// The same server exposes the simulation metrics at /metrics for Prometheus scrapes.
 func startPprofServer(address string) error {
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
 		return fmt.Errorf("listen pprof server on %s: %w", address, err)
 	}
 
+	registerMetricsRoute()
 	go func() {
-		log.Printf("pprof HTTP server listening on http://%s/debug/pprof/", address)
+		log.Printf("pprof HTTP server listening on http://%s/debug/pprof/ (metrics at /metrics)", address)
 		if err := http.Serve(listener, nil); err != nil {
 			log.Printf("pprof HTTP server stopped: %v", err)
 		}
@@ -0,0 +1,75 @@
+package assets
+
+import "math"
+
+// Direction is one of the eight facings a directional clip may provide frames for. Screen y
+// grows downwards, so south faces the bottom of the screen.
+type Direction string
+
+const (
+	DirectionEast      Direction = "east"
+	DirectionSouthEast Direction = "south_east"
+	DirectionSouth     Direction = "south"
+	DirectionSouthWest Direction = "south_west"
+	DirectionWest      Direction = "west"
+	DirectionNorthWest Direction = "north_west"
+	DirectionNorth     Direction = "north"
+	DirectionNorthEast Direction = "north_east"
+)
+
+// directions lists the facings clockwise on screen, starting east, so the facing of an angle is
+// one table lookup.
+var directions = [8]Direction{
+	DirectionEast, DirectionSouthEast, DirectionSouth, DirectionSouthWest,
+	DirectionWest, DirectionNorthWest, DirectionNorth, DirectionNorthEast,
+}
+
+// DirectionToward returns the facing closest to the screen-space vector. A zero vector has no
+// direction and reports false.
+func DirectionToward(dx, dy float64) (Direction, bool) {
+	if dx == 0 && dy == 0 {
+		return "", false
+	}
+	octant := int(math.Round(math.Atan2(dy, dx)/(math.Pi/4))) & 7
+	return directions[octant], true
+}
+
+// mirrored is the facing reflected across the vertical axis, which is how a sheet drawn facing
+// east is reused for the west side.
+func (d Direction) mirrored() Direction {
+	switch d {
+	case DirectionEast:
+		return DirectionWest
+	case DirectionWest:
+		return DirectionEast
+	case DirectionNorthEast:
+		return DirectionNorthWest
+	case DirectionNorthWest:
+		return DirectionNorthEast
+	case DirectionSouthEast:
+		return DirectionSouthWest
+	case DirectionSouthWest:
+		return DirectionSouthEast
+	default:
+		return d
+	}
+}
+
+// sideways snaps a diagonal to its horizontal component, the nearest facing a four-direction
+// clip that lacks diagonals can show. Side views read better than front or back views while a
+// unit runs diagonally.
+func (d Direction) sideways() Direction {
+	switch d {
+	case DirectionNorthEast, DirectionSouthEast:
+		return DirectionEast
+	case DirectionNorthWest, DirectionSouthWest:
+		return DirectionWest
+	default:
+		return d
+	}
+}
+
+// westward reports facings a sheet drawn facing east shows mirrored.
+func (d Direction) westward() bool {
+	return d == DirectionWest || d == DirectionNorthWest || d == DirectionSouthWest
+}
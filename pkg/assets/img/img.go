@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"image"
 	"image/png"
+	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
@@ -25,8 +26,62 @@ var (
 
 	mu      sync.RWMutex
 	storage = make(map[string]*ebiten.Image)
+	// sourceDir replaces the embedded raw directory when set, so development builds read the
+	// files artists are editing instead of the copy compiled into the binary.
+	sourceDir string
 )
 
+// SetSourceDir makes every loader read raw assets from dir, laid out like the embedded raw
+// directory, instead of from the binary. An empty dir switches back to the embedded copy. The
+// in-memory cache is dropped so no image from the previous source survives the switch.
+func SetSourceDir(dir string) error {
+	if dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return fmt.Errorf("asset source directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("asset source %q is not a directory", dir)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	sourceDir = dir
+	clear(storage)
+	return nil
+}
+
+// Invalidate forgets the cached images of the given raw assets, in memory and on disk, so the
+// next Img call rebuilds them from the source. Paths are relative to the raw directory, as the
+// watcher reports them.
+func Invalidate(assetPaths ...string) error {
+	root, err := cacheRoot()
+	if err != nil {
+		return err
+	}
+
+	for _, assetPath := range assetPaths {
+		assetName, err := normalizeAssetName(assetPath)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		for key := range storage {
+			if strings.HasPrefix(key, assetName+"/") {
+				delete(storage, key)
+			}
+		}
+		mu.Unlock()
+
+		if err := os.RemoveAll(filepath.Join(root, assetDirName(assetName))); err != nil {
+			return fmt.Errorf("remove cached asset %q: %w", assetName, err)
+		}
+	}
+	return nil
+}
+
 // Img returns an image resized to the requested dimensions.
 // The loader uses a two-level cache:
 //   - in-memory cache avoids repeated decode/upload work during the current run;
@@ -53,11 +108,13 @@ func Img(name string, w, h uint64) (*ebiten.Image, error) {
 		return nil, err
 	}
 
-	if cached, err := loadCachedAsset(cachePath); err == nil {
-		store(key, cached)
-		return cached, nil
-	} else if !os.IsNotExist(err) {
-		return nil, fmt.Errorf("load cached asset %q: %w", assetName, err)
+	if cacheFresh(assetName, cachePath) {
+		if cached, err := loadCachedAsset(cachePath); err == nil {
+			store(key, cached)
+			return cached, nil
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("load cached asset %q: %w", assetName, err)
+		}
 	}
 
 	built, err := buildCachedAsset(assetName, cachePath, int(w), int(h))
@@ -68,15 +125,104 @@ func Img(name string, w, h uint64) (*ebiten.Image, error) {
 	return built, nil
 }
 
+// Source decodes an asset by its path below raw, such as a tile inside one of the craftpix
+// packs, and resizes it to the requested dimensions. Unlike Img it keeps the image on
+// the CPU and bypasses both caches, for assets that are processed further before upload.
+func Source(assetPath string, w, h uint64) (image.Image, error) {
+	assetPath = strings.ReplaceAll(strings.TrimSpace(assetPath), "\\", "/")
+	if !fs.ValidPath(assetPath) || assetPath == "." {
+		return nil, fmt.Errorf("asset path %q is invalid", assetPath)
+	}
+	if w == 0 || h == 0 {
+		return nil, fmt.Errorf("asset %q dimensions must be positive", assetPath)
+	}
+
+	return buildResizedAsset(assetPath, int(w), int(h))
+}
+
+// Check reports why the raw asset at assetPath cannot be read, or nil when it can. It looks in
+// the same place as Source: the source directory when one is set, or else the embedded copy.
+func Check(assetPath string) error {
+	assetPath = strings.ReplaceAll(strings.TrimSpace(assetPath), "\\", "/")
+	if !fs.ValidPath(assetPath) || assetPath == "." {
+		return fmt.Errorf("asset path %q is invalid", assetPath)
+	}
+
+	mu.RLock()
+	dir := sourceDir
+	mu.RUnlock()
+
+	if dir != "" {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(assetPath))); err != nil {
+			return fmt.Errorf("read asset %q: %w", assetPath, err)
+		}
+		return nil
+	}
+	if _, err := fs.Stat(images, path.Join("raw", assetPath)); err != nil {
+		return fmt.Errorf("read embedded asset %q: %w", assetPath, err)
+	}
+	return nil
+}
+
+// CheckName is Check for a name as Img takes it, which only looks at the base name.
+func CheckName(name string) error {
+	assetName, err := normalizeAssetName(name)
+	if err != nil {
+		return err
+	}
+	return Check(assetName)
+}
+
+// readSource returns the raw bytes of an asset from the source directory, or from the embedded
+// copy when none is set.
+func readSource(assetPath string) ([]byte, error) {
+	mu.RLock()
+	dir := sourceDir
+	mu.RUnlock()
+
+	if dir != "" {
+		source, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(assetPath)))
+		if err != nil {
+			return nil, fmt.Errorf("read asset %q: %w", assetPath, err)
+		}
+		return source, nil
+	}
+
+	source, err := images.ReadFile(path.Join("raw", assetPath))
+	if err != nil {
+		return nil, fmt.Errorf("read embedded asset %q: %w", assetPath, err)
+	}
+	return source, nil
+}
+
+// cacheFresh reports whether the disk cache may be used. The cache is trusted for embedded
+// assets as before; with a source directory a cached file older than its source is rebuilt,
+// which also covers edits made while the game was not running.
+func cacheFresh(assetName, cachePath string) bool {
+	mu.RLock()
+	dir := sourceDir
+	mu.RUnlock()
+	if dir == "" {
+		return true
+	}
+
+	source, err := os.Stat(filepath.Join(dir, filepath.FromSlash(assetName)))
+	if err != nil {
+		return false
+	}
+	cached, err := os.Stat(cachePath)
+	return err == nil && !cached.ModTime().Before(source.ModTime())
+}
+
 func buildResizedAsset(assetName string, width, height int) (image.Image, error) {
-	source, err := images.ReadFile(path.Join("raw", assetName))
+	source, err := readSource(assetName)
 	if err != nil {
-		return nil, fmt.Errorf("read embedded asset %q: %w", assetName, err)
+		return nil, err
 	}
 
 	src, _, err := image.Decode(bytes.NewReader(source))
 	if err != nil {
-		return nil, fmt.Errorf("decode embedded asset %q: %w", assetName, err)
+		return nil, fmt.Errorf("decode asset %q: %w", assetName, err)
 	}
 
 	if src.Bounds().Dx() == width && src.Bounds().Dy() == height {
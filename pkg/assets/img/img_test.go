@@ -1,9 +1,13 @@
 package img
 
 import (
+	"bytes"
+	"image"
+	"image/png"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestNormalizeAssetName(t *testing.T) {
@@ -55,3 +59,82 @@ func TestCacheFilePathUsesPerAssetDirectory(t *testing.T) {
 		t.Fatalf("cacheFilePath = %q, want %q", got, want)
 	}
 }
+
+func TestSourceReadsNestedAssetsAndRejectsEscapes(t *testing.T) {
+	t.Parallel()
+
+	const tile = "craftpix-net-381103-free-simple-summer-top-down-vector-tileset/PNG/Top-Down Simple Summer_Ground 08.png"
+	source, err := Source(tile, 16, 16)
+	if err != nil {
+		t.Fatalf("Source() error = %v", err)
+	}
+	if got := source.Bounds().Size(); got.X != 16 || got.Y != 16 {
+		t.Fatalf("Source() size = %v, want 16x16", got)
+	}
+
+	for _, name := range []string{"../img.go", "/small.png", ""} {
+		if _, err := Source(name, 16, 16); err == nil {
+			t.Fatalf("Source(%q) error = nil, want invalid path", name)
+		}
+	}
+}
+
+func TestSourceDirReplacesEmbeddedAssetsAndStalesOlderCache(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ENDLESS_ASSET_CACHE_DIR", t.TempDir())
+	t.Cleanup(func() {
+		_ = SetSourceDir("")
+	})
+
+	sprite := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, sprite); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "edited.png"), encoded.Bytes(), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := SetSourceDir(dir); err != nil {
+		t.Fatalf("SetSourceDir() error = %v", err)
+	}
+
+	source, err := Source("edited.png", 8, 4)
+	if err != nil {
+		t.Fatalf("Source() error = %v", err)
+	}
+	if got := source.Bounds().Size(); got.X != 8 || got.Y != 4 {
+		t.Fatalf("Source() size = %v, want 8x4", got)
+	}
+
+	cachePath, err := cacheFilePath("edited.png", 8, 4)
+	if err != nil {
+		t.Fatalf("cacheFilePath() error = %v", err)
+	}
+	if cacheFresh("edited.png", cachePath) {
+		t.Fatalf("cacheFresh() = true without a cached file")
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(cachePath, encoded.Bytes(), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if !cacheFresh("edited.png", cachePath) {
+		t.Fatalf("cacheFresh() = false for a cache written after the source")
+	}
+
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, "edited.png"), later, later); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	if cacheFresh("edited.png", cachePath) {
+		t.Fatalf("cacheFresh() = true for a cache older than the edited source")
+	}
+
+	if err := Invalidate("nested/edited.png"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+		t.Fatalf("cached file after Invalidate() stat error = %v, want not exist", err)
+	}
+}
@@ -0,0 +1,143 @@
+package img
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// fileStamp is what the watcher compares between two scans. Editors that save in place keep
+// the size but bump the modification time, and copying a file over another keeps the time but
+// usually changes the size.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher polls files and directory trees for changes. Polling a few thousand files twice a
+// second is cheap, needs no platform-specific notification API and works the same on network
+// drives, which matters more here than instant notification.
+type Watcher struct {
+	paths    []string
+	interval time.Duration
+	changes  chan []string
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	known map[string]fileStamp
+}
+
+// Watch starts polling the given files and directories. The first scan runs before Watch
+// returns, so only changes made after it are reported.
+func Watch(interval time.Duration, paths ...string) (*Watcher, error) {
+	w := &Watcher{
+		paths:    slices.Clone(paths),
+		interval: interval,
+		changes:  make(chan []string, 1),
+		stop:     make(chan struct{}),
+	}
+	known, err := w.scan()
+	if err != nil {
+		return nil, err
+	}
+	w.known = known
+
+	go w.run()
+	return w, nil
+}
+
+// Changes delivers the paths that were added, modified or removed, sorted. Batches are merged
+// while nobody receives them, so a slow consumer still sees every change once.
+func (w *Watcher) Changes() <-chan []string {
+	return w.changes
+}
+
+// Close stops polling.
+func (w *Watcher) Close() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+}
+
+func (w *Watcher) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var pending []string
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+
+		known, err := w.scan()
+		if err != nil {
+			// A file vanishing mid-scan is the usual cause; the next scan sees a settled tree.
+			continue
+		}
+		for _, changed := range changedPaths(w.known, known) {
+			if !slices.Contains(pending, changed) {
+				pending = append(pending, changed)
+			}
+		}
+		w.known = known
+		if len(pending) == 0 {
+			continue
+		}
+
+		select {
+		case w.changes <- sortedPaths(pending):
+			pending = nil
+		default:
+		}
+	}
+}
+
+func (w *Watcher) scan() (map[string]fileStamp, error) {
+	known := make(map[string]fileStamp)
+	for _, root := range w.paths {
+		err := filepath.WalkDir(root, func(current string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			known[current] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return known, nil
+}
+
+func changedPaths(before, after map[string]fileStamp) []string {
+	var changed []string
+	for current, stamp := range after {
+		if previous, ok := before[current]; !ok || previous != stamp {
+			changed = append(changed, current)
+		}
+	}
+	for previous := range before {
+		if _, ok := after[previous]; !ok {
+			changed = append(changed, previous)
+		}
+	}
+	return sortedPaths(changed)
+}
+
+func sortedPaths(paths []string) []string {
+	sorted := slices.Clone(paths)
+	slices.Sort(sorted)
+	return sorted
+}
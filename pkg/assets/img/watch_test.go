@@ -0,0 +1,63 @@
+package img
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatcherReportsAddedModifiedAndRemovedFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "kept.png")
+	removed := filepath.Join(dir, "removed.png")
+	for _, path := range []string{kept, removed} {
+		if err := os.WriteFile(path, []byte("a"), 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	watcher, err := Watch(10*time.Millisecond, dir)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer watcher.Close()
+
+	added := filepath.Join(dir, "nested", "added.png")
+	if err := os.MkdirAll(filepath.Dir(added), 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(added, []byte("b"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.WriteFile(kept, []byte("changed"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.Remove(removed); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	want := []string{kept, added, removed}
+	var got []string
+	deadline := time.After(5 * time.Second)
+	for len(got) < len(want) {
+		select {
+		case changed := <-watcher.Changes():
+			for _, path := range changed {
+				if !slices.Contains(got, path) {
+					got = append(got, path)
+				}
+			}
+		case <-deadline:
+			t.Fatalf("Changes() = %v before timeout, want %v", got, want)
+		}
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Fatalf("Changes() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,406 @@
+package assets
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"image"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/unng-lab/endless/pkg/assets/img"
+)
+
+// manifestVersion is bumped when the manifest layout changes incompatibly.
+const manifestVersion = 1
+
+// Animation clip names the renderer knows. A sprite only has to provide the clips its units
+// play; ClipRun is required for every sprite a unit kind uses, and the units fall back to it or
+// to ClipIdle for the others.
+const (
+	ClipIdle  = "idle"
+	ClipRun   = "run"
+	ClipFire  = "fire"
+	ClipHit   = "hit"
+	ClipDeath = "death"
+)
+
+var knownClips = []string{ClipIdle, ClipRun, ClipFire, ClipHit, ClipDeath}
+
+//go:embed manifest.json
+var manifestFile []byte
+
+// Manifest describes every image the game draws from files: the terrain atlas of each quality,
+// the terrain transition pieces, the unit sprite sheets with their frame rectangles and
+// animation clips, and how large each unit kind appears on the map. Adding a unit kind with a
+// sprite only needs a sheet in the raw directory and entries here.
+type Manifest struct {
+	Version int                    `json:"version"`
+	Tiles   TileManifest           `json:"tiles"`
+	Sprites map[string]*Sprite     `json:"sprites"`
+	Units   map[string]UnitVisuals `json:"units"`
+}
+
+// TileManifest lists the terrain atlas of each quality and the transition pieces cut from it.
+type TileManifest struct {
+	Variants    map[Quality]AtlasConfig `json:"variants"`
+	Transitions TransitionMetadata      `json:"transitions"`
+}
+
+// Sprite describes one unit sprite sheet. Frames are rectangles in the sheet's Width by Height
+// base layout; every quality variant scales the whole sheet, frames included, by its Scale.
+//
+// A sprite naming another one as Layout shares its size, frames, clips and variant scales and
+// only brings its own file, which suits recoloured copies of one sheet.
+type Sprite struct {
+	File     string                    `json:"file"`
+	Layout   string                    `json:"layout,omitempty"`
+	Width    int                       `json:"width,omitempty"`
+	Height   int                       `json:"height,omitempty"`
+	Variants map[Quality]SpriteVariant `json:"variants,omitempty"`
+	Frames   []FrameRect               `json:"frames,omitempty"`
+	Clips    map[string]Clip           `json:"clips,omitempty"`
+}
+
+// SpriteVariant is the sheet of one quality. An empty File reuses the sprite's file.
+type SpriteVariant struct {
+	File  string `json:"file,omitempty"`
+	Scale int    `json:"scale"`
+}
+
+// FrameRect is one frame as [x, y, width, height] in the base layout.
+type FrameRect [4]int
+
+// Rect returns the frame in a sheet scaled by the given factor.
+func (f FrameRect) Rect(scale int) image.Rectangle {
+	return image.Rect(f[0]*scale, f[1]*scale, (f[0]+f[2])*scale, (f[1]+f[3])*scale)
+}
+
+// Clip is one animation: indexes into the sprite's frames, each shown for FrameTicks ticks.
+// Looping clips wrap around; Hold clips stop on their last frame, which suits one-shot actions
+// such as firing or dying.
+//
+// Directions gives the frames of each facing the sheet draws, with the same frame count as
+// Frames. A facing without its own frames uses its horizontal neighbour, then, when Mirror is
+// set, the mirror image of its east or west counterpart, and finally Frames, which are drawn
+// facing east.
+type Clip struct {
+	Frames     []int               `json:"frames,omitempty"`
+	Directions map[Direction][]int `json:"directions,omitempty"`
+	Mirror     bool                `json:"mirror,omitempty"`
+	FrameTicks int                 `json:"frame_ticks"`
+	Hold       bool                `json:"hold,omitempty"`
+}
+
+// Len returns the number of frames of every facing.
+func (c Clip) Len() int {
+	if len(c.Frames) > 0 {
+		return len(c.Frames)
+	}
+	for _, frames := range c.Directions {
+		return len(frames)
+	}
+	return 0
+}
+
+// Ticks returns how long one pass through the clip lasts.
+func (c Clip) Ticks() int {
+	return c.Len() * c.FrameTicks
+}
+
+// facingFrames resolves the frame list of a facing and whether it must be drawn mirrored.
+func (c Clip) facingFrames(facing Direction) ([]int, bool) {
+	for _, candidate := range [2]Direction{facing, facing.sideways()} {
+		if frames, ok := c.Directions[candidate]; ok {
+			return frames, false
+		}
+		if !c.Mirror {
+			continue
+		}
+		if frames, ok := c.Directions[candidate.mirrored()]; ok {
+			return frames, true
+		}
+	}
+	if len(c.Frames) > 0 {
+		return c.Frames, c.Mirror && facing.westward()
+	}
+	// Validation guarantees either Frames or at least one facing.
+	for _, fallback := range directions {
+		if frames, ok := c.Directions[fallback]; ok {
+			return frames, false
+		}
+	}
+	return nil, false
+}
+
+// UnitVisuals sizes a unit kind on the map in tiles. AnchorY is the share of the height above
+// the unit's position, so feet stand on the tile centre. Sprite names the sheet to draw; kinds
+// without one are drawn by the renderer's built-in shapes.
+type UnitVisuals struct {
+	Sprite      string  `json:"sprite,omitempty"`
+	WidthTiles  float64 `json:"width_tiles"`
+	HeightTiles float64 `json:"height_tiles"`
+	AnchorY     float64 `json:"anchor_y"`
+}
+
+// embeddedManifest is the manifest built into the binary. It is parsed and validated once.
+var embeddedManifest = sync.OnceValues(func() (*Manifest, error) {
+	return ParseManifest(manifestFile)
+})
+
+// manifestOverride replaces the embedded manifest while assets are edited on disk.
+var manifestOverride atomic.Pointer[Manifest]
+
+// DefaultManifest returns the manifest the game draws with: the one installed by UseManifest,
+// or else the one embedded in the binary. The game calls it at startup so a broken manifest,
+// including one that names a file missing from the raw assets, stops the game with its
+// validation error instead of surfacing as missing sprites later.
+func DefaultManifest() (*Manifest, error) {
+	if manifest := manifestOverride.Load(); manifest != nil {
+		return manifest, nil
+	}
+	return embeddedManifest()
+}
+
+// UseManifest makes DefaultManifest return the given manifest, or the embedded one again for
+// nil. Atlases and renderers built earlier keep what they loaded until they are reloaded.
+func UseManifest(manifest *Manifest) {
+	manifestOverride.Store(manifest)
+}
+
+// LoadManifestFile reads and validates a manifest from disk, such as the source copy of the
+// embedded one while it is being edited.
+func LoadManifestFile(path string) (*Manifest, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read asset manifest: %w", err)
+	}
+	manifest, err := ParseManifest(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// ParseManifest decodes and validates a manifest. Unknown fields are rejected so a misspelt key
+// does not silently fall back to a default.
+func ParseManifest(payload []byte) (*Manifest, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+
+	var manifest Manifest
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("decode asset manifest: %w", err)
+	}
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("asset manifest: unsupported version %d, want %d", manifest.Version, manifestVersion)
+	}
+	if err := manifest.resolveLayouts(); err != nil {
+		return nil, err
+	}
+	if err := manifest.validate(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// Sprite returns the named sprite with its layout resolved.
+func (m *Manifest) Sprite(name string) (*Sprite, bool) {
+	sprite, ok := m.Sprites[name]
+	return sprite, ok
+}
+
+// UnitVisuals returns the map size and sprite of a unit kind.
+func (m *Manifest) UnitVisuals(kind string) (UnitVisuals, bool) {
+	visuals, ok := m.Units[kind]
+	return visuals, ok
+}
+
+// resolveLayouts copies the shared layout into every sprite that names one. Layouts do not
+// chain, which keeps a sprite's frames one lookup away from the sheet that defines them.
+func (m *Manifest) resolveLayouts() error {
+	for name, sprite := range m.Sprites {
+		if sprite == nil {
+			return fmt.Errorf("asset manifest: sprite %q is empty", name)
+		}
+		if sprite.Layout == "" {
+			continue
+		}
+		layout, ok := m.Sprites[sprite.Layout]
+		if !ok || layout == nil {
+			return fmt.Errorf("asset manifest: sprite %q uses unknown layout %q", name, sprite.Layout)
+		}
+		if layout.Layout != "" {
+			return fmt.Errorf("asset manifest: sprite %q uses layout %q, which has a layout of its own", name, sprite.Layout)
+		}
+		if sprite.Width != 0 || sprite.Height != 0 || len(sprite.Frames) > 0 || len(sprite.Clips) > 0 {
+			return fmt.Errorf("asset manifest: sprite %q uses layout %q and must not list its own size, frames or clips", name, sprite.Layout)
+		}
+
+		sprite.Width, sprite.Height = layout.Width, layout.Height
+		sprite.Frames, sprite.Clips = layout.Frames, layout.Clips
+		if sprite.Variants == nil {
+			sprite.Variants = make(map[Quality]SpriteVariant, len(layout.Variants))
+			for quality, variant := range layout.Variants {
+				sprite.Variants[quality] = SpriteVariant{Scale: variant.Scale}
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Manifest) validate() error {
+	for _, quality := range qualities {
+		variant, ok := m.Tiles.Variants[quality]
+		if !ok {
+			return fmt.Errorf("asset manifest: tiles have no %s variant", quality)
+		}
+		if variant.FileName == "" || variant.TileSize <= 0 {
+			return fmt.Errorf("asset manifest: tiles %s variant needs a file and a positive tile size", quality)
+		}
+	}
+	if err := m.Tiles.Transitions.validate(); err != nil {
+		return err
+	}
+
+	for name, sprite := range m.Sprites {
+		if err := sprite.validate(); err != nil {
+			return fmt.Errorf("asset manifest: sprite %q: %w", name, err)
+		}
+	}
+
+	for kind, visuals := range m.Units {
+		if visuals.WidthTiles <= 0 || visuals.HeightTiles <= 0 {
+			return fmt.Errorf("asset manifest: unit %q needs a positive size", kind)
+		}
+		if visuals.AnchorY < 0 || visuals.AnchorY > 1 {
+			return fmt.Errorf("asset manifest: unit %q anchor_y %g is outside 0..1", kind, visuals.AnchorY)
+		}
+		if visuals.Sprite == "" {
+			continue
+		}
+		sprite, ok := m.Sprites[visuals.Sprite]
+		if !ok {
+			return fmt.Errorf("asset manifest: unit %q uses unknown sprite %q", kind, visuals.Sprite)
+		}
+		if _, ok := sprite.Clips[ClipRun]; !ok {
+			return fmt.Errorf("asset manifest: unit %q uses sprite %q, which has no %s clip", kind, visuals.Sprite, ClipRun)
+		}
+	}
+	return m.validateFiles()
+}
+
+// validateFiles checks that every file the manifest names can be read, the way the loaders
+// will read it: atlases and sprite sheets by name, transition pieces by their path below raw.
+// Files come from the asset source directory when one is set, or else from the binary.
+func (m *Manifest) validateFiles() error {
+	for _, quality := range qualities {
+		file := m.Tiles.Variants[quality].FileName
+		if err := img.CheckName(file); err != nil {
+			return fmt.Errorf("asset manifest: tiles %s variant file %q: %w", quality, file, err)
+		}
+	}
+	for _, piece := range m.Tiles.Transitions.Pieces {
+		if err := img.Check(piece.Source); err != nil {
+			return fmt.Errorf("asset manifest: terrain transition %s %s source %q: %w", piece.Shape, piece.Side, piece.Source, err)
+		}
+	}
+	for name, sprite := range m.Sprites {
+		for _, quality := range qualities {
+			file, _, _, err := sprite.Sheet(quality)
+			if err != nil {
+				return fmt.Errorf("asset manifest: sprite %q: %w", name, err)
+			}
+			if err := img.CheckName(file); err != nil {
+				return fmt.Errorf("asset manifest: sprite %q %s variant file %q: %w", name, quality, file, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Sprite) validate() error {
+	if s.File == "" {
+		return fmt.Errorf("no file")
+	}
+	if s.Width <= 0 || s.Height <= 0 {
+		return fmt.Errorf("size %dx%d is not positive", s.Width, s.Height)
+	}
+	for _, quality := range qualities {
+		variant, ok := s.Variants[quality]
+		if !ok {
+			return fmt.Errorf("no %s variant", quality)
+		}
+		if variant.Scale <= 0 {
+			return fmt.Errorf("%s variant scale %d is not positive", quality, variant.Scale)
+		}
+	}
+
+	bounds := image.Rect(0, 0, s.Width, s.Height)
+	for index, frame := range s.Frames {
+		rect := frame.Rect(1)
+		if rect.Empty() || !rect.In(bounds) {
+			return fmt.Errorf("frame %d %v is empty or outside the %dx%d sheet", index, frame, s.Width, s.Height)
+		}
+	}
+	for name, clip := range s.Clips {
+		if !slices.Contains(knownClips, name) {
+			return fmt.Errorf("unknown clip %q, want one of %v", name, knownClips)
+		}
+		if clip.Len() == 0 || clip.FrameTicks <= 0 {
+			return fmt.Errorf("clip %q needs frames and positive frame_ticks", name)
+		}
+		sequences := [][]int{clip.Frames}
+		for facing, frames := range clip.Directions {
+			if !slices.Contains(directions[:], facing) {
+				return fmt.Errorf("clip %q has unknown direction %q", name, facing)
+			}
+			if len(frames) != clip.Len() {
+				return fmt.Errorf("clip %q %s has %d frames, want %d like the other facings", name, facing, len(frames), clip.Len())
+			}
+			sequences = append(sequences, frames)
+		}
+		for _, frames := range sequences {
+			for _, frame := range frames {
+				if frame < 0 || frame >= len(s.Frames) {
+					return fmt.Errorf("clip %q frame %d is out of range, the sprite has %d frames", name, frame, len(s.Frames))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Sheet returns the file and the resized sheet dimensions of one quality.
+func (s *Sprite) Sheet(quality Quality) (string, int, int, error) {
+	variant, ok := s.Variants[quality]
+	if !ok {
+		return "", 0, 0, fmt.Errorf("sprite %q has no %s variant", s.File, quality)
+	}
+	file := variant.File
+	if file == "" {
+		file = s.File
+	}
+	return file, s.Width * variant.Scale, s.Height * variant.Scale, nil
+}
+
+// FrameRect returns one frame of a clip for the facing in the sheet of the given quality, and
+// whether it has to be drawn mirrored horizontally.
+func (s *Sprite) FrameRect(clip string, facing Direction, clipFrame int, quality Quality) (image.Rectangle, bool, error) {
+	frames, ok := s.Clips[clip]
+	if !ok {
+		return image.Rectangle{}, false, fmt.Errorf("sprite %q has no %s clip", s.File, clip)
+	}
+	sequence, mirrored := frames.facingFrames(facing)
+	if clipFrame < 0 || clipFrame >= len(sequence) {
+		return image.Rectangle{}, false, fmt.Errorf("sprite %q %s frame %d is out of range", s.File, clip, clipFrame)
+	}
+	variant, ok := s.Variants[quality]
+	if !ok {
+		return image.Rectangle{}, false, fmt.Errorf("sprite %q has no %s variant", s.File, quality)
+	}
+	return s.Frames[sequence[clipFrame]].Rect(variant.Scale), mirrored, nil
+}
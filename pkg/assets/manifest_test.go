@@ -0,0 +1,160 @@
+package assets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultManifestResolvesSharedLayouts(t *testing.T) {
+	t.Parallel()
+
+	manifest, err := DefaultManifest()
+	if err != nil {
+		t.Fatalf("DefaultManifest() error = %v", err)
+	}
+
+	visuals, ok := manifest.UnitVisuals("runnerfocused")
+	if !ok {
+		t.Fatalf("UnitVisuals(runnerfocused) missing")
+	}
+	sprite, ok := manifest.Sprite(visuals.Sprite)
+	if !ok {
+		t.Fatalf("Sprite(%q) missing", visuals.Sprite)
+	}
+	file, width, height, err := sprite.Sheet(QualityHigh)
+	if err != nil {
+		t.Fatalf("Sheet() error = %v", err)
+	}
+	if file != "runnerfocused.png" || width != 1024 || height != 384 {
+		t.Fatalf("Sheet(high) = %s %dx%d, want runnerfocused.png 1024x384", file, width, height)
+	}
+
+	rect, mirrored, err := sprite.FrameRect(ClipRun, DirectionEast, 1, QualityMedium)
+	if err != nil {
+		t.Fatalf("FrameRect() error = %v", err)
+	}
+	if rect.Min.X != 64 || rect.Min.Y != 64 || rect.Dx() != 64 || rect.Dy() != 64 || mirrored {
+		t.Fatalf("FrameRect(run, east, 1, medium) = %v mirrored=%v, want (64,64)-(128,128) unmirrored", rect, mirrored)
+	}
+	if _, mirrored, _ := sprite.FrameRect(ClipRun, DirectionSouthWest, 1, QualityMedium); !mirrored {
+		t.Fatalf("FrameRect(run, south_west) is not mirrored")
+	}
+}
+
+func TestClipResolvesFacings(t *testing.T) {
+	t.Parallel()
+
+	fourWay := Clip{
+		Directions: map[Direction][]int{
+			DirectionEast:  {0, 1},
+			DirectionSouth: {2, 3},
+			DirectionNorth: {4, 5},
+		},
+		Mirror: true,
+	}
+	cases := []struct {
+		facing   Direction
+		want     int
+		mirrored bool
+	}{
+		{facing: DirectionEast, want: 0},
+		{facing: DirectionNorth, want: 4},
+		{facing: DirectionSouthEast, want: 0},
+		{facing: DirectionWest, want: 0, mirrored: true},
+		{facing: DirectionNorthWest, want: 0, mirrored: true},
+	}
+	for _, tc := range cases {
+		frames, mirrored := fourWay.facingFrames(tc.facing)
+		if frames[0] != tc.want || mirrored != tc.mirrored {
+			t.Fatalf("facingFrames(%s) = %v mirrored=%v, want first frame %d mirrored=%v", tc.facing, frames, mirrored, tc.want, tc.mirrored)
+		}
+	}
+
+	for _, tc := range []struct {
+		dx, dy float64
+		want   Direction
+	}{
+		{dx: 1, dy: 0, want: DirectionEast},
+		{dx: 1, dy: 1, want: DirectionSouthEast},
+		{dx: -0.2, dy: -1, want: DirectionNorth},
+		{dx: -1, dy: 0.1, want: DirectionWest},
+	} {
+		if got, ok := DirectionToward(tc.dx, tc.dy); !ok || got != tc.want {
+			t.Fatalf("DirectionToward(%g, %g) = %s, want %s", tc.dx, tc.dy, got, tc.want)
+		}
+	}
+	if _, ok := DirectionToward(0, 0); ok {
+		t.Fatalf("DirectionToward(0, 0) reported a direction")
+	}
+}
+
+func TestParseManifestReportsInvalidEntries(t *testing.T) {
+	t.Parallel()
+
+	const tiles = `"tiles": {"variants": {"low": {"file": "small.png", "tile_size": 16}, "medium": {"file": "normal.png", "tile_size": 32}, "high": {"file": "normal.png", "tile_size": 64}},
+		"transitions": {"pieces": [{"shape": "edge", "side": "north", "source": "white.jpg"}, {"shape": "corner", "side": "north_east", "source": "empty.jpg"}]}}`
+	const variants = `"variants": {"low": {"scale": 1}, "medium": {"scale": 2}, "high": {"scale": 4}}`
+
+	cases := []struct {
+		name    string
+		payload string
+		want    string
+	}{
+		{
+			name:    "valid",
+			payload: `{"version": 1, ` + tiles + `, "sprites": {"s": {"file": "runner.png", "width": 32, "height": 32, ` + variants + `, "frames": [[0, 0, 32, 32]], "clips": {"run": {"frames": [0], "frame_ticks": 4}}}}, "units": {"k": {"sprite": "s", "width_tiles": 1, "height_tiles": 1, "anchor_y": 0.5}}}`,
+		},
+		{
+			name:    "unknown field",
+			payload: `{"version": 1, ` + tiles + `, "sprite": {}}`,
+			want:    `unknown field "sprite"`,
+		},
+		{
+			name:    "frame outside sheet",
+			payload: `{"version": 1, ` + tiles + `, "sprites": {"s": {"file": "runner.png", "width": 32, "height": 32, ` + variants + `, "frames": [[16, 0, 32, 32]]}}}`,
+			want:    `sprite "s": frame 0`,
+		},
+		{
+			name:    "clip frame out of range",
+			payload: `{"version": 1, ` + tiles + `, "sprites": {"s": {"file": "runner.png", "width": 32, "height": 32, ` + variants + `, "frames": [[0, 0, 32, 32]], "clips": {"run": {"frames": [1], "frame_ticks": 4}}}}}`,
+			want:    `clip "run" frame 1 is out of range`,
+		},
+		{
+			name:    "unknown clip",
+			payload: `{"version": 1, ` + tiles + `, "sprites": {"s": {"file": "runner.png", "width": 32, "height": 32, ` + variants + `, "frames": [[0, 0, 32, 32]], "clips": {"walk": {"frames": [0], "frame_ticks": 4}}}}}`,
+			want:    `unknown clip "walk"`,
+		},
+		{
+			name:    "missing variant",
+			payload: `{"version": 1, ` + tiles + `, "sprites": {"s": {"file": "runner.png", "width": 32, "height": 32, "variants": {"low": {"scale": 1}}}}}`,
+			want:    `no medium variant`,
+		},
+		{
+			name:    "missing sprite file",
+			payload: `{"version": 1, ` + tiles + `, "sprites": {"s": {"file": "runner.png", "width": 32, "height": 32, "variants": {"low": {"scale": 1}, "medium": {"scale": 2}, "high": {"scale": 4, "file": "missing.png"}}, "frames": [[0, 0, 32, 32]]}}}`,
+			want:    `sprite "s" high variant file "missing.png"`,
+		},
+		{
+			name:    "missing transition source",
+			payload: `{"version": 1, ` + strings.Replace(tiles, "empty.jpg", "pieces/corner.png", 1) + `}`,
+			want:    `terrain transition corner north_east source "pieces/corner.png"`,
+		},
+		{
+			name:    "unknown sprite",
+			payload: `{"version": 1, ` + tiles + `, "units": {"k": {"sprite": "s", "width_tiles": 1, "height_tiles": 1, "anchor_y": 0.5}}}`,
+			want:    `unit "k" uses unknown sprite "s"`,
+		},
+	}
+	for _, tc := range cases {
+		_, err := ParseManifest([]byte(tc.payload))
+		if tc.want == "" {
+			if err != nil {
+				t.Fatalf("ParseManifest(%s) error = %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("ParseManifest(%s) error = %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
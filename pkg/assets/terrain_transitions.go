@@ -0,0 +1,246 @@
+package assets
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/unng-lab/endless/pkg/assets/img"
+)
+
+// TransitionShape tells which kind of terrain transition a sub-tile draws.
+type TransitionShape string
+
+const (
+	// TransitionEdge covers one whole side of the tile.
+	TransitionEdge TransitionShape = "edge"
+	// TransitionCorner covers one corner the other terrain only touches diagonally.
+	TransitionCorner TransitionShape = "corner"
+)
+
+// transitionShapes fixes the index of each shape in a transitionSet.
+var transitionShapes = [2]TransitionShape{TransitionEdge, TransitionCorner}
+
+// transitionSides names the four positions of each shape clockwise, starting at north for
+// edges and at north-east for corners. A side's index is its bit in the world's edge and
+// corner masks and the number of quarter turns from the first position.
+var transitionSides = map[TransitionShape][4]string{
+	TransitionEdge:   {"north", "east", "south", "west"},
+	TransitionCorner: {"north_east", "south_east", "south_west", "north_west"},
+}
+
+// TransitionPiece maps one source image onto the transition it represents. Source is a path
+// below the raw asset directory.
+type TransitionPiece struct {
+	Shape  TransitionShape `json:"shape"`
+	Side   string          `json:"side"`
+	Source string          `json:"source"`
+}
+
+// TransitionMetadata describes the terrain transition sub-tiles. Only one piece per shape is
+// required; the missing sides are produced by rotating a listed piece, so an art pack with one
+// edge and one corner is enough. The pieces act as masks over the atlas tile TextureTile, which
+// keeps transitions in the same texture as the terrain they grow out of.
+type TransitionMetadata struct {
+	TextureTile int               `json:"texture_tile"`
+	Pieces      []TransitionPiece `json:"pieces"`
+}
+
+// validate checks that every piece names a known side once and that both shapes have a piece
+// to rotate the missing sides from.
+func (m TransitionMetadata) validate() error {
+	if m.TextureTile < 0 || m.TextureTile >= tilesPerRow*tilesPerRow {
+		return fmt.Errorf("terrain transitions: texture tile %d is out of range", m.TextureTile)
+	}
+
+	seen := make(map[TransitionShape][4]bool)
+	for _, piece := range m.Pieces {
+		position, err := piece.position()
+		if err != nil {
+			return err
+		}
+		if piece.Source == "" {
+			return fmt.Errorf("terrain transitions: %s %s has no source", piece.Shape, piece.Side)
+		}
+		sides := seen[piece.Shape]
+		if sides[position] {
+			return fmt.Errorf("terrain transitions: %s %s is listed twice", piece.Shape, piece.Side)
+		}
+		sides[position] = true
+		seen[piece.Shape] = sides
+	}
+	for _, shape := range transitionShapes {
+		if _, ok := seen[shape]; !ok {
+			return fmt.Errorf("terrain transitions: no %s piece", shape)
+		}
+	}
+	return nil
+}
+
+func (p TransitionPiece) position() (int, error) {
+	sides, ok := transitionSides[p.Shape]
+	if !ok {
+		return 0, fmt.Errorf("terrain transitions: unknown shape %q", p.Shape)
+	}
+	for position, side := range sides {
+		if side == p.Side {
+			return position, nil
+		}
+	}
+	return 0, fmt.Errorf("terrain transitions: unknown %s side %q", p.Shape, p.Side)
+}
+
+// transitionSet holds the composed transition images of one quality, indexed by shape and by
+// the four-bit side mask. Index zero of each shape stays nil.
+type transitionSet [len(transitionShapes)][16]*ebiten.Image
+
+// TransitionImage returns the sub-tile drawing every side in mask, a world.EdgeMask for edges
+// or a world.CornerMask for corners. The image is white-on-texture like the atlas tiles, so
+// callers tint it with the colour of the terrain it shows.
+func (a *TileAtlas) TransitionImage(shape TransitionShape, mask uint8, quality Quality) (*ebiten.Image, error) {
+	if mask == 0 || mask > 15 {
+		return nil, fmt.Errorf("transition mask %d is out of range", mask)
+	}
+	shapeIndex := -1
+	for index, known := range transitionShapes {
+		if known == shape {
+			shapeIndex = index
+		}
+	}
+	if shapeIndex < 0 {
+		return nil, fmt.Errorf("unknown transition shape %q", shape)
+	}
+
+	set, err := a.ensureTransitions(quality)
+	if err != nil {
+		return nil, err
+	}
+	return set[shapeIndex][mask], nil
+}
+
+// ensureTransitions composes the transition images of one quality on first use: the masks of
+// every side combination are cut out of the texture tile. The build runs once per quality
+// under its own lock, because it reads atlas tiles through TileImage.
+func (a *TileAtlas) ensureTransitions(quality Quality) (*transitionSet, error) {
+	a.transitionsMu.Lock()
+	defer a.transitionsMu.Unlock()
+
+	if set := a.transitions[quality]; set != nil {
+		return set, nil
+	}
+
+	texture, err := a.TileImage(a.transitionMeta.TextureTile, quality)
+	if err != nil {
+		return nil, err
+	}
+	tileSize := a.TileSize(quality)
+	masks, err := buildTransitionMasks(a.transitionMeta, tileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &transitionSet{}
+	for shapeIndex := range masks {
+		for mask := 1; mask < len(masks[shapeIndex]); mask++ {
+			composed := ebiten.NewImage(tileSize, tileSize)
+			composed.DrawImage(texture, nil)
+			composed.DrawImage(ebiten.NewImageFromImage(masks[shapeIndex][mask]), &ebiten.DrawImageOptions{Blend: ebiten.BlendDestinationIn})
+			set[shapeIndex][mask] = composed
+		}
+	}
+	a.transitions[quality] = set
+	return set, nil
+}
+
+// buildTransitionMasks loads every listed piece at the tile size, rotates listed pieces into
+// the missing sides and unions the sides of each mask value.
+func buildTransitionMasks(metadata TransitionMetadata, tileSize int) ([len(transitionShapes)][16]*image.Alpha, error) {
+	var masks [len(transitionShapes)][16]*image.Alpha
+	for shapeIndex, shape := range transitionShapes {
+		var sides [4]*image.Alpha
+		for _, piece := range metadata.Pieces {
+			if piece.Shape != shape {
+				continue
+			}
+			position, err := piece.position()
+			if err != nil {
+				return masks, err
+			}
+			source, err := img.Source(piece.Source, uint64(tileSize), uint64(tileSize))
+			if err != nil {
+				return masks, fmt.Errorf("load %s %s transition: %w", piece.Shape, piece.Side, err)
+			}
+			sides[position] = transitionPieceMask(source)
+		}
+		fillRotatedSides(&sides)
+
+		for mask := 1; mask < 16; mask++ {
+			combined := image.NewAlpha(image.Rect(0, 0, tileSize, tileSize))
+			for position, side := range sides {
+				if mask&(1<<position) != 0 {
+					unionAlpha(combined, side)
+				}
+			}
+			masks[shapeIndex][mask] = combined
+		}
+	}
+	return masks, nil
+}
+
+// transitionPieceMask turns a source tile into a mask of the terrain that grows into the tile.
+// The craftpix ground tiles paint grass over dirt, so the mask keeps the pixels where green
+// outweighs red, which includes the dark outline along the grass border, and drops the dirt.
+func transitionPieceMask(source image.Image) *image.Alpha {
+	bounds := source.Bounds()
+	mask := image.NewAlpha(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			r, g, _, a := source.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			if a > 0 && g >= r {
+				mask.SetAlpha(x, y, color.Alpha{A: uint8(a >> 8)})
+			}
+		}
+	}
+	return mask
+}
+
+// fillRotatedSides derives every missing side from the nearest listed side counter-clockwise
+// of it, turned clockwise by the number of positions between them.
+func fillRotatedSides(sides *[4]*image.Alpha) {
+	listed := *sides
+	for position := range sides {
+		if listed[position] != nil {
+			continue
+		}
+		for turns := 1; turns < 4; turns++ {
+			if source := listed[(position-turns+4)%4]; source != nil {
+				sides[position] = rotateAlphaClockwise(source, turns)
+				break
+			}
+		}
+	}
+}
+
+// rotateAlphaClockwise turns a square mask by the given number of quarter turns.
+func rotateAlphaClockwise(source *image.Alpha, turns int) *image.Alpha {
+	size := source.Rect.Dx()
+	rotated := source
+	for ; turns > 0; turns-- {
+		next := image.NewAlpha(image.Rect(0, 0, size, size))
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				next.SetAlpha(x, y, rotated.AlphaAt(y, size-1-x))
+			}
+		}
+		rotated = next
+	}
+	return rotated
+}
+
+func unionAlpha(dst, src *image.Alpha) {
+	for index, alpha := range src.Pix {
+		dst.Pix[index] = max(dst.Pix[index], alpha)
+	}
+}
@@ -0,0 +1,64 @@
+package assets
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTransitionMetadataRejectsIncompletePieces(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"unknown side": `{"pieces":[{"shape":"edge","side":"up","source":"a.png"},{"shape":"corner","side":"north_east","source":"b.png"}]}`,
+		"duplicate":    `{"pieces":[{"shape":"edge","side":"north","source":"a.png"},{"shape":"edge","side":"north","source":"b.png"},{"shape":"corner","side":"north_east","source":"c.png"}]}`,
+		"no corner":    `{"pieces":[{"shape":"edge","side":"north","source":"a.png"}]}`,
+		"texture tile": `{"texture_tile":256,"pieces":[{"shape":"edge","side":"north","source":"a.png"},{"shape":"corner","side":"north_east","source":"b.png"}]}`,
+	}
+	for name, payload := range cases {
+		var metadata TransitionMetadata
+		if err := json.Unmarshal([]byte(payload), &metadata); err != nil {
+			t.Fatalf("json.Unmarshal(%s) error = %v", name, err)
+		}
+		if err := metadata.validate(); err == nil {
+			t.Fatalf("validate(%s) error = nil, want error", name)
+		} else if !strings.HasPrefix(err.Error(), "terrain transitions") {
+			t.Fatalf("validate(%s) error = %v, want terrain transitions prefix", name, err)
+		}
+	}
+}
+
+func TestBuildTransitionMasksRotatesListedPieces(t *testing.T) {
+	t.Parallel()
+
+	manifest, err := DefaultManifest()
+	if err != nil {
+		t.Fatalf("DefaultManifest() error = %v", err)
+	}
+	const size = 32
+	masks, err := buildTransitionMasks(manifest.Tiles.Transitions, size)
+	if err != nil {
+		t.Fatalf("buildTransitionMasks() error = %v", err)
+	}
+
+	edges := masks[0]
+	// The north and south edges are a listed piece and its half-turn; both must cover their own
+	// border row and leave the middle of the tile open.
+	northSouth := edges[1|4]
+	if northSouth.AlphaAt(size/2, 0).A == 0 || northSouth.AlphaAt(size/2, size-1).A == 0 {
+		t.Fatalf("north+south edge mask leaves a border row open")
+	}
+	if northSouth.AlphaAt(size/2, size/2).A != 0 {
+		t.Fatalf("north+south edge mask covers the tile centre")
+	}
+	east := edges[2]
+	if east.AlphaAt(size-1, size/2).A == 0 || east.AlphaAt(0, size/2).A != 0 {
+		t.Fatalf("east edge mask does not hug the east border")
+	}
+
+	corners := masks[1]
+	southEast := corners[2]
+	if southEast.AlphaAt(size-1, size-1).A == 0 || southEast.AlphaAt(0, 0).A != 0 {
+		t.Fatalf("south-east corner mask is not in the south-east corner")
+	}
+}
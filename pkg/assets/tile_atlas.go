@@ -18,39 +18,98 @@ const (
 	QualityHigh
 )
 
+// qualities lists every quality in ascending order; the manifest must cover each of them.
+var qualities = []Quality{QualityLow, QualityMedium, QualityHigh}
+
+var qualityNames = map[Quality]string{
+	QualityLow:    "low",
+	QualityMedium: "medium",
+	QualityHigh:   "high",
+}
+
+// String returns the name the manifest uses for the quality.
+func (q Quality) String() string {
+	if name, ok := qualityNames[q]; ok {
+		return name
+	}
+	return fmt.Sprintf("quality(%d)", int(q))
+}
+
+func (q Quality) MarshalText() ([]byte, error) {
+	if _, ok := qualityNames[q]; !ok {
+		return nil, fmt.Errorf("unknown quality %d", int(q))
+	}
+	return []byte(q.String()), nil
+}
+
+func (q *Quality) UnmarshalText(text []byte) error {
+	for quality, name := range qualityNames {
+		if name == string(text) {
+			*q = quality
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown quality %q", text)
+}
+
 const tilesPerRow = 16
 
+// AtlasConfig names the atlas file of one quality; the atlas is resized to tilesPerRow tiles of
+// TileSize pixels per side.
 type AtlasConfig struct {
-	FileName string
-	TileSize int
+	FileName string `json:"file"`
+	TileSize int    `json:"tile_size"`
 }
 
+// TileAtlas loads the terrain atlases described by the asset manifest lazily, one quality at a
+// time, and hands out per-tile sub-images of them.
 type TileAtlas struct {
 	mu       sync.Mutex
 	configs  map[Quality]AtlasConfig
 	atlases  map[Quality]*ebiten.Image
 	tileRefs map[Quality]map[int]*ebiten.Image
+	// manifestErr is the reason the manifest could not be used; it fails every atlas load.
+	manifestErr error
+
+	transitionsMu  sync.Mutex
+	transitionMeta TransitionMetadata
+	transitions    map[Quality]*transitionSet
 }
 
+// NewTileAtlas returns an atlas for the current asset manifest. A manifest error is kept and
+// returned by every lookup; the game checks DefaultManifest at startup before it gets here.
 func NewTileAtlas() *TileAtlas {
-	return &TileAtlas{
-		configs: map[Quality]AtlasConfig{
-			QualityLow: {
-				FileName: "small.png",
-				TileSize: 16,
-			},
-			QualityMedium: {
-				FileName: "normal.png",
-				TileSize: 32,
-			},
-			QualityHigh: {
-				FileName: "normal.png",
-				TileSize: 64,
-			},
-		},
-		atlases:  make(map[Quality]*ebiten.Image),
-		tileRefs: make(map[Quality]map[int]*ebiten.Image),
+	atlas := &TileAtlas{}
+	atlas.Reload()
+	return atlas
+}
+
+// Reload drops every loaded atlas, tile and transition image and rereads the tile section of
+// the current manifest, so the next lookup picks up edited files. It must not run while tiles
+// are being drawn.
+func (a *TileAtlas) Reload() {
+	// Transitions are built from atlas tiles under transitionsMu, so take it first as they do.
+	a.transitionsMu.Lock()
+	defer a.transitionsMu.Unlock()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.configs = make(map[Quality]AtlasConfig)
+	a.atlases = make(map[Quality]*ebiten.Image)
+	a.tileRefs = make(map[Quality]map[int]*ebiten.Image)
+	a.transitions = make(map[Quality]*transitionSet)
+	a.transitionMeta = TransitionMetadata{}
+	a.manifestErr = nil
+
+	manifest, err := DefaultManifest()
+	if err != nil {
+		a.manifestErr = err
+		return
 	}
+	for quality, config := range manifest.Tiles.Variants {
+		a.configs[quality] = config
+	}
+	a.transitionMeta = manifest.Tiles.Transitions
 }
 
 func (a *TileAtlas) QualityForScreenSize(screenTileSize float64) Quality {
@@ -109,6 +168,9 @@ func (a *TileAtlas) ensureAtlas(quality Quality) (*ebiten.Image, error) {
 	if atlas := a.atlases[quality]; atlas != nil {
 		return atlas, nil
 	}
+	if a.manifestErr != nil {
+		return nil, a.manifestErr
+	}
 
 	cfg, ok := a.configs[quality]
 	if !ok {
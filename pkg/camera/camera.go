@@ -14,6 +14,8 @@ type Camera struct {
 	scale    float64
 	minScale float64
 	maxScale float64
+
+	motion motion
 }
 
 func New(cfg Config) *Camera {
@@ -46,7 +48,10 @@ func (c *Camera) SetPosition(pos geom.Point) {
 	c.position = pos
 }
 
+// Move pans by a world-space delta. It is the manual pan path, so it also ends a running
+// transition.
 func (c *Camera) Move(dx, dy float64) {
+	c.motion.transition = nil
 	c.position.X += dx
 	c.position.Y += dy
 }
@@ -55,11 +60,18 @@ func (c *Camera) Scale() float64 {
 	return c.scale
 }
 
+// Zoom changes the scale by a relative delta around the cursor, so the world point under the
+// cursor stays put. Like Move it ends a running transition.
 func (c *Camera) Zoom(delta float64, cursor geom.Point) bool {
 	if delta == 0 {
 		return false
 	}
 
+	c.motion.transition = nil
+	return c.zoomAround(delta, cursor)
+}
+
+func (c *Camera) zoomAround(delta float64, cursor geom.Point) bool {
 	newScale := geom.ClampFloat(c.scale*(1+delta), c.minScale, c.maxScale)
 	if geom.AlmostEqual(newScale, c.scale) {
 		return false
@@ -80,6 +92,14 @@ func (c *Camera) ScreenToWorld(screen geom.Point) geom.Point {
 	}
 }
 
+// WorldToScreen is the inverse of ScreenToWorld.
+func (c *Camera) WorldToScreen(world geom.Point) geom.Point {
+	return geom.Point{
+		X: (world.X - c.position.X) * c.scale,
+		Y: (world.Y - c.position.Y) * c.scale,
+	}
+}
+
 func (c *Camera) ViewRect(screenWidth, screenHeight float64) geom.Rect {
 	return geom.Rect{
 		Min: c.position,
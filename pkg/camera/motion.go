@@ -0,0 +1,182 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+const (
+	// zoomInertiaDecay is the share of the zoom velocity kept from one frame to the next.
+	zoomInertiaDecay = 0.82
+	// zoomInertiaStop is the velocity below which inertia is dropped altogether.
+	zoomInertiaStop = 0.002
+	// maxShakeAmplitude caps stacked shakes, in screen pixels, so a salvo of explosions never
+	// throws the view around more than a few tiles.
+	maxShakeAmplitude = 18.0
+)
+
+// transition is one eased move from a start view to a target view. Position and scale are
+// interpolated independently with the same easing curve.
+type transition struct {
+	fromPosition geom.Point
+	toPosition   geom.Point
+	fromScale    float64
+	toScale      float64
+	frame        int
+	frames       int
+}
+
+// motion holds the animated state of a camera. It is advanced once per frame by Update and
+// never touches the logical position outside of it, so callers that clamp the camera after
+// Update always see the final word.
+type motion struct {
+	transition *transition
+
+	zoomVelocity float64
+	zoomCursor   geom.Point
+
+	shakeAmplitude float64
+	shakeFrames    int
+	shakeFrame     int
+	shakeOffset    geom.Point
+}
+
+// AnimateTo starts an eased transition to the given top-left position and scale over the given
+// number of frames. A non-positive duration jumps right away. Any transition or zoom inertia
+// already in progress is replaced.
+func (c *Camera) AnimateTo(position geom.Point, scale float64, frames int) {
+	scale = geom.ClampFloat(scale, c.minScale, c.maxScale)
+	c.motion.zoomVelocity = 0
+	if frames <= 0 {
+		c.motion.transition = nil
+		c.position = position
+		c.scale = scale
+		return
+	}
+
+	c.motion.transition = &transition{
+		fromPosition: c.position,
+		toPosition:   position,
+		fromScale:    c.scale,
+		toScale:      scale,
+		frames:       frames,
+	}
+}
+
+// Animating reports whether a transition is still running.
+func (c *Camera) Animating() bool {
+	return c.motion.transition != nil
+}
+
+// StopMotion cancels the running transition and any zoom inertia, leaving the camera where it
+// currently is. Manual panning calls it so the player always wins over an animation.
+func (c *Camera) StopMotion() {
+	c.motion.transition = nil
+	c.motion.zoomVelocity = 0
+}
+
+// AddZoomVelocity feeds zoom inertia: the velocity is applied around the cursor on every Update
+// and decays over a few frames, so a quick wheel flick keeps gliding for a moment.
+func (c *Camera) AddZoomVelocity(delta float64, cursor geom.Point) {
+	c.motion.transition = nil
+	c.motion.zoomVelocity += delta
+	c.motion.zoomCursor = cursor
+}
+
+// Approach moves the camera a fraction of the way towards the target position, which gives a
+// smooth follow when called every frame. A factor of one snaps to the target.
+func (c *Camera) Approach(position geom.Point, factor float64) {
+	factor = geom.ClampFloat(factor, 0, 1)
+	c.position.X += (position.X - c.position.X) * factor
+	c.position.Y += (position.Y - c.position.Y) * factor
+}
+
+// ApproachScale moves the zoom a fraction of the way towards the target scale, clamped to the
+// configured range.
+func (c *Camera) ApproachScale(scale float64, factor float64) {
+	scale = geom.ClampFloat(scale, c.minScale, c.maxScale)
+	c.scale += (scale - c.scale) * geom.ClampFloat(factor, 0, 1)
+}
+
+// Shake starts a screen shake of the given amplitude in screen pixels that fades out over the
+// given number of frames. Overlapping shakes keep the stronger amplitude and the longer tail.
+func (c *Camera) Shake(amplitude float64, frames int) {
+	if amplitude <= 0 || frames <= 0 {
+		return
+	}
+
+	remaining := c.remainingShake()
+	if amplitude <= remaining && frames <= c.motion.shakeFrames-c.motion.shakeFrame {
+		return
+	}
+	c.motion.shakeAmplitude = math.Min(math.Max(amplitude, remaining), maxShakeAmplitude)
+	c.motion.shakeFrames = frames
+	c.motion.shakeFrame = 0
+}
+
+// ShakeOffset returns the current shake displacement in world units. It is meant for rendering
+// only; the logical position never includes it, so input and clamping stay steady.
+func (c *Camera) ShakeOffset() geom.Point {
+	return c.motion.shakeOffset
+}
+
+// Shaken returns a copy of the camera displaced by the current shake, for the draw pass.
+func (c *Camera) Shaken() *Camera {
+	shaken := *c
+	shaken.motion = motion{}
+	shaken.position.X += c.motion.shakeOffset.X
+	shaken.position.Y += c.motion.shakeOffset.Y
+	return &shaken
+}
+
+// Update advances the transition, the zoom inertia and the shake by one frame.
+func (c *Camera) Update() {
+	if step := c.motion.transition; step != nil {
+		step.frame++
+		progress := easeInOutCubic(float64(step.frame) / float64(step.frames))
+		c.position.X = step.fromPosition.X + (step.toPosition.X-step.fromPosition.X)*progress
+		c.position.Y = step.fromPosition.Y + (step.toPosition.Y-step.fromPosition.Y)*progress
+		c.scale = step.fromScale + (step.toScale-step.fromScale)*progress
+		if step.frame >= step.frames {
+			c.motion.transition = nil
+		}
+	}
+
+	if c.motion.zoomVelocity != 0 {
+		c.zoomAround(c.motion.zoomVelocity, c.motion.zoomCursor)
+		c.motion.zoomVelocity *= zoomInertiaDecay
+		if math.Abs(c.motion.zoomVelocity) < zoomInertiaStop {
+			c.motion.zoomVelocity = 0
+		}
+	}
+
+	c.motion.shakeOffset = geom.Point{}
+	if c.motion.shakeFrame < c.motion.shakeFrames {
+		c.motion.shakeFrame++
+		amplitude := c.remainingShake() / c.scale
+		phase := float64(c.motion.shakeFrame)
+		c.motion.shakeOffset = geom.Point{
+			X: amplitude * math.Sin(phase*2.1),
+			Y: amplitude * math.Cos(phase*2.9),
+		}
+	}
+}
+
+// remainingShake is the shake amplitude left after the linear fade-out.
+func (c *Camera) remainingShake() float64 {
+	if c.motion.shakeFrames <= 0 || c.motion.shakeFrame >= c.motion.shakeFrames {
+		return 0
+	}
+
+	return c.motion.shakeAmplitude * (1 - float64(c.motion.shakeFrame)/float64(c.motion.shakeFrames))
+}
+
+func easeInOutCubic(t float64) float64 {
+	t = geom.ClampFloat(t, 0, 1)
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
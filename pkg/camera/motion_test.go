@@ -0,0 +1,82 @@
+package camera
+
+import (
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// TestAnimateToEasesIntoTargetAndManualPanCancels verifies that a transition starts slow, ends
+// exactly on the target and that a manual pan in the middle stops it where it is.
+func TestAnimateToEasesIntoTargetAndManualPanCancels(t *testing.T) {
+	cam := New(Config{})
+	cam.AnimateTo(geom.Point{X: 100, Y: 40}, 2, 4)
+
+	cam.Update()
+	if first := cam.Position().X; first <= 0 || first >= 25 {
+		t.Fatalf("position after first frame = %.2f, want an eased start below the linear 25", first)
+	}
+	for range 3 {
+		cam.Update()
+	}
+	if cam.Animating() || cam.Position() != (geom.Point{X: 100, Y: 40}) || cam.Scale() != 2 {
+		t.Fatalf("after the last frame animating=%v position=%+v scale=%.2f, want the target", cam.Animating(), cam.Position(), cam.Scale())
+	}
+
+	cam.AnimateTo(geom.Point{}, 1, 10)
+	cam.Update()
+	cam.Move(5, 0)
+	stopped := cam.Position()
+	cam.Update()
+	if cam.Animating() || cam.Position() != stopped {
+		t.Fatalf("after manual pan animating=%v position=%+v, want the transition cancelled at %+v", cam.Animating(), cam.Position(), stopped)
+	}
+}
+
+// TestZoomInertiaGlidesAndDecays verifies that one velocity impulse keeps zooming over several
+// frames around the cursor and then stops on its own.
+func TestZoomInertiaGlidesAndDecays(t *testing.T) {
+	cam := New(Config{})
+	cursor := geom.Point{X: 50, Y: 50}
+	anchor := cam.ScreenToWorld(cursor)
+
+	cam.AddZoomVelocity(0.1, cursor)
+	cam.Update()
+	afterOne := cam.Scale()
+	cam.Update()
+	if afterOne <= 1 || cam.Scale() <= afterOne {
+		t.Fatalf("scale after two frames = %.3f then %.3f, want it still growing", afterOne, cam.Scale())
+	}
+	for range 100 {
+		cam.Update()
+	}
+	settled := cam.Scale()
+	cam.Update()
+	if cam.Scale() != settled {
+		t.Fatal("zoom inertia still running after a hundred frames, want it decayed to a stop")
+	}
+	if got := cam.ScreenToWorld(cursor); !geom.AlmostEqual(got.X, anchor.X) || !geom.AlmostEqual(got.Y, anchor.Y) {
+		t.Fatalf("world point under cursor = %+v, want it kept at %+v", got, anchor)
+	}
+}
+
+// TestShakeOffsetsOnlyTheRenderViewAndFades verifies that the shake never moves the logical
+// position and dies out after its duration.
+func TestShakeOffsetsOnlyTheRenderViewAndFades(t *testing.T) {
+	cam := New(Config{Position: geom.Point{X: 10, Y: 10}})
+	cam.Shake(8, 3)
+
+	cam.Update()
+	if cam.ShakeOffset() == (geom.Point{}) || cam.Shaken().Position() == cam.Position() {
+		t.Fatal("shake offset is zero on the first frame, want a displaced render view")
+	}
+	if cam.Position() != (geom.Point{X: 10, Y: 10}) {
+		t.Fatalf("logical position = %+v, want it untouched by the shake", cam.Position())
+	}
+	for range 3 {
+		cam.Update()
+	}
+	if cam.ShakeOffset() != (geom.Point{}) {
+		t.Fatalf("shake offset after the duration = %+v, want zero", cam.ShakeOffset())
+	}
+}
@@ -0,0 +1,133 @@
+package endless
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/unng-lab/endless/pkg/assets"
+	"github.com/unng-lab/endless/pkg/assets/img"
+)
+
+// assetPollInterval is how often the development watcher scans the asset directory. Half a
+// second feels immediate after saving a sprite and keeps the scan of a few thousand files cheap.
+const assetPollInterval = 500 * time.Millisecond
+
+// assetReloader serves the development asset mode: images are read from the assets package
+// directory on disk instead of the binary, and files saved there are picked up while the game
+// runs.
+type assetReloader struct {
+	rawDir       string
+	manifestPath string
+	watcher      *img.Watcher
+}
+
+// newAssetReloader switches the image loaders to the raw directory below dir, installs the
+// manifest found next to it and starts watching both. An empty dir disables the mode and
+// returns nil.
+func newAssetReloader(dir string) (*assetReloader, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	reloader := &assetReloader{
+		rawDir:       filepath.Join(dir, "raw"),
+		manifestPath: filepath.Join(dir, "manifest.json"),
+	}
+	if err := img.SetSourceDir(reloader.rawDir); err != nil {
+		return nil, err
+	}
+	manifest, err := assets.LoadManifestFile(reloader.manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	assets.UseManifest(manifest)
+
+	reloader.watcher, err = img.Watch(assetPollInterval, reloader.rawDir, reloader.manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("watch assets: %w", err)
+	}
+	log.Printf("[assets] development mode: reading %s and %s", reloader.rawDir, reloader.manifestPath)
+	return reloader, nil
+}
+
+// close stops the watcher.
+func (r *assetReloader) close() {
+	if r == nil {
+		return
+	}
+
+	r.watcher.Close()
+}
+
+// pendingChanges returns the files changed since the last call without waiting for the next
+// scan.
+func (r *assetReloader) pendingChanges() []string {
+	if r == nil {
+		return nil
+	}
+
+	select {
+	case changed := <-r.watcher.Changes():
+		return changed
+	default:
+		return nil
+	}
+}
+
+// apply drops what the changed files invalidate. A manifest that no longer validates is logged
+// and the previous one stays in use, so a half-typed edit does not take every sprite down.
+func (r *assetReloader) apply(changed []string) error {
+	if slices.Contains(changed, r.manifestPath) {
+		manifest, err := assets.LoadManifestFile(r.manifestPath)
+		if err != nil {
+			log.Printf("[assets] %v; keeping the previous manifest", err)
+		} else {
+			assets.UseManifest(manifest)
+		}
+	}
+
+	var rawChanged []string
+	for _, path := range changed {
+		relative, err := filepath.Rel(r.rawDir, path)
+		if err != nil || !filepath.IsLocal(relative) {
+			continue
+		}
+		rawChanged = append(rawChanged, filepath.ToSlash(relative))
+	}
+	return img.Invalidate(rawChanged...)
+}
+
+// reloadAssets invalidates the images behind the changed files and makes the terrain atlas and
+// the unit renderer load everything again on the next draw.
+func (g *Game) reloadAssets(changed []string) error {
+	if g.assetReloader != nil {
+		if err := g.assetReloader.apply(changed); err != nil {
+			return err
+		}
+	}
+
+	g.atlas.Reload()
+	g.units.ReloadAssets()
+	g.assetErr = nil
+	log.Printf("[assets] reloaded changed=%d", len(changed))
+	return nil
+}
+
+// updateAssetReload applies the changes the watcher found since the previous frame. Errors are
+// shown like other asset failures instead of stopping the game.
+func (g *Game) updateAssetReload() {
+	changed := g.assetReloader.pendingChanges()
+	if len(changed) == 0 {
+		return
+	}
+
+	for _, path := range changed {
+		log.Printf("[assets] changed %s", path)
+	}
+	if err := g.reloadAssets(changed); err != nil {
+		g.assetErr = err
+	}
+}
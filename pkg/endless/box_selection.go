@@ -0,0 +1,94 @@
+package endless
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/unit"
+)
+
+// boxSelectThreshold is how far, in screen pixels, the cursor has to travel while the left
+// button is held before the press counts as a drag rectangle instead of a click.
+const boxSelectThreshold = 4.0
+
+// boxSelection tracks one select gesture from press to release.
+type boxSelection struct {
+	active bool
+	start  geom.Point
+}
+
+func (b boxSelection) dragged(cursor geom.Point) bool {
+	return b.active &&
+		(math.Abs(cursor.X-b.start.X) >= boxSelectThreshold || math.Abs(cursor.Y-b.start.Y) >= boxSelectThreshold)
+}
+
+// handleUnitSelection resolves the select gesture, the left button by default, on release. A
+// drag selects everything in the rectangle, a click picks the unit under the cursor, Shift adds
+// to or removes from the current selection and Ctrl+click selects every unit of the clicked
+// kind. Presses that start on the info panel or the minimap are ignored so neither ever eats
+// into the selection.
+func (g *Game) handleUnitSelection() {
+	x, y := ebiten.CursorPosition()
+	cursor := geom.Point{X: float64(x), Y: float64(y)}
+	if g.input.JustPressed(actionSelect) {
+		g.boxSelect = boxSelection{
+			active: !g.units.PointInPanel(g.cam, cursor, g.screenWidth, g.screenHeight) && !g.pointInMinimap(cursor),
+			start:  cursor,
+		}
+		return
+	}
+	if !g.boxSelect.active || !g.input.JustReleased(actionSelect) {
+		return
+	}
+
+	gesture := g.boxSelect
+	g.boxSelect = boxSelection{}
+	mode := unit.SelectionReplace
+	if g.input.Pressed(actionSelectAdd) {
+		mode = unit.SelectionToggle
+	}
+
+	switch {
+	case gesture.dragged(cursor):
+		if mode == unit.SelectionToggle {
+			mode = unit.SelectionAdd
+		}
+		g.units.SelectInScreenRect(g.cam, gesture.start, cursor, mode)
+	case g.input.Pressed(actionSelectKind):
+		g.units.SelectAtScreen(g.cam, cursor, g.screenWidth, g.screenHeight)
+		if picked, ok := g.units.Selected(); ok {
+			g.units.SelectAllOfKind(picked.UnitKind(), unit.SelectionReplace)
+		}
+	default:
+		g.units.SelectAtScreenMode(g.cam, cursor, g.screenWidth, g.screenHeight, mode)
+	}
+}
+
+// drawBoxSelection outlines the rectangle of a drag in progress.
+func (g *Game) drawBoxSelection(screen *ebiten.Image) {
+	if !g.input.Pressed(actionSelect) {
+		return
+	}
+
+	x, y := ebiten.CursorPosition()
+	cursor := geom.Point{X: float64(x), Y: float64(y)}
+	if !g.boxSelect.dragged(cursor) {
+		return
+	}
+
+	left := math.Min(g.boxSelect.start.X, cursor.X)
+	top := math.Min(g.boxSelect.start.Y, cursor.Y)
+	width := math.Abs(cursor.X - g.boxSelect.start.X)
+	height := math.Abs(cursor.Y - g.boxSelect.start.Y)
+	fill := color.NRGBA{R: 255, G: 214, B: 102, A: 40}
+	edge := color.NRGBA{R: 255, G: 214, B: 102, A: 220}
+
+	g.drawFilledRect(screen, left, top, width, height, fill)
+	g.drawFilledRect(screen, left, top, width, 1, edge)
+	g.drawFilledRect(screen, left, top+height-1, width, 1, edge)
+	g.drawFilledRect(screen, left, top, 1, height, edge)
+	g.drawFilledRect(screen, left+width-1, top, 1, height, edge)
+}
@@ -0,0 +1,190 @@
+package endless
+
+import (
+	"math"
+
+	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/unit"
+)
+
+const (
+	// cameraTransitionFrames is the length of eased jumps such as bookmark recall, a third of a
+	// second at 60 frames per second.
+	cameraTransitionFrames = 20
+	// cameraFollowSmoothing is the share of the remaining distance the camera covers per frame
+	// while following, which trails a runner smoothly without lagging a full screen behind.
+	cameraFollowSmoothing = 0.15
+	// cameraFramePaddingTiles keeps framed subjects this many tiles away from the screen edge.
+	cameraFramePaddingTiles = 6
+	// cameraFrameMaxScale stops the subject framing from zooming in further once the subjects
+	// stand close together.
+	cameraFrameMaxScale = 2.5
+
+	// zoomInertiaImpulse turns one wheel notch into a zoom velocity that adds up to roughly the
+	// same total zoom as a plain notch once the inertia has decayed.
+	zoomInertiaImpulse = zoomStep * 0.18
+
+	explosionShakeAmplitude = 6.0
+	explosionShakeFrames    = 14
+)
+
+// cameraFollowMode selects what, if anything, the camera tracks on its own.
+type cameraFollowMode int
+
+const (
+	cameraFollowOff cameraFollowMode = iota
+	// cameraFollowSelection keeps the selected units centred.
+	cameraFollowSelection
+	// cameraFollowSubjects keeps every unit the scenario names in frame, zooming as needed.
+	cameraFollowSubjects
+)
+
+func (m cameraFollowMode) String() string {
+	switch m {
+	case cameraFollowSelection:
+		return "selection"
+	case cameraFollowSubjects:
+		return "subjects"
+	default:
+		return "off"
+	}
+}
+
+// cameraSubjects returns the scenario's framing subjects, or nil when the scenario has none.
+func (g *Game) cameraSubjects() []int64 {
+	framed, ok := g.scenario.(gamescenario.FramedScenario)
+	if !ok || framed == nil {
+		return nil
+	}
+
+	return framed.CameraSubjects()
+}
+
+// handleCameraFollowToggle cycles the follow mode on the follow action: off, selected units,
+// then the scenario subjects when the scenario has any.
+func (g *Game) handleCameraFollowToggle() {
+	if !g.input.JustPressed(actionFollow) {
+		return
+	}
+
+	switch g.cameraFollow {
+	case cameraFollowOff:
+		g.cameraFollow = cameraFollowSelection
+	case cameraFollowSelection:
+		g.cameraFollow = cameraFollowOff
+		if _, ok := g.scenario.(gamescenario.FramedScenario); ok {
+			g.cameraFollow = cameraFollowSubjects
+		}
+	default:
+		g.cameraFollow = cameraFollowOff
+	}
+	g.cam.StopMotion()
+}
+
+// updateCameraMotion advances transitions, inertia and shake, then lets the follow mode steer.
+func (g *Game) updateCameraMotion() {
+	g.cam.Update()
+	g.collectExplosionShake()
+
+	switch g.cameraFollow {
+	case cameraFollowSelection:
+		if center, ok := g.units.SelectionCenter(); ok {
+			g.cam.Approach(g.cameraPositionCenteredOn(center, g.cam.Scale()), cameraFollowSmoothing)
+		}
+	case cameraFollowSubjects:
+		g.frameSubjects()
+	}
+}
+
+// frameSubjects eases both zoom and position towards the view that holds every live subject.
+func (g *Game) frameSubjects() {
+	center, scale, ok := g.subjectsView()
+	if !ok {
+		return
+	}
+
+	g.cam.ApproachScale(scale, cameraFollowSmoothing)
+	g.cam.Approach(g.cameraPositionCenteredOn(center, g.cam.Scale()), cameraFollowSmoothing)
+}
+
+// subjectsView fits the bounding box of the live subjects, padded on every side, into the
+// screen and returns the centre and zoom of that view. It reports false when no subject is
+// alive.
+func (g *Game) subjectsView() (geom.Point, float64, bool) {
+	var bounds geom.Rect
+	found := 0
+	for _, unitID := range g.cameraSubjects() {
+		snapshot, ok := g.units.UnitSnapshot(unitID)
+		if !ok || !snapshot.Alive {
+			continue
+		}
+		if found == 0 {
+			bounds = geom.Rect{Min: snapshot.Position, Max: snapshot.Position}
+		}
+		bounds.Min.X = math.Min(bounds.Min.X, snapshot.Position.X)
+		bounds.Min.Y = math.Min(bounds.Min.Y, snapshot.Position.Y)
+		bounds.Max.X = math.Max(bounds.Max.X, snapshot.Position.X)
+		bounds.Max.Y = math.Max(bounds.Max.Y, snapshot.Position.Y)
+		found++
+	}
+	if found == 0 {
+		return geom.Point{}, 0, false
+	}
+
+	padding := cameraFramePaddingTiles * g.world.TileSize()
+	width := bounds.Max.X - bounds.Min.X + padding*2
+	height := bounds.Max.Y - bounds.Min.Y + padding*2
+	scale := math.Min(float64(g.screenWidth)/width, float64(g.screenHeight)/height)
+	center := geom.Point{X: (bounds.Min.X + bounds.Max.X) / 2, Y: (bounds.Min.Y + bounds.Max.Y) / 2}
+	return center, math.Min(scale, cameraFrameMaxScale), true
+}
+
+// collectExplosionShake shakes the camera for projectile impacts inside or just around the
+// view, weaker the farther the impact is from the screen centre. The subscription follows the
+// manager, which replay seeking may replace.
+func (g *Game) collectExplosionShake() {
+	if g.shakeEvents == nil || g.shakeEventsOf != g.units {
+		g.shakeEvents.Close()
+		g.shakeEventsOf = g.units
+		g.shakeEvents = g.units.Subscribe(unit.EventFilter{Types: []unit.GameplayEventType{
+			unit.GameplayEventProjectileHit,
+			unit.GameplayEventProjectileExpired,
+		}})
+		return
+	}
+
+	view := g.cam.ViewRect(float64(g.screenWidth), float64(g.screenHeight))
+	center := geom.Point{X: (view.Min.X + view.Max.X) / 2, Y: (view.Min.Y + view.Max.Y) / 2}
+	reach := math.Hypot(view.Max.X-view.Min.X, view.Max.Y-view.Min.Y) * 0.75
+	for _, event := range g.shakeEvents.Drain() {
+		distance := math.Hypot(event.Position.X-center.X, event.Position.Y-center.Y)
+		if distance >= reach {
+			continue
+		}
+		g.cam.Shake(explosionShakeAmplitude*(1-distance/reach), explosionShakeFrames)
+	}
+}
+
+// cameraPositionCenteredOn returns the clamped top-left camera position that puts the world
+// point in the middle of the screen at the given scale.
+func (g *Game) cameraPositionCenteredOn(target geom.Point, scale float64) geom.Point {
+	return g.world.ClampCamera(geom.Point{
+		X: target.X - float64(g.screenWidth)/(2*scale),
+		Y: target.Y - float64(g.screenHeight)/(2*scale),
+	}, scale, g.screenWidth, g.screenHeight)
+}
+
+// centerCameraOn moves the camera at once so the world point sits in the middle of the screen
+// at the current zoom, ending any transition that was still running.
+func (g *Game) centerCameraOn(target geom.Point) {
+	g.cam.StopMotion()
+	g.cam.SetPosition(g.cameraPositionCenteredOn(target, g.cam.Scale()))
+}
+
+// animateCameraTo starts an eased transition to a clamped view and drops any follow mode, since
+// the player asked to look somewhere specific.
+func (g *Game) animateCameraTo(position geom.Point, scale float64) {
+	g.cameraFollow = cameraFollowOff
+	g.cam.AnimateTo(g.world.ClampCamera(position, scale, g.screenWidth, g.screenHeight), scale, cameraTransitionFrames)
+}
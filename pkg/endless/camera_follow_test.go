@@ -0,0 +1,113 @@
+package endless
+
+import (
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/camera"
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+type framedScenario struct {
+	subjects []int64
+}
+
+func (s *framedScenario) SeedUnits(*unit.Manager)     {}
+func (s *framedScenario) Update(int64, *unit.Manager) {}
+func (s *framedScenario) DebugText() string           { return "" }
+func (s *framedScenario) CameraSubjects() []int64     { return s.subjects }
+
+func newCameraTestGame(t *testing.T, columns, rows int) *Game {
+	t.Helper()
+	gameWorld := world.New(world.Config{Columns: columns, Rows: rows, TileSize: 16})
+	g := &Game{
+		world:        gameWorld,
+		units:        unit.NewManagerWithWorkers(gameWorld, 1),
+		cam:          camera.New(camera.Config{}),
+		screenWidth:  1280,
+		screenHeight: 720,
+	}
+	t.Cleanup(g.units.Close)
+	return g
+}
+
+// TestFrameSubjectsKeepsBothDuellistsInView eases the subject framing from the world origin
+// towards duellists at different distances and corners and checks that both end up on screen.
+func TestFrameSubjectsKeepsBothDuellistsInView(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		first geom.Point
+		other geom.Point
+	}{
+		{name: "side by side", first: geom.Point{X: 2008, Y: 2008}, other: geom.Point{X: 2040, Y: 2008}},
+		{name: "across the map", first: geom.Point{X: 600, Y: 2008}, other: geom.Point{X: 3400, Y: 2008}},
+		{name: "diagonal", first: geom.Point{X: 1000, Y: 900}, other: geom.Point{X: 2600, Y: 3100}},
+		{name: "world corner", first: geom.Point{X: 24, Y: 24}, other: geom.Point{X: 312, Y: 88}},
+		{name: "stacked far apart", first: geom.Point{X: 2008, Y: 600}, other: geom.Point{X: 2008, Y: 2600}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newCameraTestGame(t, 256, 256)
+			scenario := &framedScenario{}
+			for _, position := range []geom.Point{tc.first, tc.other} {
+				unitID, err := g.units.SpawnUnit(unit.UnitSpec{Kind: unit.KindRunner, Position: position})
+				if err != nil {
+					t.Fatalf("SpawnUnit() error = %v", err)
+				}
+				scenario.subjects = append(scenario.subjects, unitID)
+			}
+			g.scenario = scenario
+			g.cameraFollow = cameraFollowSubjects
+
+			for range 120 {
+				g.updateCameraMotion()
+			}
+			view := g.cam.ViewRect(float64(g.screenWidth), float64(g.screenHeight))
+			for _, position := range []geom.Point{tc.first, tc.other} {
+				if position.X < view.Min.X || position.X > view.Max.X || position.Y < view.Min.Y || position.Y > view.Max.Y {
+					t.Fatalf("subject at %+v is outside the framed view %+v (scale %.3f)", position, view, g.cam.Scale())
+				}
+			}
+			if g.cam.Scale() > cameraFrameMaxScale {
+				t.Fatalf("framed scale = %.3f, want at most %.1f", g.cam.Scale(), cameraFrameMaxScale)
+			}
+		})
+	}
+}
+
+// TestExplosionShakeStartsOnImpactAndDecaysToZero fires a shot into a wall in view, waits for
+// the impact to shake the camera and then checks the shake dies out once no new impact arrives.
+func TestExplosionShakeStartsOnImpactAndDecaysToZero(t *testing.T) {
+	g := newCameraTestGame(t, 64, 64)
+	shooter, err := g.units.SpawnUnit(unit.UnitSpec{Kind: unit.KindRunner, Position: geom.Point{X: 200, Y: 200}})
+	if err != nil {
+		t.Fatalf("SpawnUnit(runner) error = %v", err)
+	}
+	if _, err := g.units.SpawnUnit(unit.UnitSpec{Kind: unit.KindWall, Position: geom.Point{X: 296, Y: 200}}); err != nil {
+		t.Fatalf("SpawnUnit(wall) error = %v", err)
+	}
+	if err := g.units.IssueFireOrder(shooter, geom.Point{X: 1, Y: 0}); err != nil {
+		t.Fatalf("IssueFireOrder() error = %v", err)
+	}
+
+	g.updateCameraMotion()
+	shaking := false
+	for tick := int64(1); tick <= 120 && !shaking; tick++ {
+		g.units.Update(tick)
+		g.updateCameraMotion()
+		shaking = g.cam.ShakeOffset() != (geom.Point{})
+	}
+	if !shaking {
+		t.Fatal("camera never shook, want the projectile impact in view to start a shake")
+	}
+
+	for range explosionShakeFrames {
+		g.updateCameraMotion()
+	}
+	if offset := g.cam.ShakeOffset(); offset != (geom.Point{}) {
+		t.Fatalf("shake offset after %d quiet frames = %+v, want zero", explosionShakeFrames, offset)
+	}
+	if g.cam.Position() != (geom.Point{}) {
+		t.Fatalf("logical camera position = %+v, want the shake to leave it untouched", g.cam.Position())
+	}
+}
@@ -0,0 +1,383 @@
+package endless
+
+import (
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// CaptureFormat selects how RunCapture stores the rendered frames.
+type CaptureFormat string
+
+const (
+	// CaptureFormatPNG writes one numbered PNG file per frame into the output directory.
+	CaptureFormatPNG CaptureFormat = "png"
+	// CaptureFormatGIF writes every frame into one looping animated GIF.
+	CaptureFormatGIF CaptureFormat = "gif"
+)
+
+const (
+	DefaultCaptureWidth      = 640
+	DefaultCaptureHeight     = 360
+	DefaultCaptureFrameEvery = 4
+
+	// defaultCaptureMaxTicks stops captures of scenes that never finish on their own after ten
+	// minutes of game time.
+	defaultCaptureMaxTicks = 36000
+	// captureTicksPerSecond is the game tick rate the GIF frame delays are derived from.
+	captureTicksPerSecond = 60
+	// captureFinalFrameHold keeps the last GIF frame on screen for this many centiseconds so
+	// the outcome of the clip is readable before it loops.
+	captureFinalFrameHold = 150
+)
+
+// CaptureConfig describes one offscreen recording of the game scene.
+type CaptureConfig struct {
+	// Game picks the scene exactly as for the desktop client. A ReplayPath plays the recording
+	// back instead of running a scenario.
+	Game GameConfig
+	// Output is the frame directory for PNG captures and the file path for GIF captures.
+	Output string
+	Format CaptureFormat
+	// Width and Height are the frame size in pixels.
+	Width  int
+	Height int
+	// FrameEvery is the number of game ticks between two captured frames.
+	FrameEvery int
+	// MaxTicks ends the capture early. Zero runs until the scenario finishes or the replay
+	// ends, bounded by a ten-minute safety limit.
+	MaxTicks int64
+	// Center and Scale fix the camera for the whole capture. A zero Center frames the units
+	// the scenario names at the start, or the world centre without any; a zero Scale uses the
+	// zoom that framing picks.
+	Center geom.Point
+	Scale  float64
+	// Overlays lists debug overlays by name, as printed in the help line. Empty keeps the
+	// scene defaults and "off" hides every overlay.
+	Overlays string
+}
+
+// CaptureSummary reports what one capture wrote.
+type CaptureSummary struct {
+	Output string
+	Format CaptureFormat
+	Frames int
+	Ticks  int64
+}
+
+// normalizedCaptureConfig fills the frame size and cadence defaults and rejects configs that
+// could not produce any output.
+func normalizedCaptureConfig(config CaptureConfig) (CaptureConfig, error) {
+	if config.Output == "" {
+		return config, fmt.Errorf("capture output path is required")
+	}
+	switch config.Format {
+	case "":
+		config.Format = CaptureFormatGIF
+	case CaptureFormatPNG, CaptureFormatGIF:
+	default:
+		return config, fmt.Errorf("unsupported capture format %q; use png or gif", config.Format)
+	}
+	if config.Width <= 0 {
+		config.Width = DefaultCaptureWidth
+	}
+	if config.Height <= 0 {
+		config.Height = DefaultCaptureHeight
+	}
+	if config.FrameEvery <= 0 {
+		config.FrameEvery = DefaultCaptureFrameEvery
+	}
+	if config.MaxTicks <= 0 {
+		config.MaxTicks = defaultCaptureMaxTicks
+	}
+	if config.Scale < 0 {
+		return config, fmt.Errorf("capture scale must not be negative, got %g", config.Scale)
+	}
+	return config, nil
+}
+
+// RunCapture renders the configured scene at a fixed camera and writes the frames as a PNG
+// sequence or an animated GIF. The simulation advances exactly FrameEvery ticks between frames,
+// so the clip does not depend on how fast the machine renders.
+//
+// The capture is not truly headless: Ebiten has no offscreen mode and only draws inside its game
+// loop, which needs a window. The capture therefore opens a small unfocused window that previews
+// the frames and closes itself when done. Machines without a display have to run it under a
+// virtual framebuffer such as xvfb-run; without one RunCapture fails before building the game.
+func RunCapture(config CaptureConfig) (CaptureSummary, error) {
+	config, err := normalizedCaptureConfig(config)
+	if err != nil {
+		return CaptureSummary{}, err
+	}
+	if err := checkCaptureDisplay(); err != nil {
+		return CaptureSummary{}, err
+	}
+	// Captures never record replays or touch the player's bookmarks.
+	config.Game.RecordReplayPath = ""
+	config.Game.SettingsPath = ""
+
+	game, err := NewGameWithConfig(config.Game)
+	if err != nil {
+		return CaptureSummary{}, err
+	}
+	defer game.Close()
+	if err := game.prepareCapture(config); err != nil {
+		return CaptureSummary{}, err
+	}
+	sink, err := newFrameSink(config.Format, config.Output, config.FrameEvery)
+	if err != nil {
+		return CaptureSummary{}, err
+	}
+
+	capture := &captureGame{
+		game:   game,
+		sink:   sink,
+		config: config,
+		frame:  ebiten.NewImage(config.Width, config.Height),
+		pixels: image.NewRGBA(image.Rect(0, 0, config.Width, config.Height)),
+	}
+	ebiten.SetWindowTitle("Endless capture")
+	ebiten.SetWindowSize(config.Width, config.Height)
+	ebiten.SetVsyncEnabled(false)
+	ebiten.SetTPS(ebiten.SyncWithFPS)
+	runErr := ebiten.RunGameWithOptions(capture, &ebiten.RunGameOptions{InitUnfocused: true})
+	closeErr := sink.Close()
+	if runErr != nil {
+		return CaptureSummary{}, fmt.Errorf("run capture: %w", runErr)
+	}
+	if closeErr != nil {
+		return CaptureSummary{}, fmt.Errorf("write capture %s: %w", config.Output, closeErr)
+	}
+
+	log.Printf("[capture] output=%s format=%s frames=%d ticks=%d", config.Output, config.Format, capture.frames, capture.ticks)
+	return CaptureSummary{
+		Output: config.Output,
+		Format: config.Format,
+		Frames: capture.frames,
+		Ticks:  capture.ticks,
+	}, nil
+}
+
+// checkCaptureDisplay reports a missing X display up front on the platforms where Ebiten draws
+// through X11, instead of letting the window creation fail after the scenario was built.
+func checkCaptureDisplay() error {
+	switch runtime.GOOS {
+	case "linux", "freebsd", "netbsd", "openbsd", "dragonfly":
+		if os.Getenv("DISPLAY") == "" {
+			return fmt.Errorf("capture needs a display because Ebiten renders only inside a window: DISPLAY is not set, run under xvfb-run or another virtual framebuffer")
+		}
+	}
+	return nil
+}
+
+// prepareCapture sizes the view to the frame, applies the requested overlays and fixes the
+// camera. The follow mode is switched off so the camera stays where it was placed.
+func (g *Game) prepareCapture(config CaptureConfig) error {
+	if config.Overlays != "" {
+		enabled, err := parseDebugOverlays(config.Overlays)
+		if err != nil {
+			return err
+		}
+		g.overlays.enabled = enabled
+	}
+	g.units.SetPathSearchTracing(g.overlays.enabled.has(overlaySearch))
+
+	g.screenWidth = config.Width
+	g.screenHeight = config.Height
+	g.cameraFollow = cameraFollowOff
+
+	center := geom.Point{X: g.world.Width() / 2, Y: g.world.Height() / 2}
+	scale := 1.0
+	if subjectsCenter, subjectsScale, ok := g.subjectsView(); ok {
+		center, scale = subjectsCenter, subjectsScale
+	}
+	if config.Center != (geom.Point{}) {
+		center = config.Center
+	}
+	if config.Scale > 0 {
+		scale = config.Scale
+	}
+	scale = geom.ClampFloat(scale, minZoom, maxZoom)
+	g.cam.AnimateTo(g.cameraPositionCenteredOn(center, scale), scale, 0)
+	return nil
+}
+
+// captureTick advances the scene by one tick outside the simulation clock. It reports false
+// once the replay has ended or the scenario has reached its outcome.
+func (g *Game) captureTick() (bool, error) {
+	if g.replayPlayer != nil {
+		advanced, err := g.replayPlayer.Step()
+		if err != nil {
+			return false, fmt.Errorf("replay playback: %w", err)
+		}
+		g.units = g.replayPlayer.Manager()
+		g.tickCounter = g.replayPlayer.Tick()
+		return advanced, nil
+	}
+
+	if finished, ok := g.scenario.(gamescenario.FinishedScenario); ok && finished.Finished() {
+		return false, nil
+	}
+	return g.advanceSimulationTick(), nil
+}
+
+// captureGame drives a Game through the Ebiten loop one captured frame at a time. Update only
+// advances the simulation after Draw has written the frame of the previous step, so no frame is
+// skipped or repeated however Ebiten interleaves the two calls.
+type captureGame struct {
+	game   *Game
+	sink   frameSink
+	config CaptureConfig
+
+	frame  *ebiten.Image
+	pixels *image.RGBA
+
+	captured bool
+	finished bool
+	frames   int
+	ticks    int64
+	err      error
+}
+
+func (c *captureGame) Update() error {
+	if c.err != nil {
+		return c.err
+	}
+	if !c.captured {
+		return nil
+	}
+	if c.finished {
+		return ebiten.Termination
+	}
+
+	advanced := 0
+	for advanced < c.config.FrameEvery && c.ticks < c.config.MaxTicks {
+		ok, err := c.game.captureTick()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		advanced++
+		c.ticks++
+	}
+	if advanced < c.config.FrameEvery {
+		c.finished = true
+		if advanced == 0 {
+			return ebiten.Termination
+		}
+	}
+	c.captured = false
+	return nil
+}
+
+func (c *captureGame) Draw(screen *ebiten.Image) {
+	if !c.captured && c.err == nil {
+		c.game.drawFrame(c.frame, false)
+		c.frame.ReadPixels(c.pixels.Pix)
+		if err := c.sink.WriteFrame(c.pixels); err != nil {
+			c.err = fmt.Errorf("write capture frame %d: %w", c.frames, err)
+		}
+		c.frames++
+		c.captured = true
+	}
+	screen.DrawImage(c.frame, nil)
+}
+
+func (c *captureGame) Layout(int, int) (int, int) {
+	return c.config.Width, c.config.Height
+}
+
+// frameSink stores captured frames. WriteFrame must not keep the frame, since the capture
+// reuses its pixel buffer for the next one.
+type frameSink interface {
+	WriteFrame(frame *image.RGBA) error
+	Close() error
+}
+
+func newFrameSink(format CaptureFormat, output string, frameEvery int) (frameSink, error) {
+	switch format {
+	case CaptureFormatPNG:
+		if err := os.MkdirAll(output, 0o755); err != nil {
+			return nil, fmt.Errorf("create capture directory: %w", err)
+		}
+		return &pngFrameSink{dir: output}, nil
+	case CaptureFormatGIF:
+		delay := int(math.Round(float64(frameEvery) * 100 / captureTicksPerSecond))
+		return &gifFrameSink{path: output, delay: max(delay, 2)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported capture format %q", format)
+	}
+}
+
+// pngFrameSink writes frame_00000.png, frame_00001.png and so on, which video tools can pick up
+// with a plain numbered pattern.
+type pngFrameSink struct {
+	dir    string
+	frames int
+}
+
+func (s *pngFrameSink) WriteFrame(frame *image.RGBA) error {
+	file, err := os.Create(filepath.Join(s.dir, fmt.Sprintf("frame_%05d.png", s.frames)))
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, frame); err != nil {
+		_ = file.Close()
+		return err
+	}
+	s.frames++
+	return file.Close()
+}
+
+func (s *pngFrameSink) Close() error {
+	return nil
+}
+
+// gifFrameSink keeps every frame in memory and encodes the animation on Close. Frames are
+// mapped onto the fixed Plan 9 palette without dithering, which keeps flat terrain steady
+// between frames instead of shimmering.
+type gifFrameSink struct {
+	path      string
+	delay     int
+	animation gif.GIF
+}
+
+func (s *gifFrameSink) WriteFrame(frame *image.RGBA) error {
+	paletted := image.NewPaletted(frame.Bounds(), palette.Plan9)
+	draw.Draw(paletted, paletted.Rect, frame, frame.Bounds().Min, draw.Src)
+	s.animation.Image = append(s.animation.Image, paletted)
+	s.animation.Delay = append(s.animation.Delay, s.delay)
+	return nil
+}
+
+func (s *gifFrameSink) Close() error {
+	if len(s.animation.Image) == 0 {
+		return fmt.Errorf("no frames captured")
+	}
+	s.animation.Delay[len(s.animation.Delay)-1] = max(s.delay, captureFinalFrameHold)
+
+	file, err := os.Create(s.path)
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(file, &s.animation); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,105 @@
+package endless
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestNormalizedCaptureConfigFillsDefaultsAndRejectsUnknownFormat(t *testing.T) {
+	config, err := normalizedCaptureConfig(CaptureConfig{Output: "clip.gif"})
+	if err != nil {
+		t.Fatalf("normalizedCaptureConfig() error = %v", err)
+	}
+	if config.Format != CaptureFormatGIF || config.Width != DefaultCaptureWidth || config.Height != DefaultCaptureHeight {
+		t.Fatalf("normalizedCaptureConfig() = %+v, want GIF at the default size", config)
+	}
+	if config.FrameEvery != DefaultCaptureFrameEvery || config.MaxTicks != defaultCaptureMaxTicks {
+		t.Fatalf("normalizedCaptureConfig() cadence = every %d, max %d", config.FrameEvery, config.MaxTicks)
+	}
+
+	if _, err := normalizedCaptureConfig(CaptureConfig{Output: "clip.mp4", Format: "mp4"}); err == nil {
+		t.Fatal("normalizedCaptureConfig() accepted an mp4 capture")
+	}
+	if _, err := normalizedCaptureConfig(CaptureConfig{}); err == nil {
+		t.Fatal("normalizedCaptureConfig() accepted a capture without output")
+	}
+}
+
+func TestGIFFrameSinkEncodesEveryFrameAndHoldsTheLast(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clip.gif")
+	sink, err := newFrameSink(CaptureFormatGIF, path, 6)
+	if err != nil {
+		t.Fatalf("newFrameSink() error = %v", err)
+	}
+	frame := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	for _, fill := range []color.RGBA{{R: 255, A: 255}, {G: 255, A: 255}, {B: 255, A: 255}} {
+		for i := 0; i < len(frame.Pix); i += 4 {
+			frame.Pix[i], frame.Pix[i+1], frame.Pix[i+2], frame.Pix[i+3] = fill.R, fill.G, fill.B, fill.A
+		}
+		if err := sink.WriteFrame(frame); err != nil {
+			t.Fatalf("WriteFrame() error = %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer file.Close()
+	animation, err := gif.DecodeAll(file)
+	if err != nil {
+		t.Fatalf("DecodeAll() error = %v", err)
+	}
+	if len(animation.Image) != 3 {
+		t.Fatalf("frames = %d, want 3", len(animation.Image))
+	}
+	if animation.Delay[0] != 10 || animation.Delay[2] != captureFinalFrameHold {
+		t.Fatalf("delays = %v, want 10cs per frame and a held last frame", animation.Delay)
+	}
+	if r, g, _, _ := animation.Image[1].At(0, 0).RGBA(); g>>8 < 200 || r>>8 > 50 {
+		t.Fatalf("second frame pixel = %v, want green", animation.Image[1].At(0, 0))
+	}
+}
+
+func TestPNGFrameSinkNumbersFrames(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "frames")
+	sink, err := newFrameSink(CaptureFormatPNG, dir, 1)
+	if err != nil {
+		t.Fatalf("newFrameSink() error = %v", err)
+	}
+	frame := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for range 2 {
+		if err := sink.WriteFrame(frame); err != nil {
+			t.Fatalf("WriteFrame() error = %v", err)
+		}
+	}
+
+	for _, name := range []string{"frame_00000.png", "frame_00001.png"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("Stat(%s) error = %v", name, err)
+		}
+	}
+}
+
+// TestRunCaptureFailsFastWithoutDisplay checks that a capture on an X11 platform without
+// DISPLAY stops with an explicit error instead of trying to open the window.
+func TestRunCaptureFailsFastWithoutDisplay(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the display check only applies to X11 platforms")
+	}
+	t.Setenv("DISPLAY", "")
+
+	_, err := RunCapture(CaptureConfig{Output: filepath.Join(t.TempDir(), "clip.gif")})
+	if err == nil || !strings.Contains(err.Error(), "xvfb-run") {
+		t.Fatalf("RunCapture() error = %v, want the missing display error", err)
+	}
+}
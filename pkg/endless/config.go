@@ -2,6 +2,7 @@ package endless
 
 import (
 	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
+	"github.com/unng-lab/endless/pkg/replay"
 	"github.com/unng-lab/endless/pkg/rl"
 	"github.com/unng-lab/endless/pkg/world"
 )
@@ -20,6 +21,28 @@ const (
 type GameConfig struct {
 	Mode   gamescenario.Mode
 	RLDuel rl.VisualDuelScenarioConfig
+	// Seed fixes the basic and stress scene layouts; zero keeps their default seeding.
+	Seed int64
+
+	// ReplayPath switches the game into playback mode: the scenario is skipped and the unit
+	// manager is driven by the recorded command stream in the given file.
+	ReplayPath string
+	// RecordReplayPath enables command recording for a live session. The file is written by
+	// SaveReplayRecording once the launcher leaves the Ebiten loop.
+	RecordReplayPath string
+
+	// SettingsPath is the per-user file that keeps camera bookmarks between sessions. Empty
+	// disables persistence; launchers default it to DefaultSettingsPath.
+	SettingsPath string
+	// BindingsPath is the per-user input bindings file. Empty or missing keeps the default
+	// layout; launchers default it to DefaultBindingsPath.
+	BindingsPath string
+	// AssetDevDir is the assets package directory of a source checkout, holding raw and
+	// manifest.json. When set, images and the manifest are read from it instead of the binary
+	// and reloaded whenever a file there changes. Empty keeps the embedded assets.
+	AssetDevDir string
+	// ZoomInertia lets the mouse wheel zoom keep gliding for a few frames after each notch.
+	ZoomInertia bool
 }
 
 // normalizedGameConfig applies stable defaults once so every launcher path builds the game
@@ -51,3 +74,20 @@ func (config GameConfig) worldConfig() world.Config {
 		}
 	}
 }
+
+// replaySeed picks the metadata seed stored next to a recording. The visual RL duel keeps its
+// own seed; the other scenes report the scene seed, which stays zero unless one was requested.
+func (config GameConfig) replaySeed() int64 {
+	if config.Mode == gamescenario.ModeRLDuel {
+		return config.RLDuel.Seed
+	}
+	return config.Seed
+}
+
+// newReplayRecorder returns nil when recording is disabled so Game can keep one nil check.
+func (config GameConfig) newReplayRecorder(worldConfig world.Config) *replay.Recorder {
+	if config.RecordReplayPath == "" {
+		return nil
+	}
+	return replay.NewRecorder(string(config.Mode), config.replaySeed(), worldConfig)
+}
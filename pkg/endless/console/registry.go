@@ -0,0 +1,152 @@
+// Package console implements the command registry behind the in-game developer console. It
+// knows nothing about the game: commands are plain functions registered by the client and by
+// scenarios, so the same registry can be driven from tests without a window.
+package console
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Command is one console command. Name may hold several words, such as "set speed" or
+// "policy load"; the registry matches the longest registered name at the start of a line and
+// passes the remaining words to Run. The text Run returns is printed as the command's output.
+type Command struct {
+	Name  string
+	Usage string
+	Help  string
+	Run   func(args []string) (string, error)
+}
+
+// Registry holds the console commands by name. It always contains a built-in "help" command
+// that lists the others.
+type Registry struct {
+	commands map[string]Command
+	// longest is the word count of the longest registered name, which bounds the prefix match.
+	longest int
+}
+
+// NewRegistry returns a registry holding only the help command.
+func NewRegistry() *Registry {
+	r := &Registry{commands: make(map[string]Command)}
+	r.mustRegister(Command{
+		Name:  "help",
+		Usage: "help [command]",
+		Help:  "list the commands or describe one",
+		Run:   r.help,
+	})
+	return r
+}
+
+// Register adds a command. Names are normalised to single-spaced lower case; registering a
+// name twice is an error so a scenario cannot silently replace a client command.
+func (r *Registry) Register(command Command) error {
+	name := normalizedName(command.Name)
+	if name == "" {
+		return fmt.Errorf("console command has no name")
+	}
+	if command.Run == nil {
+		return fmt.Errorf("console command %q has no handler", name)
+	}
+	if _, exists := r.commands[name]; exists {
+		return fmt.Errorf("console command %q is already registered", name)
+	}
+
+	command.Name = name
+	if command.Usage == "" {
+		command.Usage = name
+	}
+	r.commands[name] = command
+	r.longest = max(r.longest, len(strings.Fields(name)))
+	return nil
+}
+
+func (r *Registry) mustRegister(command Command) {
+	if err := r.Register(command); err != nil {
+		panic(err)
+	}
+}
+
+// Execute runs one input line. Blank lines do nothing; an unknown command or a handler error
+// is returned as the error.
+func (r *Registry) Execute(line string) (string, error) {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	command, args, ok := r.lookup(words)
+	if !ok {
+		return "", fmt.Errorf("unknown command %q; type help for the list", words[0])
+	}
+	return command.Run(args)
+}
+
+// Names returns the registered command names in alphabetical order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.commands))
+	for name := range r.commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Complete extends a partly typed line to the longest prefix shared by every command name it
+// matches, which is what the console does on Tab. Lines that match nothing come back as is.
+func (r *Registry) Complete(line string) string {
+	prefix := strings.ToLower(strings.TrimLeft(line, " "))
+	var matches []string
+	for _, name := range r.Names() {
+		if strings.HasPrefix(name, prefix) {
+			matches = append(matches, name)
+		}
+	}
+	if len(matches) == 0 {
+		return line
+	}
+
+	common := matches[0]
+	for _, name := range matches[1:] {
+		for !strings.HasPrefix(name, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	if len(matches) == 1 {
+		common += " "
+	}
+	return common
+}
+
+// lookup finds the command with the longest name matching the first words of the line.
+func (r *Registry) lookup(words []string) (Command, []string, bool) {
+	for count := min(r.longest, len(words)); count > 0; count-- {
+		name := strings.ToLower(strings.Join(words[:count], " "))
+		if command, ok := r.commands[name]; ok {
+			return command, words[count:], true
+		}
+	}
+	return Command{}, nil, false
+}
+
+func (r *Registry) help(args []string) (string, error) {
+	if len(args) > 0 {
+		command, _, ok := r.lookup(args)
+		if !ok {
+			return "", fmt.Errorf("unknown command %q", strings.Join(args, " "))
+		}
+		return command.Usage + " - " + command.Help, nil
+	}
+
+	lines := make([]string, 0, len(r.commands))
+	for _, name := range r.Names() {
+		command := r.commands[name]
+		lines = append(lines, command.Usage+" - "+command.Help)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func normalizedName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
@@ -0,0 +1,66 @@
+package console
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRegistryMatchesLongestCommandName(t *testing.T) {
+	registry := NewRegistry()
+	var got []string
+	record := func(name string) func([]string) (string, error) {
+		return func(args []string) (string, error) {
+			got = append(got, name+":"+strings.Join(args, ","))
+			return "", nil
+		}
+	}
+	for _, name := range []string{"set", "set speed", "policy load"} {
+		if err := registry.Register(Command{Name: name, Run: record(name)}); err != nil {
+			t.Fatalf("Register(%q) error = %v", name, err)
+		}
+	}
+
+	for _, line := range []string{"set speed 4", "SET  overlay on", "policy load model.json", "   "} {
+		if _, err := registry.Execute(line); err != nil {
+			t.Fatalf("Execute(%q) error = %v", line, err)
+		}
+	}
+	want := []string{"set speed:4", "set:overlay,on", "policy load:model.json"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("handled = %v, want %v", got, want)
+	}
+
+	if _, err := registry.Execute("policy 2"); err == nil {
+		t.Fatal("Execute(policy 2) error = nil, want unknown command")
+	}
+	if err := registry.Register(Command{Name: "Set  Speed", Run: record("dup")}); err == nil {
+		t.Fatal("Register() duplicate error = nil, want rejection")
+	}
+}
+
+func TestRegistryHelpAndCompletion(t *testing.T) {
+	registry := NewRegistry()
+	for _, name := range []string{"spawn", "set speed", "set overlay"} {
+		if err := registry.Register(Command{Name: name, Usage: name + " <x>", Help: "does " + name, Run: func([]string) (string, error) { return "", nil }}); err != nil {
+			t.Fatalf("Register(%q) error = %v", name, err)
+		}
+	}
+
+	output, err := registry.Execute("help")
+	if err != nil {
+		t.Fatalf("Execute(help) error = %v", err)
+	}
+	if lines := strings.Split(output, "\n"); len(lines) != 4 || !strings.HasPrefix(lines[0], "help") {
+		t.Fatalf("help output = %q, want four sorted usage lines", output)
+	}
+	if output, _ := registry.Execute("help set speed"); output != "set speed <x> - does set speed" {
+		t.Fatalf("help set speed = %q", output)
+	}
+
+	for line, want := range map[string]string{"sp": "spawn ", "se": "set ", "set o": "set overlay ", "x": "x"} {
+		if got := registry.Complete(line); got != want {
+			t.Fatalf("Complete(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package endless
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/unng-lab/endless/pkg/endless/console"
+	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/pathfinding"
+	"github.com/unng-lab/endless/pkg/unit"
+)
+
+// newConsoleRegistry registers the client commands and then the commands of the scenario, if
+// it offers any. A scenario command whose name is already taken is skipped with a log line
+// rather than failing the game start.
+func (g *Game) newConsoleRegistry() *console.Registry {
+	registry := console.NewRegistry()
+	commands := g.consoleCommands()
+	if extension, ok := g.scenario.(gamescenario.ConsoleScenario); ok {
+		commands = append(commands, extension.ConsoleCommands()...)
+	}
+	for _, command := range commands {
+		if err := registry.Register(command); err != nil {
+			log.Printf("[console] %v", err)
+		}
+	}
+	return registry
+}
+
+// consoleCommands lists the commands every scene has. Unit commands take tile coordinates,
+// like the hovered-tile line of the help text, and go through the manager's public API so a
+// recording replays them.
+func (g *Game) consoleCommands() []console.Command {
+	return []console.Command{
+		{
+			Name:  "spawn",
+			Usage: "spawn <kind> <tile_x> <tile_y>",
+			Help:  "add a runner, runnerfocused, wall or barricade",
+			Run:   g.consoleSpawn,
+		},
+		{
+			Name:  "kill",
+			Usage: "kill <id>",
+			Help:  "remove a unit",
+			Run:   g.consoleKill,
+		},
+		{
+			Name:  "tp",
+			Usage: "tp <id> <tile_x> <tile_y>",
+			Help:  "teleport a unit, dropping its orders",
+			Run:   g.consoleTeleport,
+		},
+		{
+			Name:  "set speed",
+			Usage: "set speed [multiplier|max]",
+			Help:  "show or set the simulation speed",
+			Run:   g.consoleSetSpeed,
+		},
+		{
+			Name:  "set inertia",
+			Usage: "set inertia [on|off]",
+			Help:  "show or switch the zoom inertia",
+			Run: func(args []string) (string, error) {
+				return consoleSwitch("zoom inertia", &g.zoomInertia, args)
+			},
+		},
+		{
+			Name:  "overlay",
+			Usage: "overlay [name|all] [on|off]",
+			Help:  "show or switch debug overlays; paths is an alias of route",
+			Run:   g.consoleOverlay,
+		},
+		{
+			Name:  "debug api",
+			Usage: "debug api [on|off]",
+			Help:  "show or switch the move and fire order API trace in the log",
+			Run: func(args []string) (string, error) {
+				enabled := g.units.ExternalAPIDebugLogging()
+				output, err := consoleSwitch("external api trace", &enabled, args)
+				g.units.SetExternalAPIDebugLogging(enabled)
+				return output, err
+			},
+		},
+		{
+			Name:  "load map",
+			Usage: "load map <file>",
+			Help:  "place the units of a map file on the current terrain",
+			Run:   g.consoleLoadMap,
+		},
+		{
+			Name:  "reload assets",
+			Usage: "reload assets",
+			Help:  "load the terrain atlas and unit sprites again",
+			Run: func([]string) (string, error) {
+				if err := g.reloadAssets(nil); err != nil {
+					return "", err
+				}
+				return "assets reloaded", nil
+			},
+		},
+	}
+}
+
+func (g *Game) consoleSpawn(args []string) (string, error) {
+	if len(args) != 3 {
+		return "", fmt.Errorf("usage: spawn <kind> <tile_x> <tile_y>")
+	}
+	if err := g.consoleCanEditUnits(); err != nil {
+		return "", err
+	}
+	kind := unit.Kind(strings.ToLower(args[0]))
+	if kind == unit.KindProjectile {
+		return "", fmt.Errorf("projectiles are only created by fire orders")
+	}
+	position, err := g.consoleTilePoint(args[1], args[2])
+	if err != nil {
+		return "", err
+	}
+
+	unitID, err := g.units.SpawnUnit(unit.UnitSpec{Kind: kind, Position: position})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("spawned %s %d at tile (%s, %s)", kind, unitID, args[1], args[2]), nil
+}
+
+func (g *Game) consoleKill(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("usage: kill <id>")
+	}
+	if err := g.consoleCanEditUnits(); err != nil {
+		return "", err
+	}
+	unitID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("unit id %q is not a number", args[0])
+	}
+
+	if err := g.units.RemoveUnit(unitID, unit.RemovalReasonScripted); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("removed unit %d", unitID), nil
+}
+
+func (g *Game) consoleTeleport(args []string) (string, error) {
+	if len(args) != 3 {
+		return "", fmt.Errorf("usage: tp <id> <tile_x> <tile_y>")
+	}
+	if err := g.consoleCanEditUnits(); err != nil {
+		return "", err
+	}
+	unitID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("unit id %q is not a number", args[0])
+	}
+	position, err := g.consoleTilePoint(args[1], args[2])
+	if err != nil {
+		return "", err
+	}
+
+	if err := g.units.TeleportUnit(unitID, position); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("teleported unit %d to tile (%s, %s)", unitID, args[1], args[2]), nil
+}
+
+func (g *Game) consoleSetSpeed(args []string) (string, error) {
+	switch len(args) {
+	case 0:
+		return "speed " + g.clock.speedLabel(), nil
+	case 1:
+	default:
+		return "", fmt.Errorf("usage: set speed [multiplier|max]")
+	}
+
+	speed := float64(unlimitedSimulationSpeed)
+	if args[0] != "max" {
+		parsed, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "x"), 64)
+		if err != nil || parsed == unlimitedSimulationSpeed {
+			return "", fmt.Errorf("speed %q is not a multiplier", args[0])
+		}
+		speed = parsed
+	}
+	if !g.clock.setSpeed(speed) {
+		return "", fmt.Errorf("speed %s is not available; use one of %s", args[0], simulationSpeedList())
+	}
+	return "speed " + g.clock.speedLabel(), nil
+}
+
+// simulationSpeedList formats the selectable speeds for error messages.
+func simulationSpeedList() string {
+	labels := make([]string, 0, len(simulationSpeeds))
+	for _, speed := range simulationSpeeds {
+		if speed == unlimitedSimulationSpeed {
+			labels = append(labels, "max")
+			continue
+		}
+		labels = append(labels, strconv.FormatFloat(speed, 'g', -1, 64))
+	}
+	return strings.Join(labels, " ")
+}
+
+func (g *Game) consoleOverlay(args []string) (string, error) {
+	if len(args) == 0 {
+		return "overlays " + g.overlays.enabled.String(), nil
+	}
+	if len(args) != 2 {
+		return "", fmt.Errorf("usage: overlay [name|all] [on|off]")
+	}
+
+	overlay, ok := debugOverlayByName(strings.ToLower(args[0]))
+	if args[0] == "all" {
+		overlay, ok = 0, true
+		for _, toggle := range debugOverlayToggles {
+			overlay |= toggle.overlay
+		}
+	}
+	if !ok {
+		return "", fmt.Errorf("unknown debug overlay %q", args[0])
+	}
+	enabled, err := parseConsoleSwitch(args[1])
+	if err != nil {
+		return "", err
+	}
+
+	if enabled {
+		g.overlays.enabled |= overlay
+	} else {
+		g.overlays.enabled &^= overlay
+	}
+	g.units.SetPathSearchTracing(g.overlays.enabled.has(overlaySearch))
+	return "overlays " + g.overlays.enabled.String(), nil
+}
+
+// consoleMap is the layout of a map file. The terrain comes from the world generator, so a
+// map only places units on it, in the same tile coordinates the spawn command takes.
+type consoleMap struct {
+	Units []consoleMapUnit `json:"units"`
+}
+
+type consoleMapUnit struct {
+	Kind  unit.Kind `json:"kind"`
+	TileX int       `json:"tile_x"`
+	TileY int       `json:"tile_y"`
+}
+
+// consoleLoadMap spawns every unit of a map file. Units whose tile is outside the world or
+// already blocked are skipped and reported, so one bad entry does not void the whole layout.
+func (g *Game) consoleLoadMap(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("usage: load map <file>")
+	}
+	if err := g.consoleCanEditUnits(); err != nil {
+		return "", err
+	}
+	payload, err := os.ReadFile(args[0])
+	if err != nil {
+		return "", fmt.Errorf("read map %s: %w", args[0], err)
+	}
+	var layout consoleMap
+	if err := json.Unmarshal(payload, &layout); err != nil {
+		return "", fmt.Errorf("decode map %s: %w", args[0], err)
+	}
+
+	spawned := 0
+	var rejected []error
+	for index, entry := range layout.Units {
+		if entry.Kind == unit.KindProjectile {
+			rejected = append(rejected, fmt.Errorf("unit %d: projectiles cannot be placed", index))
+			continue
+		}
+		position := g.tileCenter(pathfinding.Step{X: entry.TileX, Y: entry.TileY})
+		if _, err := g.units.SpawnUnit(unit.UnitSpec{Kind: entry.Kind, Position: position}); err != nil {
+			rejected = append(rejected, fmt.Errorf("unit %d: %w", index, err))
+			continue
+		}
+		spawned++
+	}
+
+	output := fmt.Sprintf("map %s: spawned %d of %d units", args[0], spawned, len(layout.Units))
+	if len(rejected) > 0 {
+		output += "\n" + errors.Join(rejected...).Error()
+	}
+	return output, nil
+}
+
+// consoleCanEditUnits rejects unit edits during replay playback, where the recorded command
+// stream alone must drive the manager or seeking would drift from what is on screen.
+func (g *Game) consoleCanEditUnits() error {
+	if g.replayPlayer != nil {
+		return fmt.Errorf("units cannot be changed during replay playback")
+	}
+	return nil
+}
+
+// consoleTilePoint parses tile coordinates into the centre of that tile. Bounds are left to
+// the manager, which reports them together with blocked tiles.
+func (g *Game) consoleTilePoint(x, y string) (geom.Point, error) {
+	tileX, err := strconv.Atoi(x)
+	if err != nil {
+		return geom.Point{}, fmt.Errorf("tile x %q is not a number", x)
+	}
+	tileY, err := strconv.Atoi(y)
+	if err != nil {
+		return geom.Point{}, fmt.Errorf("tile y %q is not a number", y)
+	}
+	return g.tileCenter(pathfinding.Step{X: tileX, Y: tileY}), nil
+}
+
+// consoleSwitch implements the "[on|off]" commands: no argument shows the switch, one
+// argument sets it.
+func consoleSwitch(label string, value *bool, args []string) (string, error) {
+	switch len(args) {
+	case 0:
+	case 1:
+		enabled, err := parseConsoleSwitch(args[0])
+		if err != nil {
+			return "", err
+		}
+		*value = enabled
+	default:
+		return "", fmt.Errorf("expected on or off, got %q", strings.Join(args, " "))
+	}
+
+	if *value {
+		return label + " on", nil
+	}
+	return label + " off", nil
+}
+
+func parseConsoleSwitch(text string) (bool, error) {
+	switch strings.ToLower(text) {
+	case "on", "true", "1":
+		return true, nil
+	case "off", "false", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%q is not on or off", text)
+	}
+}
@@ -0,0 +1,112 @@
+package endless
+
+import (
+	"log"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/unit"
+)
+
+// controlGroupDoubleTap is the longest gap between two presses of the same group key that still
+// counts as a double tap and moves the camera to the group.
+const controlGroupDoubleTap = 350 * time.Millisecond
+
+// controlGroupKeys are the default keys of groups 0 through 9, the digit row.
+var controlGroupKeys = [...]ebiten.Key{
+	ebiten.KeyDigit0, ebiten.KeyDigit1, ebiten.KeyDigit2, ebiten.KeyDigit3, ebiten.KeyDigit4,
+	ebiten.KeyDigit5, ebiten.KeyDigit6, ebiten.KeyDigit7, ebiten.KeyDigit8, ebiten.KeyDigit9,
+}
+
+// cameraBookmarkKeys are the default keys of the bookmark slots 1 through 8, F1 through F8.
+var cameraBookmarkKeys = [...]ebiten.Key{
+	ebiten.KeyF1, ebiten.KeyF2, ebiten.KeyF3, ebiten.KeyF4,
+	ebiten.KeyF5, ebiten.KeyF6, ebiten.KeyF7, ebiten.KeyF8,
+}
+
+// controlGroups keeps the unit IDs stored under each digit for the current session. Groups are
+// not persisted: unit IDs only mean something inside one simulation run.
+type controlGroups struct {
+	groups  [len(controlGroupKeys)][]int64
+	lastKey int
+	lastTap time.Time
+	hasTap  bool
+	now     func() time.Time
+}
+
+func newControlGroups() *controlGroups {
+	return &controlGroups{now: time.Now}
+}
+
+func (c *controlGroups) assign(group int, unitIDs []int64) {
+	c.groups[group] = slices.Clone(unitIDs)
+	c.hasTap = false
+}
+
+// recall returns the IDs stored under the group and whether this press is the second tap of a
+// double tap on the same group.
+func (c *controlGroups) recall(group int) ([]int64, bool) {
+	now := c.now()
+	doubleTap := c.hasTap && c.lastKey == group && now.Sub(c.lastTap) <= controlGroupDoubleTap
+	c.lastKey = group
+	c.lastTap = now
+	c.hasTap = !doubleTap
+	return c.groups[group], doubleTap
+}
+
+// handleControlGroups assigns the selection to a group on the assign action (Ctrl+digit by
+// default), selects the group on its select action and centers the camera on it when that is
+// pressed twice in a row.
+func (g *Game) handleControlGroups() {
+	for group := range controlGroupKeys {
+		if g.input.JustPressed(assignGroupAction(group)) {
+			g.controlGroups.assign(group, g.units.SelectedIDs())
+			return
+		}
+		if !g.input.JustPressed(groupAction(group)) {
+			continue
+		}
+
+		unitIDs, jump := g.controlGroups.recall(group)
+		if len(unitIDs) == 0 {
+			return
+		}
+		g.units.SelectUnits(unitIDs, unit.SelectionReplace)
+		if center, ok := g.units.SelectionCenter(); ok && jump {
+			g.animateCameraTo(g.cameraPositionCenteredOn(center, g.cam.Scale()), g.cam.Scale())
+		}
+		return
+	}
+}
+
+// handleCameraBookmarks stores the current view on the save action (Ctrl+F1..F8 by default) and
+// eases back to it on the recall action. Every store is written to the settings file right away
+// so bookmarks survive crashes as well.
+func (g *Game) handleCameraBookmarks() {
+	for index := range cameraBookmarkKeys {
+		slot := index + 1
+		if g.input.JustPressed(saveBookmarkAction(slot)) {
+			position := g.cam.Position()
+			g.settings.setCameraBookmark(g.settingsScene, slot, CameraBookmark{
+				X:    position.X,
+				Y:    position.Y,
+				Zoom: g.cam.Scale(),
+			})
+			if err := g.settings.save(g.settingsPath); err != nil {
+				log.Printf("[settings] save camera bookmark %d failed: %v", slot, err)
+			}
+			return
+		}
+		if !g.input.JustPressed(bookmarkAction(slot)) {
+			continue
+		}
+
+		if bookmark, ok := g.settings.cameraBookmark(g.settingsScene, slot); ok {
+			g.animateCameraTo(geom.Point{X: bookmark.X, Y: bookmark.Y}, bookmark.Zoom)
+		}
+		return
+	}
+}
@@ -0,0 +1,40 @@
+package endless
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestControlGroupsRecallDetectsDoubleTapOnSameGroupOnly verifies the jump gesture: a second
+// press of the same digit inside the window jumps, while a slow second press, a press on another
+// group or a third quick press only select.
+func TestControlGroupsRecallDetectsDoubleTapOnSameGroupOnly(t *testing.T) {
+	now := time.Unix(0, 0)
+	groups := newControlGroups()
+	groups.now = func() time.Time { return now }
+
+	members := []int64{4, 9}
+	groups.assign(1, members)
+	members[0] = 100
+	if ids, _ := groups.recall(1); !slices.Equal(ids, []int64{4, 9}) {
+		t.Fatalf("recall(1) ids = %v, want the IDs stored on assign", ids)
+	}
+
+	now = now.Add(controlGroupDoubleTap / 2)
+	if _, jump := groups.recall(1); !jump {
+		t.Fatal("second quick press jump = false, want double tap")
+	}
+	now = now.Add(controlGroupDoubleTap / 2)
+	if _, jump := groups.recall(1); jump {
+		t.Fatal("third quick press jump = true, want a fresh first tap")
+	}
+	now = now.Add(controlGroupDoubleTap / 2)
+	if _, jump := groups.recall(2); jump {
+		t.Fatal("press on another group jump = true, want no double tap")
+	}
+	now = now.Add(2 * controlGroupDoubleTap)
+	if _, jump := groups.recall(2); jump {
+		t.Fatal("slow second press jump = true, want no double tap")
+	}
+}
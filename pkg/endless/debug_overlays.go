@@ -0,0 +1,400 @@
+package endless
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/pathfinding"
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// debugOverlay is a set of gameplay debug layers drawn on top of the world.
+type debugOverlay uint8
+
+const (
+	// overlayRoute draws the remaining and queued routes of the selected units.
+	overlayRoute debugOverlay = 1 << iota
+	// overlayCost tints every visible tile by its terrain movement cost.
+	overlayCost
+	// overlayOccupancy shades tiles by how many bodies their stack holds.
+	overlayOccupancy
+	// overlayBlocked marks tiles the path search cannot enter, by terrain or by a blocking unit.
+	overlayBlocked
+	// overlayProjectiles draws the remaining trajectory of every visible projectile.
+	overlayProjectiles
+	// overlaySearch shows the tiles the last move-order path search expanded.
+	overlaySearch
+	// overlayPolicy explains the last decision of an RL-driven scenario.
+	overlayPolicy
+)
+
+// debugOverlayToggles lists the overlays in the order of the help line, with the digit that
+// toggles each one together with Alt by default.
+var debugOverlayToggles = [...]struct {
+	key     ebiten.Key
+	overlay debugOverlay
+	name    string
+}{
+	{ebiten.KeyDigit1, overlayRoute, "route"},
+	{ebiten.KeyDigit2, overlayCost, "cost"},
+	{ebiten.KeyDigit3, overlayOccupancy, "occupancy"},
+	{ebiten.KeyDigit4, overlayBlocked, "blocked"},
+	{ebiten.KeyDigit5, overlayProjectiles, "shots"},
+	{ebiten.KeyDigit6, overlaySearch, "search"},
+	{ebiten.KeyDigit7, overlayPolicy, "policy"},
+}
+
+const (
+	// overlayCountMinTileSize is the smallest on-screen tile, in pixels, that still gets its
+	// occupancy count printed; below it the digits would overlap their neighbours.
+	overlayCountMinTileSize = 20.0
+	// overlayMaxCost is the movement cost shown fully red in the cost heatmap. Water is the
+	// slowest passable terrain and sits just below it.
+	overlayMaxCost = 3.0
+)
+
+var (
+	overlayRouteColor      = color.NRGBA{R: 255, G: 214, B: 102, A: 230}
+	overlayQueuedColor     = color.NRGBA{R: 120, G: 200, B: 255, A: 200}
+	overlayProjectileColor = color.NRGBA{R: 255, G: 120, B: 72, A: 220}
+	overlayExpandedColor   = color.NRGBA{R: 90, G: 160, B: 255, A: 70}
+	overlaySearchPathColor = color.NRGBA{R: 255, G: 255, B: 255, A: 140}
+	overlayBlockedColor    = color.NRGBA{R: 220, G: 40, B: 40, A: 130}
+)
+
+func (o debugOverlay) has(overlay debugOverlay) bool {
+	return o&overlay != 0
+}
+
+// String lists the enabled overlays by name, or "off" when none is enabled.
+func (o debugOverlay) String() string {
+	var names []string
+	for _, toggle := range debugOverlayToggles {
+		if o.has(toggle.overlay) {
+			names = append(names, toggle.name)
+		}
+	}
+	if len(names) == 0 {
+		return "off"
+	}
+
+	return strings.Join(names, " ")
+}
+
+// parseDebugOverlays turns a comma- or space-separated list of overlay names, as printed by
+// String, into an overlay set. "off" and the empty string select none.
+func parseDebugOverlays(names string) (debugOverlay, error) {
+	var enabled debugOverlay
+	for _, name := range strings.FieldsFunc(names, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if name == "off" {
+			continue
+		}
+		overlay, ok := debugOverlayByName(name)
+		if !ok {
+			return 0, fmt.Errorf("unknown debug overlay %q", name)
+		}
+		enabled |= overlay
+	}
+	return enabled, nil
+}
+
+// debugOverlayAliases are extra names accepted where an overlay is typed rather than toggled
+// by key, such as "overlay paths on" in the console.
+var debugOverlayAliases = map[string]debugOverlay{
+	"paths":  overlayRoute,
+	"routes": overlayRoute,
+}
+
+// debugOverlayByName resolves one overlay name from the help line, or one of its aliases.
+func debugOverlayByName(name string) (debugOverlay, bool) {
+	for _, toggle := range debugOverlayToggles {
+		if toggle.name == name {
+			return toggle.overlay, true
+		}
+	}
+	overlay, ok := debugOverlayAliases[name]
+	return overlay, ok
+}
+
+// tileLayer is a reusable one-pixel-per-tile image that is stretched over the visible tiles.
+// Painting a whole heatmap this way costs one pixel upload and one draw call, however far the
+// camera is zoomed out.
+type tileLayer struct {
+	image  *ebiten.Image
+	pixels []byte
+	width  int
+	height int
+}
+
+// reset sizes the layer for the tile rectangle and clears it to transparent.
+func (l *tileLayer) reset(tiles image.Rectangle) {
+	width, height := tiles.Dx(), tiles.Dy()
+	if l.image == nil || l.width < width || l.height < height {
+		if l.image != nil {
+			l.image.Deallocate()
+		}
+		l.width, l.height = max(width, l.width), max(height, l.height)
+		l.image = ebiten.NewImage(l.width, l.height)
+		l.pixels = make([]byte, l.width*l.height*4)
+	}
+	clear(l.pixels)
+}
+
+func (l *tileLayer) set(tiles image.Rectangle, tileX, tileY int, fill color.NRGBA) {
+	offset := ((tileY-tiles.Min.Y)*l.width + (tileX - tiles.Min.X)) * 4
+	l.pixels[offset] = fill.R
+	l.pixels[offset+1] = fill.G
+	l.pixels[offset+2] = fill.B
+	l.pixels[offset+3] = fill.A
+}
+
+// movementCostColor maps a terrain movement cost onto a green-to-red ramp. Impassable terrain
+// is left to the blocked overlay.
+func movementCostColor(cost float64) (color.NRGBA, bool) {
+	if math.IsInf(cost, 1) || cost <= 0 {
+		return color.NRGBA{}, false
+	}
+
+	cheapest := world.TileRoad.MovementCost()
+	t := geom.ClampFloat((cost-cheapest)/(overlayMaxCost-cheapest), 0, 1)
+	return color.NRGBA{R: uint8(255 * t), G: uint8(255 * (1 - t)), B: 40, A: 110}, true
+}
+
+// occupancyColor darkens with the number of bodies on a tile and saturates at four.
+func occupancyColor(units int) color.NRGBA {
+	return color.NRGBA{R: 180, G: 90, B: 255, A: uint8(50 + 40*min(units, 4))}
+}
+
+// debugOverlays holds the enabled set and the scratch buffers the overlays reuse every frame.
+type debugOverlays struct {
+	enabled   debugOverlay
+	layer     tileLayer
+	occupancy []unit.TileOccupancy
+}
+
+// handleDebugOverlayInput toggles overlays on their actions, Alt+digit by default, and keeps
+// the manager's path-search tracing in step with the search overlay. Replay seeking may swap
+// the manager, so the tracing flag is pushed every frame rather than only on the toggle.
+func (g *Game) handleDebugOverlayInput() {
+	for _, toggle := range debugOverlayToggles {
+		if g.input.JustPressed(overlayAction(toggle.name)) {
+			g.overlays.enabled ^= toggle.overlay
+		}
+	}
+	g.units.SetPathSearchTracing(g.overlays.enabled.has(overlaySearch))
+}
+
+// drawDebugOverlays draws the enabled overlays for the visible tile rectangle. Tile layers go
+// first so the line overlays stay readable on top of them.
+func (g *Game) drawDebugOverlays(screen *ebiten.Image, visible image.Rectangle) {
+	enabled := g.overlays.enabled
+	if enabled == 0 || visible.Empty() {
+		return
+	}
+
+	if enabled.has(overlayCost) {
+		g.drawTileLayer(screen, visible, func(tileX, tileY int) (color.NRGBA, bool) {
+			return movementCostColor(g.world.TileType(tileX, tileY).MovementCost())
+		})
+	}
+	if enabled.has(overlayOccupancy) || enabled.has(overlayBlocked) {
+		g.overlays.occupancy = g.units.AppendTileOccupancy(g.overlays.occupancy[:0], visible)
+	}
+	if enabled.has(overlayBlocked) {
+		g.drawBlockedTiles(screen, visible)
+	}
+	if enabled.has(overlayOccupancy) {
+		g.drawOccupancy(screen, visible)
+	}
+	if enabled.has(overlaySearch) {
+		g.drawPathSearch(screen, visible)
+	}
+	if enabled.has(overlayProjectiles) {
+		for _, trajectory := range g.units.ProjectileTrajectories(visible) {
+			g.drawWorldPolyline(screen, trajectory.Position, trajectory.Path, overlayProjectileColor)
+		}
+	}
+	if enabled.has(overlayRoute) {
+		g.drawSelectedRoutes(screen)
+	}
+	if enabled.has(overlayPolicy) {
+		g.drawPolicyOverlay(screen)
+	}
+}
+
+// drawTileLayer paints one pixel per visible tile with the colour the callback picks and draws
+// the layer stretched over the tiles.
+func (g *Game) drawTileLayer(screen *ebiten.Image, visible image.Rectangle, tileColor func(tileX, tileY int) (color.NRGBA, bool)) {
+	layer := &g.overlays.layer
+	layer.reset(visible)
+	for tileY := visible.Min.Y; tileY < visible.Max.Y; tileY++ {
+		for tileX := visible.Min.X; tileX < visible.Max.X; tileX++ {
+			if fill, ok := tileColor(tileX, tileY); ok {
+				layer.set(visible, tileX, tileY, fill)
+			}
+		}
+	}
+	layer.image.WritePixels(layer.pixels)
+
+	scale := g.cam.Scale()
+	left, top := g.tileScreenPosition(visible.Min.X, visible.Min.Y, scale, g.cam.Position())
+	tileScreenSize := g.world.TileSize() * scale
+	var op ebiten.DrawImageOptions
+	op.GeoM.Scale(tileScreenSize, tileScreenSize)
+	op.GeoM.Translate(left, top)
+	screen.DrawImage(layer.image.SubImage(image.Rect(0, 0, visible.Dx(), visible.Dy())).(*ebiten.Image), &op)
+}
+
+// drawBlockedTiles marks impassable terrain and tiles held by a movement-blocking unit.
+func (g *Game) drawBlockedTiles(screen *ebiten.Image, visible image.Rectangle) {
+	scale := g.cam.Scale()
+	camPos := g.cam.Position()
+	drawSize := math.Max(g.world.TileSize()*scale, 1)
+	for tileY := visible.Min.Y; tileY < visible.Max.Y; tileY++ {
+		for tileX := visible.Min.X; tileX < visible.Max.X; tileX++ {
+			if !math.IsInf(g.world.TileType(tileX, tileY).MovementCost(), 1) {
+				continue
+			}
+			x, y := g.tileScreenPosition(tileX, tileY, scale, camPos)
+			g.drawFilledRect(screen, x, y, drawSize, drawSize, overlayBlockedColor)
+		}
+	}
+	for _, tile := range g.overlays.occupancy {
+		if !tile.Blocked {
+			continue
+		}
+		x, y := g.tileScreenPosition(tile.X, tile.Y, scale, camPos)
+		g.drawFilledRect(screen, x, y, drawSize, drawSize, overlayBlockedColor)
+	}
+}
+
+// drawOccupancy shades occupied tiles and, once tiles are large enough on screen, prints the
+// stack size in the tile corner.
+func (g *Game) drawOccupancy(screen *ebiten.Image, visible image.Rectangle) {
+	scale := g.cam.Scale()
+	camPos := g.cam.Position()
+	tileScreenSize := g.world.TileSize() * scale
+	drawSize := math.Max(tileScreenSize, 1)
+	for _, tile := range g.overlays.occupancy {
+		x, y := g.tileScreenPosition(tile.X, tile.Y, scale, camPos)
+		g.drawFilledRect(screen, x, y, drawSize, drawSize, occupancyColor(tile.Units))
+		if tileScreenSize >= overlayCountMinTileSize {
+			ebitenutil.DebugPrintAt(screen, fmt.Sprint(tile.Units), int(x)+2, int(y))
+		}
+	}
+}
+
+// drawPathSearch tints the tiles the last traced search expanded and outlines the route it
+// returned, so a failed order shows how far the search got before running out of tiles.
+func (g *Game) drawPathSearch(screen *ebiten.Image, visible image.Rectangle) {
+	search, ok := g.units.LastPathSearch()
+	if !ok {
+		return
+	}
+
+	scale := g.cam.Scale()
+	camPos := g.cam.Position()
+	drawSize := math.Max(g.world.TileSize()*scale, 1)
+	for _, step := range search.Expanded {
+		if !image.Pt(step.X, step.Y).In(visible) {
+			continue
+		}
+		x, y := g.tileScreenPosition(step.X, step.Y, scale, camPos)
+		g.drawFilledRect(screen, x, y, drawSize, drawSize, overlayExpandedColor)
+	}
+
+	path := make([]geom.Point, 0, len(search.Path))
+	for _, step := range search.Path {
+		path = append(path, g.tileCenter(step))
+	}
+	g.drawWorldPolyline(screen, g.tileCenter(search.Start), path, overlaySearchPathColor)
+}
+
+// drawSelectedRoutes draws, for every selected mobile unit, the route it is walking and, in a
+// second colour, the route of the order queued behind it.
+func (g *Game) drawSelectedRoutes(screen *ebiten.Image) {
+	for _, unitID := range g.units.SelectedIDs() {
+		route, ok := g.units.UnitRoute(unitID)
+		if !ok {
+			continue
+		}
+
+		g.drawWorldPolyline(screen, route.Start, route.Path, overlayRouteColor)
+		g.drawWaypoints(screen, route.Path, overlayRouteColor)
+		if len(route.Queued) == 0 {
+			continue
+		}
+		from := route.Start
+		if len(route.Path) > 0 {
+			from = route.Path[len(route.Path)-1]
+		}
+		g.drawWorldPolyline(screen, from, route.Queued, overlayQueuedColor)
+		g.drawWaypoints(screen, route.Queued, overlayQueuedColor)
+	}
+}
+
+// drawWorldPolyline strokes the world-space polyline that starts at from and runs through every
+// point.
+func (g *Game) drawWorldPolyline(screen *ebiten.Image, from geom.Point, points []geom.Point, stroke color.Color) {
+	width := float32(math.Max(1.5, g.cam.Scale()))
+	previous := g.cam.WorldToScreen(from)
+	for _, point := range points {
+		next := g.cam.WorldToScreen(point)
+		vector.StrokeLine(screen, float32(previous.X), float32(previous.Y), float32(next.X), float32(next.Y), width, stroke, true)
+		previous = next
+	}
+}
+
+// drawWaypoints marks each waypoint with a small dot.
+func (g *Game) drawWaypoints(screen *ebiten.Image, points []geom.Point, fill color.Color) {
+	radius := float32(math.Max(2, g.cam.Scale()*1.5))
+	for _, point := range points {
+		screenPoint := g.cam.WorldToScreen(point)
+		vector.DrawFilledCircle(screen, float32(screenPoint.X), float32(screenPoint.Y), radius, fill, true)
+	}
+}
+
+// tileCenter returns the world point in the middle of a path-search tile.
+func (g *Game) tileCenter(step pathfinding.Step) geom.Point {
+	tileSize := g.world.TileSize()
+	return geom.Point{X: (float64(step.X) + 0.5) * tileSize, Y: (float64(step.Y) + 0.5) * tileSize}
+}
+
+// debugOverlayText summarises the last traced search for the debug text while the search
+// overlay is on.
+func (g *Game) debugOverlayText() string {
+	first, last := debugOverlayToggles[0].name, debugOverlayToggles[len(debugOverlayToggles)-1].name
+	text := g.actionRangeLabel(overlayAction(first), overlayAction(last)) + ": overlays route/cost/occupancy/blocked/shots/search/policy  On: " + g.overlays.enabled.String()
+	if !g.overlays.enabled.has(overlaySearch) {
+		return text
+	}
+
+	search, ok := g.units.LastPathSearch()
+	if !ok {
+		return text + "\nPath search: none traced yet"
+	}
+	outcome := fmt.Sprintf("%d steps", len(search.Path))
+	if search.Err != nil {
+		outcome = search.Err.Error()
+	}
+	return text + fmt.Sprintf(
+		"\nPath search: unit %d tick %d (%d, %d) -> (%d, %d) expanded %d tiles, %s",
+		search.UnitID,
+		search.Tick,
+		search.Start.X,
+		search.Start.Y,
+		search.Goal.X,
+		search.Goal.Y,
+		len(search.Expanded),
+		outcome,
+	)
+}
@@ -0,0 +1,52 @@
+package endless
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+func TestDebugOverlayStringListsEnabledLayersInToggleOrder(t *testing.T) {
+	var overlays debugOverlay
+	if got := overlays.String(); got != "off" {
+		t.Fatalf("String() = %q, want off", got)
+	}
+
+	overlays ^= overlaySearch | overlayRoute | overlayCost
+	if got := overlays.String(); got != "route cost search" {
+		t.Fatalf("String() = %q, want route cost search", got)
+	}
+}
+
+func TestParseDebugOverlaysAcceptsStringOutput(t *testing.T) {
+	want := overlayRoute | overlayProjectiles | overlayPolicy
+	got, err := parseDebugOverlays(want.String())
+	if err != nil {
+		t.Fatalf("parseDebugOverlays() error = %v", err)
+	}
+	if got != want {
+		t.Fatalf("parseDebugOverlays() = %s, want %s", got, want)
+	}
+
+	if got, err := parseDebugOverlays("cost,search"); err != nil || got != overlayCost|overlaySearch {
+		t.Fatalf("parseDebugOverlays(cost,search) = %s, %v", got, err)
+	}
+	if _, err := parseDebugOverlays("route,heat"); err == nil {
+		t.Fatal("parseDebugOverlays() accepted an unknown name")
+	}
+}
+
+func TestMovementCostColorRampsFromRoadToWater(t *testing.T) {
+	road, ok := movementCostColor(world.TileRoad.MovementCost())
+	if !ok || road.R != 0 || road.G != 255 {
+		t.Fatalf("road colour = %+v, %v, want pure green", road, ok)
+	}
+	water, ok := movementCostColor(world.TileWater.MovementCost())
+	if !ok || water.R <= water.G {
+		t.Fatalf("water colour = %+v, %v, want red dominating", water, ok)
+	}
+	if _, ok := movementCostColor(math.Inf(1)); ok {
+		t.Fatal("impassable cost coloured, want it left to the blocked overlay")
+	}
+}
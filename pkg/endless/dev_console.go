@@ -0,0 +1,186 @@
+package endless
+
+import (
+	"image/color"
+	"log"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+
+	"github.com/unng-lab/endless/pkg/endless/console"
+)
+
+const (
+	// consoleScrollback bounds the kept output lines; older lines are dropped.
+	consoleScrollback = 200
+	// consoleVisibleLines is how many output lines fit above the prompt.
+	consoleVisibleLines = 14
+	consoleHistoryLimit = 50
+	// consoleLineHeight matches the glyph height of Ebiten's debug font.
+	consoleLineHeight = 16
+	consolePadding    = 6
+	consolePrompt     = "> "
+
+	// consoleRepeatDelay and consoleRepeatInterval, in frames, make a held Backspace keep
+	// deleting like in a text field.
+	consoleRepeatDelay    = 30
+	consoleRepeatInterval = 3
+)
+
+var consoleBackgroundColor = color.NRGBA{R: 8, G: 12, B: 16, A: 215}
+
+// devConsole is the line editor, history and scrollback of the developer console. Commands
+// live in the registry; the console only feeds it lines and prints what comes back.
+type devConsole struct {
+	registry *console.Registry
+	open     bool
+	line     []rune
+	output   []string
+
+	history []string
+	// historyIndex points into history while browsing it with Up and Down, and equals
+	// len(history) while editing a fresh line.
+	historyIndex int
+}
+
+func newDevConsole(registry *console.Registry) *devConsole {
+	return &devConsole{registry: registry}
+}
+
+// toggle opens or closes the console. The line being edited is kept, so closing the console by
+// accident does not lose a long command.
+func (c *devConsole) toggle() {
+	c.open = !c.open
+	c.historyIndex = len(c.history)
+}
+
+// submit runs the edited line and prints it together with its output or error.
+func (c *devConsole) submit() {
+	line := strings.TrimSpace(string(c.line))
+	c.line = c.line[:0]
+	if line == "" {
+		return
+	}
+
+	if len(c.history) == 0 || c.history[len(c.history)-1] != line {
+		c.history = append(c.history, line)
+		if len(c.history) > consoleHistoryLimit {
+			c.history = c.history[len(c.history)-consoleHistoryLimit:]
+		}
+	}
+	c.historyIndex = len(c.history)
+
+	c.print(consolePrompt + line)
+	output, err := c.registry.Execute(line)
+	if err != nil {
+		log.Printf("[console] command=%q err=%q", line, err)
+		c.print("error: " + err.Error())
+		return
+	}
+	log.Printf("[console] command=%q", line)
+	if output != "" {
+		c.print(output)
+	}
+}
+
+// print appends output, one scrollback entry per line.
+func (c *devConsole) print(text string) {
+	c.output = append(c.output, strings.Split(text, "\n")...)
+	if len(c.output) > consoleScrollback {
+		c.output = c.output[len(c.output)-consoleScrollback:]
+	}
+}
+
+// browseHistory moves through earlier lines; delta is -1 for older and +1 for newer. Moving
+// past the newest entry leaves an empty line.
+func (c *devConsole) browseHistory(delta int) {
+	if len(c.history) == 0 {
+		return
+	}
+
+	c.historyIndex = min(max(c.historyIndex+delta, 0), len(c.history))
+	if c.historyIndex == len(c.history) {
+		c.line = c.line[:0]
+		return
+	}
+	c.line = []rune(c.history[c.historyIndex])
+}
+
+func (c *devConsole) backspace() {
+	if len(c.line) > 0 {
+		c.line = c.line[:len(c.line)-1]
+	}
+}
+
+func (c *devConsole) complete() {
+	c.line = []rune(c.registry.Complete(string(c.line)))
+}
+
+// updateConsole toggles the console and, while it is open, edits the command line. An open
+// console owns the keyboard and the mouse buttons, so it consumes the action state of the
+// frame before camera and gameplay input run; the simulation clock keeps going.
+//
+// The editing keys are fixed rather than rebindable because they behave like those of any
+// text field.
+func (g *Game) updateConsole() {
+	c := g.console
+	if c == nil {
+		return
+	}
+	if g.input.JustPressed(actionConsole) {
+		c.toggle()
+		g.input.Consume()
+		return
+	}
+	if !c.open {
+		return
+	}
+	g.input.Consume()
+
+	for _, char := range ebiten.AppendInputChars(nil) {
+		c.line = append(c.line, char)
+	}
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		c.toggle()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter), inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter):
+		c.submit()
+	case inpututil.IsKeyJustPressed(ebiten.KeyTab):
+		c.complete()
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowUp):
+		c.browseHistory(-1)
+	case inpututil.IsKeyJustPressed(ebiten.KeyArrowDown):
+		c.browseHistory(1)
+	case keyRepeated(ebiten.KeyBackspace):
+		c.backspace()
+	}
+}
+
+// keyRepeated reports a press on the first frame of the key and then at the typing repeat
+// rate while it stays held.
+func keyRepeated(key ebiten.Key) bool {
+	duration := inpututil.KeyPressDuration(key)
+	return duration == 1 || (duration >= consoleRepeatDelay && (duration-consoleRepeatDelay)%consoleRepeatInterval == 0)
+}
+
+// drawConsole draws the open console as a translucent band across the top of the screen, the
+// latest output lines above the prompt.
+func (g *Game) drawConsole(screen *ebiten.Image) {
+	c := g.console
+	if c == nil || !c.open {
+		return
+	}
+
+	height := float64((consoleVisibleLines+1)*consoleLineHeight + 2*consolePadding)
+	g.drawFilledRect(screen, 0, 0, float64(g.screenWidth), height, consoleBackgroundColor)
+
+	visible := c.output[max(len(c.output)-consoleVisibleLines, 0):]
+	y := consolePadding + (consoleVisibleLines-len(visible))*consoleLineHeight
+	for _, line := range visible {
+		ebitenutil.DebugPrintAt(screen, line, consolePadding, y)
+		y += consoleLineHeight
+	}
+	ebitenutil.DebugPrintAt(screen, consolePrompt+string(c.line)+"_", consolePadding, y)
+}
@@ -0,0 +1,135 @@
+package endless
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/endless/console"
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+func newConsoleTestGame(t *testing.T) *Game {
+	t.Helper()
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	g := &Game{world: gameWorld, units: unit.NewManagerWithWorkers(gameWorld, 1), clock: newSimulationClock()}
+	t.Cleanup(g.units.Close)
+	g.console = newDevConsole(g.newConsoleRegistry())
+	return g
+}
+
+func (c *devConsole) run(line string) string {
+	c.line = []rune(line)
+	before := len(c.output)
+	c.submit()
+	return strings.Join(c.output[before+1:], "\n")
+}
+
+// TestDevConsoleCommandsDriveUnitsClockAndOverlays runs the stock commands the way a player
+// types them and checks their effect on the manager and the client state.
+func TestDevConsoleCommandsDriveUnitsClockAndOverlays(t *testing.T) {
+	g := newConsoleTestGame(t)
+	c := g.console
+
+	var unitID int64
+	if output := c.run("spawn runner 3 4"); !strings.HasPrefix(output, "spawned runner ") {
+		t.Fatalf("spawn output = %q", output)
+	} else if _, err := fmt.Sscanf(output, "spawned runner %d", &unitID); err != nil {
+		t.Fatalf("spawn output %q has no unit id: %v", output, err)
+	}
+	id := strconv.FormatInt(unitID, 10)
+	c.run("spawn wall 5 5")
+	if output := c.run("spawn runner 5 5"); !strings.HasPrefix(output, "error: ") {
+		t.Fatalf("spawn on wall tile output = %q, want error", output)
+	}
+
+	c.run("tp " + id + " 10 12")
+	if snapshot, _ := g.units.UnitSnapshot(unitID); snapshot.Position != (geom.Point{X: 168, Y: 200}) {
+		t.Fatalf("teleported position = %+v, want centre of tile (10, 12)", snapshot.Position)
+	}
+
+	if output := c.run("set speed 4"); output != "speed 4x" {
+		t.Fatalf("set speed output = %q", output)
+	}
+	if output := c.run("set speed 3"); !strings.HasPrefix(output, "error: ") {
+		t.Fatalf("set speed 3 output = %q, want error", output)
+	}
+	if output := c.run("overlay paths on"); output != "overlays route" {
+		t.Fatalf("overlay output = %q", output)
+	}
+	if output := c.run("debug api off"); output != "external api trace off" || g.units.ExternalAPIDebugLogging() {
+		t.Fatalf("debug api output = %q, logging = %t", output, g.units.ExternalAPIDebugLogging())
+	}
+
+	mapPath := filepath.Join(t.TempDir(), "map.json")
+	payload := `{"units": [{"kind": "wall", "tile_x": 1, "tile_y": 1}, {"kind": "barricade", "tile_x": 99, "tile_y": 1}]}`
+	if err := os.WriteFile(mapPath, []byte(payload), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if output := c.run("load map " + mapPath); !strings.Contains(output, "spawned 1 of 2 units") || !strings.Contains(output, "unit 1:") {
+		t.Fatalf("load map output = %q, want one spawn and one rejection", output)
+	}
+
+	c.run("kill " + id)
+	if _, ok := g.units.UnitSnapshot(unitID); ok {
+		t.Fatalf("UnitSnapshot(%d) found the killed runner", unitID)
+	}
+
+	c.browseHistory(-1)
+	if got := string(c.line); got != "kill "+id {
+		t.Fatalf("history line = %q, want the last command", got)
+	}
+}
+
+// consoleScenario adds one command of its own and tries to take over a client command name.
+type consoleScenario struct {
+	pings int
+}
+
+func (s *consoleScenario) SeedUnits(*unit.Manager)     {}
+func (s *consoleScenario) Update(int64, *unit.Manager) {}
+func (s *consoleScenario) DebugText() string           { return "" }
+
+func (s *consoleScenario) ConsoleCommands() []console.Command {
+	return []console.Command{
+		{
+			Name:  "scene ping",
+			Usage: "scene ping",
+			Help:  "count a ping",
+			Run: func([]string) (string, error) {
+				s.pings++
+				return fmt.Sprintf("pings %d", s.pings), nil
+			},
+		},
+		{
+			Name:  "spawn",
+			Usage: "spawn",
+			Help:  "clashes with the client command",
+			Run:   func([]string) (string, error) { return "scenario spawn", nil },
+		},
+	}
+}
+
+// TestDevConsoleRegistersScenarioCommands verifies that a scenario extends the console with its
+// own commands without replacing the client ones.
+func TestDevConsoleRegistersScenarioCommands(t *testing.T) {
+	g := newConsoleTestGame(t)
+	if output := g.console.run("scene ping"); !strings.HasPrefix(output, "error: ") {
+		t.Fatalf("scene ping without a console scenario = %q, want error", output)
+	}
+
+	scene := &consoleScenario{}
+	g.scenario = scene
+	g.console = newDevConsole(g.newConsoleRegistry())
+	if output := g.console.run("scene ping"); output != "pings 1" || scene.pings != 1 {
+		t.Fatalf("scene ping output = %q, pings %d", output, scene.pings)
+	}
+	if output := g.console.run("spawn runner 3 4"); !strings.HasPrefix(output, "spawned runner ") {
+		t.Fatalf("spawn output = %q, want the client command", output)
+	}
+}
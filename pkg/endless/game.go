@@ -11,13 +11,14 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	"github.com/unng-lab/endless/pkg/assets"
 	"github.com/unng-lab/endless/pkg/camera"
+	"github.com/unng-lab/endless/pkg/endless/input"
 	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
 	"github.com/unng-lab/endless/pkg/geom"
 	"github.com/unng-lab/endless/pkg/pathfinding"
+	"github.com/unng-lab/endless/pkg/replay"
 	"github.com/unng-lab/endless/pkg/unit"
 	"github.com/unng-lab/endless/pkg/world"
 )
@@ -40,6 +41,17 @@ type Game struct {
 	units    *unit.Manager
 	scenario gamescenario.Scenario
 
+	clock   *simulationClock
+	input   *input.Map
+	console *devConsole
+	hud     *gameHUD
+
+	assetReloader *assetReloader
+
+	replayPlayer     *replay.Player
+	replayRecorder   *replay.Recorder
+	recordReplayPath string
+
 	tileRenderWorkers []chan tileRenderRequest
 	tileRenderTargets []*ebiten.Image
 	tileRenderWG      sync.WaitGroup
@@ -50,6 +62,19 @@ type Game struct {
 	dragging    bool
 	lastCursorX int
 	lastCursorY int
+	boxSelect   boxSelection
+	minimap     minimap
+	overlays    debugOverlays
+
+	cameraFollow  cameraFollowMode
+	zoomInertia   bool
+	shakeEvents   *unit.EventSubscription
+	shakeEventsOf *unit.Manager
+
+	controlGroups *controlGroups
+	settings      userSettings
+	settingsPath  string
+	settingsScene string
 
 	renderedTiles int
 	assetErr      error
@@ -79,13 +104,44 @@ func NewGameWithConfig(config GameConfig) (*Game, error) {
 	log.Printf("[startup] game: NewGame started")
 	config = normalizedGameConfig(config)
 
+	reloader, err := newAssetReloader(config.AssetDevDir)
+	if err != nil {
+		return nil, err
+	}
+	built := false
+	defer func() {
+		if !built {
+			reloader.close()
+		}
+	}()
+	if _, err := assets.DefaultManifest(); err != nil {
+		return nil, err
+	}
+
 	tileStartedAt := time.Now()
 	tile := ebiten.NewImage(1, 1)
 	tile.Fill(color.White)
 	log.Printf("[startup] game: base tile image prepared in %s", time.Since(tileStartedAt))
 
+	var player *replay.Player
+	if config.ReplayPath != "" {
+		replayStartedAt := time.Now()
+		recorded, err := replay.Load(config.ReplayPath)
+		if err != nil {
+			return nil, err
+		}
+		player, err = replay.NewPlayer(recorded)
+		if err != nil {
+			return nil, fmt.Errorf("create replay player: %w", err)
+		}
+		log.Printf("[startup] game: replay %s loaded in %s (%d ticks, %d commands)", config.ReplayPath, time.Since(replayStartedAt), recorded.Ticks, len(recorded.Commands))
+	}
+
 	worldStartedAt := time.Now()
 	worldConfig := config.worldConfig()
+	if player != nil {
+		worldConfig = player.Replay().World
+	}
 	gameWorld := world.New(worldConfig)
 	log.Printf(
 		"[startup] game: world created in %s (%dx%d tiles, tile size %.1f)",
@@ -95,15 +151,20 @@ func NewGameWithConfig(config GameConfig) (*Game, error) {
 		worldConfig.TileSize,
 	)
 
-	scenarioStartedAt := time.Now()
-	selectedScenario, err := gamescenario.New(gamescenario.Config{
-		Mode:   config.Mode,
-		RLDuel: config.RLDuel,
-	}, gameWorld)
-	if err != nil {
-		return nil, fmt.Errorf("create %s scenario: %w", config.Mode, err)
+	var selectedScenario gamescenario.Scenario
+	if player == nil {
+		scenarioStartedAt := time.Now()
+		var err error
+		selectedScenario, err = gamescenario.New(gamescenario.Config{
+			Mode:   config.Mode,
+			RLDuel: config.RLDuel,
+			Seed:   config.Seed,
+		}, gameWorld)
+		if err != nil {
+			return nil, fmt.Errorf("create %s scenario: %w", config.Mode, err)
+		}
+		log.Printf("[startup] game: %s scenario prepared in %s", config.Mode, time.Since(scenarioStartedAt))
 	}
-	log.Printf("[startup] game: %s scenario prepared in %s", config.Mode, time.Since(scenarioStartedAt))
 
 	structStartedAt := time.Now()
 	g := &Game{
@@ -119,13 +180,40 @@ func NewGameWithConfig(config GameConfig) (*Game, error) {
 		screenWidth:  DefaultScreenWidth,
 		screenHeight: DefaultScreenHeight,
 		startedAt:    startedAt,
+		clock:        newSimulationClock(),
+		input:        input.NewMap(loadInputBindings(config.BindingsPath), input.Ebiten()),
+
+		replayPlayer:     player,
+		replayRecorder:   config.newReplayRecorder(worldConfig),
+		recordReplayPath: config.RecordReplayPath,
+
+		assetReloader: reloader,
+		zoomInertia:   config.ZoomInertia,
+		controlGroups: newControlGroups(),
+		settingsPath:  config.SettingsPath,
+		settingsScene: string(config.Mode),
+	}
+	if player != nil {
+		g.settingsScene = player.Replay().Scenario
 	}
+	settings, err := loadUserSettings(config.SettingsPath)
+	if err != nil {
+		log.Printf("[settings] %v; starting without saved bookmarks", err)
+	}
+	g.settings = settings
 	log.Printf("[startup] game: camera, atlas and game struct initialized in %s", time.Since(structStartedAt))
 
 	managerStartedAt := time.Now()
-	g.units = unit.NewManager(g.world)
-	g.units.SetExternalAPIDebugLogging(true)
+	if g.replayPlayer != nil {
+		g.units = g.replayPlayer.Manager()
+	} else {
+		g.units = unit.NewManager(g.world)
+		g.units.SetExternalAPIDebugLogging(true)
+		g.replayRecorder.Attach(g.units)
+	}
 	log.Printf("[startup] game: unit manager initialized in %s", time.Since(managerStartedAt))
+	g.console = newDevConsole(g.newConsoleRegistry())
+	g.hud = newGameHUD(g)
 
 	tileRenderStartedAt := time.Now()
 	g.startTileRenderWorkers()
@@ -140,27 +228,96 @@ func NewGameWithConfig(config GameConfig) (*Game, error) {
 	cameraStartedAt := time.Now()
 	g.centerCamera()
 	g.clampCamera()
+	if _, ok := g.scenario.(gamescenario.FramedScenario); ok {
+		g.cameraFollow = cameraFollowSubjects
+	}
+	if _, ok := g.scenario.(gamescenario.PolicyScenario); ok {
+		g.overlays.enabled |= overlayPolicy
+	}
 	log.Printf("[startup] game: initial camera placement completed in %s", time.Since(cameraStartedAt))
 	log.Printf("[startup] game: NewGame finished in %s", time.Since(startedAt))
 
+	built = true
 	return g, nil
 }
 
+// Close stops the background work the game keeps running outside the Ebiten loop, the asset
+// watcher of the development mode. Launchers call it once RunGame has returned.
+func (g *Game) Close() {
+	if g == nil {
+		return
+	}
+
+	g.assetReloader.close()
+	g.assetReloader = nil
+}
+
+// UnitManager returns the manager that simulates the game's units, for diagnostics such as the
+// metrics endpoint of the launchers.
+func (g *Game) UnitManager() *unit.Manager {
+	if g == nil {
+		return nil
+	}
+
+	return g.units
+}
+
 func (g *Game) Update() error {
 	if !g.firstUpdateLogged {
 		g.firstUpdateLogged = true
 		log.Printf("[startup] game: first Update reached after %s", time.Since(g.startedAt))
 	}
 
+	g.input.Update()
+	g.updateAssetReload()
+	g.updateConsole()
+	g.handleSimulationClockInput()
+	g.updateHUD()
+	if g.replayPlayer != nil {
+		if err := g.updateReplayPlayback(); err != nil {
+			return err
+		}
+		g.updateCameraControls()
+		g.handleDebugOverlayInput()
+		g.handleUnitSelection()
+		g.handleControlGroups()
+		return nil
+	}
+
+	g.clock.advanceFrame(g.advanceSimulationTick)
+	g.updateCameraControls()
+	g.handleDebugOverlayInput()
+	g.handleGameplayInput()
+
+	return nil
+}
+
+// advanceSimulationTick runs one fixed gameplay tick. The simulation clock may call it several
+// times per Ebiten update or skip whole frames, so everything here must depend only on the tick
+// counter and never on frame timing.
+func (g *Game) advanceSimulationTick() bool {
 	g.tickCounter++
 	if g.scenario != nil {
 		g.scenario.Update(g.tickCounter, g.units)
 	}
 	g.units.Update(g.tickCounter)
-	g.updateCameraControls()
-	g.handleGameplayInput()
+	g.replayRecorder.MarkTick(g.tickCounter)
+	return true
+}
 
-	return nil
+// handleSimulationClockInput maps the pause, single-step and speed keys onto the clock. They
+// are read once per frame before any ticks run, so a step request takes effect immediately.
+func (g *Game) handleSimulationClockInput() {
+	switch {
+	case g.input.JustPressed(actionPause):
+		g.clock.togglePause()
+	case g.input.JustPressed(actionStep):
+		g.clock.step()
+	case g.input.JustPressed(actionSpeedUp):
+		g.clock.faster()
+	case g.input.JustPressed(actionSlowDown):
+		g.clock.slower()
+	}
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
@@ -169,46 +326,89 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		log.Printf("[startup] game: first Draw reached after %s", time.Since(g.startedAt))
 	}
 
+	g.drawFrame(screen, true)
+}
+
+// drawFrame renders the scene into the target. The interactive parts, the hovered tile, the
+// selection box, the minimap, the HUD, the help text and the console, are left out of offscreen
+// captures.
+func (g *Game) drawFrame(screen *ebiten.Image, interactive bool) {
 	screen.Fill(color.NRGBA{R: 17, G: 24, B: 31, A: 255})
 
 	g.updateScreenSize(screen)
+	if g.cam.ShakeOffset() != (geom.Point{}) {
+		// The whole draw pass sees the shaken view; the logical camera used by input and
+		// clamping is restored afterwards.
+		logical := g.cam
+		g.cam = logical.Shaken()
+		defer func() { g.cam = logical }()
+	}
 
 	visible, quality, hoveredTileX, hoveredTileY, hovered := g.drawWorld(screen)
+	g.units.SetRenderInterpolation(g.clock.interpolation())
 	if err := g.units.Draw(screen, g.cam, quality, visible, true); err != nil && g.assetErr == nil {
 		g.assetErr = err
 	}
 
-	if hovered {
+	if hovered && interactive {
 		g.drawTileHighlight(screen, hoveredTileX, hoveredTileY)
 	}
+	g.drawDebugOverlays(screen, visible)
 	g.units.DrawOverlay(screen, g.cam, g.screenWidth, g.screenHeight)
+	if !interactive {
+		return
+	}
+	g.drawBoxSelection(screen)
+	g.drawMinimap(screen)
+	g.drawHUD(screen)
 	ebitenutil.DebugPrint(screen, g.debugText(hoveredTileX, hoveredTileY, hovered))
+	g.drawConsole(screen)
 }
 
+// updateCameraControls runs the camera animation first and the manual controls after it, so
+// the player always has the last word. Any manual pan ends the follow mode.
 func (g *Game) updateCameraControls() {
+	g.updateCameraMotion()
 	g.applyZoomInput()
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
-		g.centerCamera()
+	if g.input.JustPressed(actionCenter) {
+		g.animateCameraTo(g.cameraPositionCenteredOn(geom.Point{X: g.world.Width() / 2, Y: g.world.Height() / 2}, g.cam.Scale()), g.cam.Scale())
 	}
+
+	before := g.cam.Position()
 	g.handleKeyboardPan()
 	g.handleMouseDrag()
+	if g.handleMinimapInput() || g.cam.Position() != before {
+		g.cameraFollow = cameraFollowOff
+	}
+	g.handleCameraFollowToggle()
+	g.handleCameraBookmarks()
 	g.clampCamera()
 }
 
+// applyZoomInput zooms around the cursor, one step per wheel notch or zoom button press.
 func (g *Game) applyZoomInput() {
-	_, wheelY := ebiten.Wheel()
-	if wheelY == 0 {
+	notches := g.input.Strength(actionZoomIn) - g.input.Strength(actionZoomOut)
+	if notches == 0 {
 		return
 	}
 
 	x, y := ebiten.CursorPosition()
-	g.cam.Zoom(wheelY*zoomStep, geom.Point{X: float64(x), Y: float64(y)})
+	cursor := geom.Point{X: float64(x), Y: float64(y)}
+	if g.zoomInertia {
+		g.cam.AddZoomVelocity(notches*zoomInertiaImpulse, cursor)
+		return
+	}
+	g.cam.Zoom(notches*zoomStep, cursor)
 }
 
 func (g *Game) handleGameplayInput() {
-	g.handleUnitSelection()
+	if !g.handleCommandTargeting() {
+		g.handleUnitSelection()
+	}
+	g.handleControlGroups()
 	g.handleUnitCommand()
 	g.handleUnitFire()
+	g.handleStopAndHold()
 }
 
 func (g *Game) updateScreenSize(screen *ebiten.Image) {
@@ -232,27 +432,27 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 
 func (g *Game) handleKeyboardPan() {
 	speed := panStep / g.cam.Scale()
-	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+	if g.input.Pressed(actionPanFast) {
 		speed *= 2
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
+	if g.input.Pressed(actionPanLeft) {
 		g.cam.Move(-speed, 0)
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyD) || ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
+	if g.input.Pressed(actionPanRight) {
 		g.cam.Move(speed, 0)
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
+	if g.input.Pressed(actionPanUp) {
 		g.cam.Move(0, -speed)
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyS) || ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
+	if g.input.Pressed(actionPanDown) {
 		g.cam.Move(0, speed)
 	}
 }
 
 func (g *Game) handleMouseDrag() {
 	x, y := ebiten.CursorPosition()
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle) {
+	if g.input.Pressed(actionDragPan) {
 		if g.dragging {
 			dx := float64(x-g.lastCursorX) / g.cam.Scale()
 			dy := float64(y-g.lastCursorY) / g.cam.Scale()
@@ -269,18 +469,8 @@ func (g *Game) handleMouseDrag() {
 	g.lastCursorY = y
 }
 
-func (g *Game) handleUnitSelection() {
-	if !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		return
-	}
-
-	x, y := ebiten.CursorPosition()
-	cursor := geom.Point{X: float64(x), Y: float64(y)}
-	g.units.SelectAtScreen(g.cam, cursor, g.screenWidth, g.screenHeight)
-}
-
 func (g *Game) handleUnitCommand() {
-	if !g.units.HasSelected() || !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+	if !g.units.HasSelected() || !g.input.JustPressed(actionMove) {
 		return
 	}
 
@@ -288,7 +478,11 @@ func (g *Game) handleUnitCommand() {
 	if !ok {
 		return
 	}
+	g.commandSelectedMove(x, y)
+}
 
+// commandSelectedMove orders the selection to the tile under the screen point.
+func (g *Game) commandSelectedMove(x, y int) {
 	targetTileX, targetTileY, ok := g.hoveredTile(x, y)
 	if !ok {
 		g.pathErr = pathfinding.ErrNoPath
@@ -304,7 +498,7 @@ func (g *Game) handleUnitCommand() {
 }
 
 func (g *Game) handleUnitFire() {
-	if !g.units.HasSelected() || !inpututil.IsKeyJustPressed(ebiten.KeyF) {
+	if !g.units.HasSelected() || !g.input.JustPressed(actionFire) {
 		return
 	}
 
@@ -312,7 +506,11 @@ func (g *Game) handleUnitFire() {
 	if !ok {
 		return
 	}
+	g.commandSelectedFire(cursor)
+}
 
+// commandSelectedFire orders the selection to shoot at the world point under the screen point.
+func (g *Game) commandSelectedFire(cursor geom.Point) {
 	if err := g.units.CommandSelectedFire(g.cam.ScreenToWorld(cursor)); err != nil {
 		g.fireErr = err
 		return
@@ -356,7 +554,7 @@ func (g *Game) hoveredTile(cursorX, cursorY int) (int, int, bool) {
 func (g *Game) cursorWorldCommandTarget() (int, int, geom.Point, bool) {
 	x, y := ebiten.CursorPosition()
 	cursor := geom.Point{X: float64(x), Y: float64(y)}
-	if g.units.PointInPanel(g.cam, cursor, g.screenWidth, g.screenHeight) {
+	if g.units.PointInPanel(g.cam, cursor, g.screenWidth, g.screenHeight) || g.pointInMinimap(cursor) {
 		return 0, 0, geom.Point{}, false
 	}
 
@@ -439,7 +637,9 @@ func (g *Game) debugText(hoveredTileX, hoveredTileY int, hovered bool) string {
 	}
 
 	debugText := fmt.Sprintf(
-		"WASD/Arrows: move  Shift: faster  Space: center  Middle mouse: drag  Wheel: zoom to cursor  Left mouse: select unit  Right mouse: move selected unit  F: fire to cursor\nTPS: %.1f  RPS: %.1f  Zoom: %.2fx  Visible tiles: %d  Camera: (%.0f, %.0f)  %s",
+		"%s\nTicks/frame: %d  TPS: %.1f  RPS: %.1f  Zoom: %.2fx  Visible tiles: %d  Camera: (%.0f, %.0f)  %s",
+		g.controlsHelpText(),
+		g.clock.lastTicks,
 		ebiten.ActualTPS(),
 		ebiten.ActualFPS(),
 		g.cam.Scale(),
@@ -448,6 +648,7 @@ func (g *Game) debugText(hoveredTileX, hoveredTileY int, hovered bool) string {
 		g.cam.Position().Y,
 		hoveredTileText,
 	)
+	debugText += "\n" + g.debugOverlayText()
 	if g.assetErr != nil {
 		debugText += "\nAssets fallback: " + g.assetErr.Error()
 	}
@@ -457,9 +658,10 @@ func (g *Game) debugText(hoveredTileX, hoveredTileY int, hovered bool) string {
 	if g.fireErr != nil {
 		debugText += "\nFire command: " + g.fireErr.Error()
 	}
+	// The clock, the replay state and the scenario headline are on the status bar.
 	if g.scenario != nil {
-		if scenarioDebugText := g.scenario.DebugText(); scenarioDebugText != "" {
-			debugText += "\n" + scenarioDebugText
+		if _, scenarioDetail := scenarioStatusText(g.scenario.DebugText()); scenarioDetail != "" {
+			debugText += "\n" + scenarioDetail
 		}
 	}
 
@@ -0,0 +1,274 @@
+package endless
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/unng-lab/endless/pkg/endless/hud"
+	"github.com/unng-lab/endless/pkg/endless/input"
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/unit"
+)
+
+const (
+	hudMargin          = 16.0
+	hudGap             = 8.0
+	hudPadding         = 8.0
+	statusBarHeight    = 20.0
+	selectionMaxWidth  = 480.0
+	commandButtonWidth = 72.0
+	commandButtonSize  = 26.0
+)
+
+// gameHUD is the client's retained HUD: the scenario status bar along the bottom edge, the
+// selection panel above it and the command card next to the minimap. The widgets live as long
+// as the game; layout refreshes their rectangles and texts every frame.
+type gameHUD struct {
+	layer *hud.Layer
+
+	status      *hud.Panel
+	statusLabel *hud.Label
+
+	selection      *hud.Panel
+	selectionLabel *hud.Label
+
+	commands *hud.Panel
+	move     *hud.Button
+	fire     *hud.Button
+	stop     *hud.Button
+	hold     *hud.Button
+
+	// targeting is actionMove or actionFire after the matching button was clicked: the next
+	// select click on the map gives that order instead of selecting.
+	targeting input.Action
+}
+
+func newGameHUD(g *Game) *gameHUD {
+	h := &gameHUD{
+		statusLabel:    &hud.Label{},
+		selectionLabel: &hud.Label{},
+	}
+	h.move = &hud.Button{Text: "Move", OnClick: func() { h.toggleTargeting(actionMove) }}
+	h.fire = &hud.Button{Text: "Fire", OnClick: func() { h.toggleTargeting(actionFire) }}
+	h.stop = &hud.Button{Text: "Stop", OnClick: g.commandSelectedStop}
+	h.hold = &hud.Button{Text: "Hold", OnClick: g.commandSelectedHold}
+
+	h.status = &hud.Panel{Widgets: []hud.Widget{h.statusLabel}}
+	h.selection = &hud.Panel{Accent: true, Widgets: []hud.Widget{h.selectionLabel}}
+	h.commands = &hud.Panel{Accent: true, Widgets: []hud.Widget{h.move, h.fire, h.stop, h.hold}}
+	h.layer = hud.NewLayer(h.status, h.selection, h.commands)
+	return h
+}
+
+func (h *gameHUD) toggleTargeting(action input.Action) {
+	if h.targeting == action {
+		h.targeting = ""
+		return
+	}
+	h.targeting = action
+}
+
+// updateHUD lays the HUD out for the current state and feeds it the select button. The panel
+// hit test is installed every frame because replay seeking replaces the unit manager.
+func (g *Game) updateHUD() {
+	g.layoutHUD()
+	g.units.SetPanelHitTest(g.hud.layer.Contains)
+
+	x, y := ebiten.CursorPosition()
+	g.hud.layer.Update(geom.Point{X: float64(x), Y: float64(y)}, g.input.JustPressed(actionSelect), g.input.JustReleased(actionSelect))
+}
+
+func (g *Game) drawHUD(screen *ebiten.Image) {
+	g.layoutHUD()
+	g.hud.layer.Draw(screen)
+}
+
+// layoutHUD places every panel from the screen size and fills in the texts. The minimap keeps
+// the bottom-right corner, so the status bar stops short of it and the command card sits on
+// top of it.
+func (g *Game) layoutHUD() {
+	h := g.hud
+	width := float64(g.screenWidth)
+	height := float64(g.screenHeight)
+	cardRight := width - hudMargin
+	cardBottom := height - statusBarHeight - hudGap
+	statusRight := width
+	if minimap, ok := minimapLayout(g.world, g.screenWidth, g.screenHeight); ok {
+		cardRight = minimap.Max.X
+		cardBottom = minimap.Min.Y - hudGap
+		statusRight = minimap.Min.X - hudGap
+	}
+
+	h.status.Rect = geom.Rect{Min: geom.Point{X: 0, Y: height - statusBarHeight}, Max: geom.Point{X: statusRight, Y: height}}
+	h.statusLabel.Position = geom.Point{X: hudPadding, Y: height - statusBarHeight + 2}
+	h.statusLabel.Text = hud.Truncate(g.statusBarText(), statusRight-hudPadding*2)
+
+	summary := g.units.SelectionSummary()
+	if summary.Count == 0 {
+		h.targeting = ""
+	}
+	g.layoutSelectionPanel(summary, width, height)
+	g.layoutCommandCard(summary, cardRight, cardBottom)
+}
+
+func (g *Game) layoutSelectionPanel(summary unit.SelectionSummary, width, height float64) {
+	h := g.hud
+	details, _ := g.units.SelectionDetails()
+	h.selectionLabel.Text = selectionPanelText(summary, details)
+	h.selection.Hidden = summary.Count == 0
+	if h.selection.Hidden {
+		return
+	}
+
+	_, textHeight := hud.TextSize(h.selectionLabel.Text)
+	panelWidth := math.Min(width-hudMargin*2, selectionMaxWidth)
+	panelHeight := textHeight + 28
+	left := (width - panelWidth) / 2
+	bottom := height - statusBarHeight - hudGap
+	h.selection.Rect = geom.Rect{Min: geom.Point{X: left, Y: bottom - panelHeight}, Max: geom.Point{X: left + panelWidth, Y: bottom}}
+	h.selectionLabel.Position = geom.Point{X: left + 16, Y: bottom - panelHeight + 14}
+}
+
+// layoutCommandCard arranges Move and Fire over Stop and Hold. The card is hidden during
+// replay playback, which cannot take orders.
+func (g *Game) layoutCommandCard(summary unit.SelectionSummary, right, bottom float64) {
+	h := g.hud
+	h.commands.Hidden = summary.Count == 0 || g.replayPlayer != nil
+	if h.commands.Hidden {
+		h.targeting = ""
+		return
+	}
+
+	cardWidth := hudPadding*2 + commandButtonWidth*2 + hudGap
+	cardHeight := hudPadding*2 + commandButtonSize*2 + hudGap
+	left := right - cardWidth
+	top := bottom - cardHeight
+	h.commands.Rect = geom.Rect{Min: geom.Point{X: left, Y: top}, Max: geom.Point{X: right, Y: bottom}}
+	for index, button := range []*hud.Button{h.move, h.fire, h.stop, h.hold} {
+		x := left + hudPadding + float64(index%2)*(commandButtonWidth+hudGap)
+		y := top + hudPadding + float64(index/2)*(commandButtonSize+hudGap)
+		button.Rect = geom.Rect{Min: geom.Point{X: x, Y: y}, Max: geom.Point{X: x + commandButtonWidth, Y: y + commandButtonSize}}
+	}
+
+	label := g.input.FirstLabel
+	h.move.Disabled = summary.Mobile == 0
+	h.move.Active = h.targeting == actionMove
+	h.move.Tooltip = fmt.Sprintf("Move (%s)\nClick a tile to send the selection there", label(actionMove))
+	h.fire.Disabled = summary.Shooters == 0
+	h.fire.Active = h.targeting == actionFire
+	h.fire.Tooltip = fmt.Sprintf("Fire (%s)\nClick a point to shoot at it\nWeapons ready: %d/%d", label(actionFire), summary.WeaponsReady, summary.Shooters)
+	h.stop.Disabled = summary.Mobile == 0
+	h.stop.Tooltip = fmt.Sprintf("Stop (%s)\nHalt at the next tile and drop queued orders", label(actionStop))
+	h.hold.Disabled = summary.Mobile == 0
+	h.hold.Active = summary.Mobile > 0 && summary.Holding == summary.Mobile
+	h.hold.Tooltip = fmt.Sprintf("Hold position (%s)\nStop and ignore scripted moves until released\nA move order releases the hold", label(actionHold))
+	if summary.Mobile == 0 {
+		for _, button := range []*hud.Button{h.move, h.stop, h.hold} {
+			button.Tooltip += "\nNo selected unit can move"
+		}
+	}
+	if summary.Shooters == 0 {
+		h.fire.Tooltip += "\nNo selected unit has a weapon"
+	}
+}
+
+// selectionPanelText describes a single unit in full. A group gets its totals on top and the
+// headline of the primary unit below them.
+func selectionPanelText(summary unit.SelectionSummary, details string) string {
+	if summary.Count <= 1 {
+		return details
+	}
+
+	kinds := make([]string, 0, len(summary.Kinds))
+	for _, kind := range summary.Kinds {
+		kinds = append(kinds, fmt.Sprintf("%s %d", kind.Kind, kind.Count))
+	}
+	lines := []string{
+		fmt.Sprintf("Selected: %d  (%s)", summary.Count, strings.Join(kinds, ", ")),
+		fmt.Sprintf("HP: %d/%d  Moving: %d/%d  Holding: %d", summary.Health, summary.MaxHealth, summary.Moving, summary.Mobile, summary.Holding),
+	}
+	if summary.Shooters > 0 {
+		lines = append(lines, fmt.Sprintf("Weapons ready: %d/%d", summary.WeaponsReady, summary.Shooters))
+	}
+	if headline, _, _ := strings.Cut(details, "\n"); headline != "" {
+		lines = append(lines, "Primary: "+headline)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// statusBarText is the one-line status: the clock, the headline of the scenario text and the
+// replay state. The rest of a multi-line scenario text stays in the debug block.
+func (g *Game) statusBarText() string {
+	parts := []string{fmt.Sprintf("Sim: %s  Tick: %d", g.clock.speedLabel(), g.tickCounter)}
+	if g.scenario != nil {
+		if headline, _ := scenarioStatusText(g.scenario.DebugText()); headline != "" {
+			parts = append(parts, headline)
+		}
+	}
+	if replayText := g.replayDebugText(); replayText != "" {
+		parts = append(parts, replayText)
+	}
+	return strings.Join(parts, "  |  ")
+}
+
+// scenarioStatusText splits a scenario text into the headline for the status bar and the
+// remaining detail lines.
+func scenarioStatusText(text string) (string, string) {
+	headline, detail, _ := strings.Cut(text, "\n")
+	return headline, detail
+}
+
+// handleCommandTargeting gives the armed Move or Fire order at the clicked map point. It
+// reports whether it took the select press, in which case the press does not select.
+func (g *Game) handleCommandTargeting() bool {
+	if g.hud.targeting == "" {
+		return false
+	}
+	if g.input.JustPressed(actionCancel) {
+		g.hud.targeting = ""
+		return false
+	}
+	if !g.input.JustPressed(actionSelect) {
+		return false
+	}
+
+	x, y, cursor, ok := g.cursorWorldCommandTarget()
+	if !ok {
+		return false
+	}
+	switch g.hud.targeting {
+	case actionMove:
+		g.commandSelectedMove(x, y)
+	case actionFire:
+		g.commandSelectedFire(cursor)
+	}
+	g.hud.targeting = ""
+	return true
+}
+
+// handleStopAndHold gives the stop and hold orders from their bindings.
+func (g *Game) handleStopAndHold() {
+	if !g.units.HasSelected() {
+		return
+	}
+
+	switch {
+	case g.input.JustPressed(actionStop):
+		g.commandSelectedStop()
+	case g.input.JustPressed(actionHold):
+		g.commandSelectedHold()
+	}
+}
+
+func (g *Game) commandSelectedStop() {
+	g.hud.targeting = ""
+	g.pathErr = g.units.CommandSelectedStop()
+}
+
+func (g *Game) commandSelectedHold() {
+	g.hud.targeting = ""
+	g.pathErr = g.units.CommandSelectedHold()
+}
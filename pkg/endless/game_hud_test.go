@@ -0,0 +1,69 @@
+package endless
+
+import (
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// TestCommandCardSitsAboveMinimapAndDisablesUnusableOrders lays the card out for a selection
+// without weapons and checks it clears the minimap and greys out Fire only.
+func TestCommandCardSitsAboveMinimapAndDisablesUnusableOrders(t *testing.T) {
+	g := &Game{world: world.New(world.Config{Columns: 256, Rows: 256, TileSize: 16}), screenWidth: 1280, screenHeight: 960}
+	g.hud = newGameHUD(g)
+	minimap, _ := minimapLayout(g.world, g.screenWidth, g.screenHeight)
+
+	g.layoutCommandCard(unit.SelectionSummary{Count: 2, Mobile: 2}, minimap.Max.X, minimap.Min.Y-hudGap)
+	card := g.hud.commands.Rect
+	if g.hud.commands.Hidden || card.Max.X != minimap.Max.X || card.Max.Y > minimap.Min.Y {
+		t.Fatalf("command card = %+v, hidden %t, want it shown above the minimap %+v", card, g.hud.commands.Hidden, minimap)
+	}
+	for _, button := range []struct {
+		name     string
+		disabled bool
+		want     bool
+	}{
+		{"move", g.hud.move.Disabled, false},
+		{"fire", g.hud.fire.Disabled, true},
+		{"stop", g.hud.stop.Disabled, false},
+		{"hold", g.hud.hold.Disabled, false},
+	} {
+		if button.disabled != button.want {
+			t.Fatalf("%s disabled = %t, want %t", button.name, button.disabled, button.want)
+		}
+	}
+	if g.hud.layer.ButtonAt(g.hud.hold.Rect.Min) != g.hud.hold {
+		t.Fatal("ButtonAt(hold corner) does not hit the hold button")
+	}
+
+	g.hud.targeting = actionMove
+	g.layoutCommandCard(unit.SelectionSummary{}, minimap.Max.X, minimap.Min.Y-hudGap)
+	if !g.hud.commands.Hidden || g.hud.targeting != "" {
+		t.Fatal("empty selection kept the command card or the armed order")
+	}
+}
+
+func TestSelectionPanelTextSummarizesGroupsAndSplitsScenarioHeadline(t *testing.T) {
+	details := "Object #7  Kind: runner\nState: moving"
+	if got := selectionPanelText(unit.SelectionSummary{Count: 1}, details); got != details {
+		t.Fatalf("selectionPanelText(single) = %q, want the unit details", got)
+	}
+
+	got := selectionPanelText(unit.SelectionSummary{
+		Count:     3,
+		Kinds:     []unit.KindCount{{Kind: "runner", Count: 2}, {Kind: "wall", Count: 1}},
+		Health:    5,
+		MaxHealth: 7,
+		Mobile:    2,
+		Moving:    1,
+	}, details)
+	want := "Selected: 3  (runner 2, wall 1)\nHP: 5/7  Moving: 1/2  Holding: 0\nPrimary: Object #7  Kind: runner"
+	if got != want {
+		t.Fatalf("selectionPanelText(group) = %q, want %q", got, want)
+	}
+
+	if headline, detail := scenarioStatusText("Scene: duel\nPolicy: greedy\nReward: 1"); headline != "Scene: duel" || detail != "Policy: greedy\nReward: 1" {
+		t.Fatalf("scenarioStatusText() = %q, %q, want the first line split from the rest", headline, detail)
+	}
+}
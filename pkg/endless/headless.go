@@ -0,0 +1,31 @@
+package endless
+
+import (
+	"fmt"
+
+	"github.com/unng-lab/endless/pkg/endless/headless"
+	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// NewHeadlessRunner builds the same world and scenario NewGameWithConfig would choose for the
+// config, but hands them to a window-less runner instead of the Ebiten game. Replay playback
+// is not supported here because the replay player already advances its own manager headlessly.
+func NewHeadlessRunner(config GameConfig, updateWorkers int) (*headless.Runner, error) {
+	config = normalizedGameConfig(config)
+	if config.ReplayPath != "" {
+		return nil, fmt.Errorf("headless runner does not play replays; use replay.Player instead")
+	}
+
+	gameWorld := world.New(config.worldConfig())
+	selectedScenario, err := gamescenario.New(gamescenario.Config{
+		Mode:   config.Mode,
+		RLDuel: config.RLDuel,
+		Seed:   config.Seed,
+	}, gameWorld)
+	if err != nil {
+		return nil, fmt.Errorf("create %s scenario: %w", config.Mode, err)
+	}
+
+	return headless.NewRunner(gameWorld, selectedScenario, updateWorkers), nil
+}
@@ -0,0 +1,312 @@
+package headless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/unng-lab/endless/pkg/unit"
+)
+
+// BenchmarkReportVersion is bumped whenever a metric changes meaning, so DiffBenchmarkReports
+// can refuse to compare numbers that are not measured the same way.
+const BenchmarkReportVersion = 1
+
+// BenchmarkConfig describes one repeatable benchmark run. Scenario and Seed are only recorded in
+// the report; the caller must already have built the runner with the same values.
+type BenchmarkConfig struct {
+	Scenario      string
+	Seed          int64
+	Ticks         int64
+	UpdateWorkers int
+}
+
+// BenchmarkEnvironment records where a report was produced, because tick throughput is only
+// comparable between reports taken on the same machine and toolchain.
+type BenchmarkEnvironment struct {
+	GoVersion  string `json:"go_version"`
+	GOOS       string `json:"goos"`
+	GOARCH     string `json:"goarch"`
+	NumCPU     int    `json:"num_cpu"`
+	GOMAXPROCS int    `json:"gomaxprocs"`
+	Revision   string `json:"revision,omitempty"`
+	Modified   bool   `json:"modified,omitempty"`
+}
+
+// BenchmarkReport is the JSON document written by one benchmark run. Allocation numbers cover
+// only the measured ticks; scene seeding is timed separately and excluded from every rate.
+type BenchmarkReport struct {
+	Version       int                  `json:"version"`
+	CreatedAt     time.Time            `json:"created_at"`
+	Scenario      string               `json:"scenario"`
+	Seed          int64                `json:"seed"`
+	Ticks         int64                `json:"ticks"`
+	UpdateWorkers int                  `json:"update_workers"`
+	Environment   BenchmarkEnvironment `json:"environment"`
+
+	SeedDuration   time.Duration `json:"seed_duration_ns"`
+	Elapsed        time.Duration `json:"elapsed_ns"`
+	TicksPerSecond float64       `json:"ticks_per_second"`
+	TickTime       TickTimeStats `json:"tick_time"`
+
+	Allocations        uint64  `json:"allocations"`
+	AllocatedBytes     uint64  `json:"allocated_bytes"`
+	AllocationsPerTick float64 `json:"allocations_per_tick"`
+	BytesPerTick       float64 `json:"bytes_per_tick"`
+	GCCycles           uint32  `json:"gc_cycles"`
+
+	Units               unit.UnitCounts  `json:"units"`
+	PathfindingCalls    int64            `json:"pathfinding_calls"`
+	PathfindingFailures int64            `json:"pathfinding_failures"`
+	JobCompletedCount   int64            `json:"job_completed_count"`
+	JobFailedCount      int64            `json:"job_failed_count"`
+	Counters            map[string]int64 `json:"counters,omitempty"`
+}
+
+// RunBenchmark seeds the scene, then runs the measured ticks between two memory-stat samples.
+// A forced GC before the first sample keeps garbage left over from seeding out of the GC count.
+func RunBenchmark(ctx context.Context, runner *Runner, config BenchmarkConfig) (BenchmarkReport, error) {
+	if runner == nil {
+		return BenchmarkReport{}, fmt.Errorf("headless runner is nil")
+	}
+	if runner.Tick() != 0 {
+		return BenchmarkReport{}, fmt.Errorf("benchmark needs a fresh runner, got one at tick %d", runner.Tick())
+	}
+
+	seedDuration := runner.Seed()
+	runtime.GC()
+	pathBefore := runner.Manager().PathfindingStats()
+	var memBefore runtime.MemStats
+	runtime.ReadMemStats(&memBefore)
+
+	run, err := runner.Run(ctx, config.Ticks)
+	if err != nil {
+		return BenchmarkReport{}, err
+	}
+
+	var memAfter runtime.MemStats
+	runtime.ReadMemStats(&memAfter)
+	pathAfter := runner.Manager().PathfindingStats()
+
+	report := BenchmarkReport{
+		Version:             BenchmarkReportVersion,
+		CreatedAt:           time.Now().UTC(),
+		Scenario:            config.Scenario,
+		Seed:                config.Seed,
+		Ticks:               run.Ticks,
+		UpdateWorkers:       config.UpdateWorkers,
+		Environment:         currentBenchmarkEnvironment(),
+		SeedDuration:        seedDuration,
+		Elapsed:             run.Elapsed,
+		TicksPerSecond:      run.TicksPerSecond,
+		TickTime:            run.TickTime,
+		Allocations:         memAfter.Mallocs - memBefore.Mallocs,
+		AllocatedBytes:      memAfter.TotalAlloc - memBefore.TotalAlloc,
+		GCCycles:            memAfter.NumGC - memBefore.NumGC,
+		Units:               run.Units,
+		PathfindingCalls:    pathAfter.Calls - pathBefore.Calls,
+		PathfindingFailures: pathAfter.Failures - pathBefore.Failures,
+		JobCompletedCount:   run.Counters["jobs_completed"],
+		JobFailedCount:      run.Counters["jobs_failed"],
+		Counters:            run.Counters,
+	}
+	if run.Ticks > 0 {
+		report.AllocationsPerTick = float64(report.Allocations) / float64(run.Ticks)
+		report.BytesPerTick = float64(report.AllocatedBytes) / float64(run.Ticks)
+	}
+	return report, nil
+}
+
+func currentBenchmarkEnvironment() BenchmarkEnvironment {
+	environment := BenchmarkEnvironment{
+		GoVersion:  runtime.Version(),
+		GOOS:       runtime.GOOS,
+		GOARCH:     runtime.GOARCH,
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				environment.Revision = setting.Value
+			case "vcs.modified":
+				environment.Modified = setting.Value == "true"
+			}
+		}
+	}
+	return environment
+}
+
+// SaveBenchmarkReport writes the report as indented JSON, creating parent directories.
+func SaveBenchmarkReport(path string, report BenchmarkReport) error {
+	payload, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode benchmark report: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create benchmark report directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, append(payload, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write benchmark report: %w", err)
+	}
+	return nil
+}
+
+// LoadBenchmarkReport reads one report written by SaveBenchmarkReport.
+func LoadBenchmarkReport(path string) (BenchmarkReport, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return BenchmarkReport{}, fmt.Errorf("read benchmark report: %w", err)
+	}
+
+	var report BenchmarkReport
+	if err := json.Unmarshal(payload, &report); err != nil {
+		return BenchmarkReport{}, fmt.Errorf("decode benchmark report %s: %w", path, err)
+	}
+	return report, nil
+}
+
+// MetricDirection says which way a metric should move. Workload metrics such as unit counts or
+// path searches are informational: they describe how much the scene did, so a change there
+// explains a timing difference rather than being a regression on its own.
+type MetricDirection string
+
+const (
+	MetricHigherIsBetter MetricDirection = "higher_is_better"
+	MetricLowerIsBetter  MetricDirection = "lower_is_better"
+	MetricInformational  MetricDirection = "informational"
+)
+
+// BenchmarkMetricDelta compares one metric of two reports. Change is the relative difference
+// from base to head, and Regression marks a change in the bad direction beyond the threshold.
+type BenchmarkMetricDelta struct {
+	Name       string          `json:"name"`
+	Base       float64         `json:"base"`
+	Head       float64         `json:"head"`
+	Change     float64         `json:"change"`
+	Direction  MetricDirection `json:"direction"`
+	Regression bool            `json:"regression"`
+}
+
+// BenchmarkDiff is the comparison of a head report against a base report. Warnings list setup
+// differences, such as another seed or machine, that make the numbers less comparable.
+type BenchmarkDiff struct {
+	Threshold float64                `json:"threshold"`
+	Warnings  []string               `json:"warnings,omitempty"`
+	Metrics   []BenchmarkMetricDelta `json:"metrics"`
+}
+
+// DiffBenchmarkReports compares every metric of head against base. threshold is a fraction, so
+// 0.05 flags metrics that got more than five percent worse. Reports with a different version
+// cannot be compared at all.
+func DiffBenchmarkReports(base, head BenchmarkReport, threshold float64) (BenchmarkDiff, error) {
+	if base.Version != head.Version {
+		return BenchmarkDiff{}, fmt.Errorf("benchmark report versions differ: base %d, head %d", base.Version, head.Version)
+	}
+
+	diff := BenchmarkDiff{Threshold: threshold}
+	if base.Scenario != head.Scenario {
+		diff.Warnings = append(diff.Warnings, fmt.Sprintf("scenario differs: %s vs %s", base.Scenario, head.Scenario))
+	}
+	if base.Seed != head.Seed {
+		diff.Warnings = append(diff.Warnings, fmt.Sprintf("seed differs: %d vs %d", base.Seed, head.Seed))
+	}
+	if base.Ticks != head.Ticks {
+		diff.Warnings = append(diff.Warnings, fmt.Sprintf("tick count differs: %d vs %d", base.Ticks, head.Ticks))
+	}
+	if base.UpdateWorkers != head.UpdateWorkers {
+		diff.Warnings = append(diff.Warnings, fmt.Sprintf("update workers differ: %d vs %d", base.UpdateWorkers, head.UpdateWorkers))
+	}
+	if base.Environment.GOOS != head.Environment.GOOS ||
+		base.Environment.GOARCH != head.Environment.GOARCH ||
+		base.Environment.NumCPU != head.Environment.NumCPU ||
+		base.Environment.GOMAXPROCS != head.Environment.GOMAXPROCS {
+		diff.Warnings = append(diff.Warnings, "reports come from different machines or GOMAXPROCS settings")
+	}
+	if base.Environment.GoVersion != head.Environment.GoVersion {
+		diff.Warnings = append(diff.Warnings, fmt.Sprintf("go version differs: %s vs %s", base.Environment.GoVersion, head.Environment.GoVersion))
+	}
+
+	add := func(name string, baseValue, headValue float64, direction MetricDirection) {
+		delta := BenchmarkMetricDelta{
+			Name:      name,
+			Base:      baseValue,
+			Head:      headValue,
+			Direction: direction,
+		}
+		if baseValue != 0 {
+			delta.Change = (headValue - baseValue) / math.Abs(baseValue)
+		}
+		switch direction {
+		case MetricHigherIsBetter:
+			delta.Regression = -delta.Change > threshold
+		case MetricLowerIsBetter:
+			delta.Regression = delta.Change > threshold
+		}
+		diff.Metrics = append(diff.Metrics, delta)
+	}
+	add("ticks_per_second", base.TicksPerSecond, head.TicksPerSecond, MetricHigherIsBetter)
+	add("tick_p50_ns", float64(base.TickTime.P50), float64(head.TickTime.P50), MetricLowerIsBetter)
+	add("tick_p99_ns", float64(base.TickTime.P99), float64(head.TickTime.P99), MetricLowerIsBetter)
+	add("tick_max_ns", float64(base.TickTime.Max), float64(head.TickTime.Max), MetricLowerIsBetter)
+	add("seed_duration_ns", float64(base.SeedDuration), float64(head.SeedDuration), MetricLowerIsBetter)
+	add("allocations_per_tick", base.AllocationsPerTick, head.AllocationsPerTick, MetricLowerIsBetter)
+	add("bytes_per_tick", base.BytesPerTick, head.BytesPerTick, MetricLowerIsBetter)
+	add("gc_cycles", float64(base.GCCycles), float64(head.GCCycles), MetricLowerIsBetter)
+	add("units", float64(base.Units.Total), float64(head.Units.Total), MetricInformational)
+	add("projectiles", float64(base.Units.Projectiles), float64(head.Units.Projectiles), MetricInformational)
+	add("pathfinding_calls", float64(base.PathfindingCalls), float64(head.PathfindingCalls), MetricInformational)
+	add("job_completed_count", float64(base.JobCompletedCount), float64(head.JobCompletedCount), MetricInformational)
+	add("job_failed_count", float64(base.JobFailedCount), float64(head.JobFailedCount), MetricInformational)
+	return diff, nil
+}
+
+// Regressions returns the metrics that got worse by more than the diff threshold.
+func (d BenchmarkDiff) Regressions() []BenchmarkMetricDelta {
+	regressions := make([]BenchmarkMetricDelta, 0)
+	for _, metric := range d.Metrics {
+		if metric.Regression {
+			regressions = append(regressions, metric)
+		}
+	}
+	return regressions
+}
+
+// WriteText renders the diff as an aligned table for terminals and CI logs.
+func (d BenchmarkDiff) WriteText(w io.Writer) error {
+	var builder strings.Builder
+	for _, warning := range d.Warnings {
+		fmt.Fprintf(&builder, "warning: %s\n", warning)
+	}
+	fmt.Fprintf(&builder, "%-22s %16s %16s %9s\n", "metric", "base", "head", "change")
+	for _, metric := range d.Metrics {
+		marker := ""
+		if metric.Regression {
+			marker = "  REGRESSION"
+		}
+		fmt.Fprintf(
+			&builder,
+			"%-22s %16.1f %16.1f %+8.1f%%%s\n",
+			metric.Name,
+			metric.Base,
+			metric.Head,
+			metric.Change*100,
+			marker,
+		)
+	}
+	fmt.Fprintf(&builder, "%d regression(s) beyond %.1f%%\n", len(d.Regressions()), d.Threshold*100)
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
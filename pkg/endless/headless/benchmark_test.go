@@ -0,0 +1,105 @@
+package headless
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// TestRunBenchmarkReportsWorkloadAndSurvivesSaveLoad verifies that the benchmark report counts
+// the path search issued by the scenario, samples allocations for the measured ticks and keeps
+// every field across a JSON round trip.
+func TestRunBenchmarkReportsWorkloadAndSurvivesSaveLoad(t *testing.T) {
+	runner := NewRunner(world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16}), &recordingScenario{}, 1)
+	defer runner.Close()
+
+	report, err := RunBenchmark(context.Background(), runner, BenchmarkConfig{
+		Scenario: "recording",
+		Seed:     7,
+		Ticks:    12,
+	})
+	if err != nil {
+		t.Fatalf("RunBenchmark() error = %v", err)
+	}
+	if report.Version != BenchmarkReportVersion || report.Scenario != "recording" || report.Seed != 7 || report.Ticks != 12 {
+		t.Fatalf("report header = %+v, want version/scenario/seed/ticks from config", report)
+	}
+	if report.PathfindingCalls != 1 || report.PathfindingFailures != 0 {
+		t.Fatalf("pathfinding = %d calls %d failures, want 1 and 0", report.PathfindingCalls, report.PathfindingFailures)
+	}
+	if report.Units.Total != 2 || report.TicksPerSecond <= 0 {
+		t.Fatalf("report units %+v tps %.1f, want two units and positive throughput", report.Units, report.TicksPerSecond)
+	}
+
+	path := filepath.Join(t.TempDir(), "reports", "stress.json")
+	if err := SaveBenchmarkReport(path, report); err != nil {
+		t.Fatalf("SaveBenchmarkReport() error = %v", err)
+	}
+	loaded, err := LoadBenchmarkReport(path)
+	if err != nil {
+		t.Fatalf("LoadBenchmarkReport() error = %v", err)
+	}
+	if loaded.TickTime != report.TickTime || loaded.Allocations != report.Allocations || !loaded.CreatedAt.Equal(report.CreatedAt) {
+		t.Fatalf("loaded report = %+v, want %+v", loaded, report)
+	}
+
+	if _, err := RunBenchmark(context.Background(), runner, BenchmarkConfig{Ticks: 1}); err == nil {
+		t.Fatal("RunBenchmark() on a used runner error = nil, want error")
+	}
+}
+
+// TestDiffBenchmarkReportsFlagsOnlyChangesInTheBadDirection verifies the regression rules:
+// throughput must not drop, timings and allocations must not grow, and workload metrics are
+// reported without ever counting as regressions.
+func TestDiffBenchmarkReportsFlagsOnlyChangesInTheBadDirection(t *testing.T) {
+	base := BenchmarkReport{
+		Version:            BenchmarkReportVersion,
+		Scenario:           "stress",
+		Seed:               1,
+		Ticks:              100,
+		TicksPerSecond:     100,
+		TickTime:           TickTimeStats{P50: 10 * time.Millisecond, P99: 20 * time.Millisecond},
+		AllocationsPerTick: 1000,
+		PathfindingCalls:   50,
+	}
+	head := base
+	head.Seed = 2
+	head.TicksPerSecond = 90
+	head.TickTime.P50 = 9 * time.Millisecond
+	head.TickTime.P99 = 21 * time.Millisecond
+	head.AllocationsPerTick = 1200
+	head.PathfindingCalls = 80
+
+	diff, err := DiffBenchmarkReports(base, head, 0.05)
+	if err != nil {
+		t.Fatalf("DiffBenchmarkReports() error = %v", err)
+	}
+
+	regressed := make([]string, 0)
+	for _, metric := range diff.Regressions() {
+		regressed = append(regressed, metric.Name)
+	}
+	if got, want := strings.Join(regressed, ","), "ticks_per_second,allocations_per_tick"; got != want {
+		t.Fatalf("regressions = %s, want %s", got, want)
+	}
+	if len(diff.Warnings) != 1 || !strings.Contains(diff.Warnings[0], "seed differs") {
+		t.Fatalf("warnings = %v, want one seed warning", diff.Warnings)
+	}
+
+	var text strings.Builder
+	if err := diff.WriteText(&text); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	if !strings.Contains(text.String(), "2 regression(s) beyond 5.0%") {
+		t.Fatalf("WriteText() = %q, want regression summary", text.String())
+	}
+
+	head.Version++
+	if _, err := DiffBenchmarkReports(base, head, 0.05); err == nil {
+		t.Fatal("DiffBenchmarkReports() with mismatched versions error = nil, want error")
+	}
+}
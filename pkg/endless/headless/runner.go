@@ -0,0 +1,215 @@
+package headless
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// Runner drives one scenario and its unit manager without Ebiten. It reproduces the tick order
+// of the desktop game loop: the scenario reacts first, then the manager simulates the tick, so
+// a scene behaves the same way in a window, in a batch job or inside a benchmark.
+type Runner struct {
+	world    world.World
+	scenario gamescenario.Scenario
+	manager  *unit.Manager
+
+	tick         int64
+	seeded       bool
+	seedDuration time.Duration
+
+	// progressInterval logs one progress line every N ticks during Run; zero keeps Run quiet.
+	progressInterval int64
+}
+
+// TickTimeStats summarizes the wall-clock duration of the simulated ticks of one run.
+type TickTimeStats struct {
+	Min  time.Duration `json:"min_ns"`
+	Mean time.Duration `json:"mean_ns"`
+	P50  time.Duration `json:"p50_ns"`
+	P99  time.Duration `json:"p99_ns"`
+	Max  time.Duration `json:"max_ns"`
+}
+
+// Report describes one Run call. Seed time is reported separately because large scenes spend
+// most of their startup registering static bodies, which would otherwise distort tick timings.
+type Report struct {
+	Ticks          int64            `json:"ticks"`
+	FinalTick      int64            `json:"final_tick"`
+	SeedDuration   time.Duration    `json:"seed_duration_ns"`
+	Elapsed        time.Duration    `json:"elapsed_ns"`
+	TicksPerSecond float64          `json:"ticks_per_second"`
+	TickTime       TickTimeStats    `json:"tick_time"`
+	Units          unit.UnitCounts  `json:"units"`
+	Counters       map[string]int64 `json:"counters,omitempty"`
+}
+
+// NewRunner creates the manager for the given world and binds the scenario to it. Units are
+// seeded lazily by the first Seed, Step or Run call so callers can attach recorders or event
+// subscriptions to Manager before the initial spawn layout is registered.
+func NewRunner(gameWorld world.World, scenario gamescenario.Scenario, updateWorkers int) *Runner {
+	return &Runner{
+		world:    gameWorld,
+		scenario: scenario,
+		manager:  unit.NewManagerWithWorkers(gameWorld, updateWorkers),
+	}
+}
+
+// SetProgressInterval enables periodic progress logging during Run for long batch jobs.
+func (r *Runner) SetProgressInterval(ticks int64) {
+	if r == nil {
+		return
+	}
+
+	r.progressInterval = max(ticks, 0)
+}
+
+// Manager returns the unit manager advanced by the runner.
+func (r *Runner) Manager() *unit.Manager {
+	if r == nil {
+		return nil
+	}
+
+	return r.manager
+}
+
+// World returns the world the scenario was built for.
+func (r *Runner) World() world.World {
+	if r == nil {
+		return world.World{}
+	}
+
+	return r.world
+}
+
+// Tick reports the last simulated tick.
+func (r *Runner) Tick() int64 {
+	if r == nil {
+		return 0
+	}
+
+	return r.tick
+}
+
+// Seed registers the scenario's initial units exactly once and reports how long that took.
+func (r *Runner) Seed() time.Duration {
+	if r == nil || r.seeded {
+		return 0
+	}
+
+	r.seeded = true
+	startedAt := time.Now()
+	if r.scenario != nil {
+		r.scenario.SeedUnits(r.manager)
+	}
+	r.seedDuration = time.Since(startedAt)
+	return r.seedDuration
+}
+
+// Step advances the scenario and the manager by one tick and returns the time it took.
+func (r *Runner) Step() time.Duration {
+	if r == nil {
+		return 0
+	}
+
+	r.Seed()
+	startedAt := time.Now()
+	r.tick++
+	if r.scenario != nil {
+		r.scenario.Update(r.tick, r.manager)
+	}
+	r.manager.Update(r.tick)
+	return time.Since(startedAt)
+}
+
+// Run simulates the requested number of ticks and summarizes their timing together with the
+// final unit population and scenario counters. Cancellation is checked between ticks, and the
+// returned report then covers only the ticks that actually ran.
+func (r *Runner) Run(ctx context.Context, ticks int64) (Report, error) {
+	if r == nil {
+		return Report{}, fmt.Errorf("headless runner is nil")
+	}
+	if ticks <= 0 {
+		return Report{}, fmt.Errorf("tick count must be positive: %d", ticks)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	r.Seed()
+	durations := make([]time.Duration, 0, ticks)
+	startedAt := time.Now()
+	var runErr error
+	for range ticks {
+		if err := ctx.Err(); err != nil {
+			runErr = err
+			break
+		}
+
+		durations = append(durations, r.Step())
+		if r.progressInterval > 0 && r.tick%r.progressInterval == 0 {
+			log.Printf("[headless] tick=%d elapsed=%s units=%d", r.tick, time.Since(startedAt), r.manager.UnitCounts().Total)
+		}
+	}
+
+	return r.report(durations, time.Since(startedAt)), runErr
+}
+
+// Close stops the manager worker pool.
+func (r *Runner) Close() {
+	if r == nil {
+		return
+	}
+
+	r.manager.Close()
+}
+
+func (r *Runner) report(durations []time.Duration, elapsed time.Duration) Report {
+	report := Report{
+		Ticks:        int64(len(durations)),
+		FinalTick:    r.tick,
+		SeedDuration: r.seedDuration,
+		Elapsed:      elapsed,
+		TickTime:     summarizeTickTimes(durations),
+		Units:        r.manager.UnitCounts(),
+		Counters:     gamescenario.Counters(r.scenario),
+	}
+	if elapsed > 0 {
+		report.TicksPerSecond = float64(report.Ticks) / elapsed.Seconds()
+	}
+	return report
+}
+
+// summarizeTickTimes sorts a copy of the samples and reads nearest-rank percentiles from it.
+func summarizeTickTimes(durations []time.Duration) TickTimeStats {
+	if len(durations) == 0 {
+		return TickTimeStats{}
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	total := time.Duration(0)
+	for _, duration := range sorted {
+		total += duration
+	}
+
+	return TickTimeStats{
+		Min:  sorted[0],
+		Mean: total / time.Duration(len(sorted)),
+		P50:  percentile(sorted, 0.50),
+		P99:  percentile(sorted, 0.99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+func percentile(sorted []time.Duration, quantile float64) time.Duration {
+	index := int(quantile*float64(len(sorted))+0.5) - 1
+	index = min(max(index, 0), len(sorted)-1)
+	return sorted[index]
+}
@@ -0,0 +1,123 @@
+package headless
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// recordingScenario seeds one runner and one wall, sends the runner across the map on the
+// first tick and remembers the manager tick it observed on every update.
+type recordingScenario struct {
+	runnerID     int64
+	seedCalls    int
+	updateTicks  []int64
+	orderFailure error
+}
+
+func (s *recordingScenario) SeedUnits(manager *unit.Manager) {
+	s.seedCalls++
+	s.runnerID = manager.AddUnit(unit.NewRunner(geom.Point{X: 8, Y: 8}, false, 0))
+	manager.AddUnit(unit.NewWall(geom.Point{X: 8, Y: 120}))
+}
+
+func (s *recordingScenario) Update(gameTick int64, manager *unit.Manager) {
+	s.updateTicks = append(s.updateTicks, gameTick)
+	if gameTick == 1 {
+		s.orderFailure = manager.IssueMoveOrder(s.runnerID, geom.Point{X: 200, Y: 8})
+	}
+}
+
+func (s *recordingScenario) DebugText() string {
+	return ""
+}
+
+func (s *recordingScenario) Counters() map[string]int64 {
+	return map[string]int64{"updates": int64(len(s.updateTicks))}
+}
+
+// TestRunnerRunReportsTicksUnitsAndScenarioCounters verifies that a headless run seeds once,
+// feeds every tick to the scenario in order and reports population and counters at the end.
+func TestRunnerRunReportsTicksUnitsAndScenarioCounters(t *testing.T) {
+	scenario := &recordingScenario{}
+	runner := NewRunner(world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16}), scenario, 1)
+	defer runner.Close()
+
+	report, err := runner.Run(context.Background(), 20)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if scenario.orderFailure != nil {
+		t.Fatalf("IssueMoveOrder() error = %v", scenario.orderFailure)
+	}
+	if scenario.seedCalls != 1 {
+		t.Fatalf("SeedUnits calls = %d, want 1", scenario.seedCalls)
+	}
+	if len(scenario.updateTicks) != 20 || scenario.updateTicks[0] != 1 || scenario.updateTicks[19] != 20 {
+		t.Fatalf("scenario update ticks = %v, want 1..20", scenario.updateTicks)
+	}
+
+	if report.Ticks != 20 || report.FinalTick != 20 {
+		t.Fatalf("report ticks = %d final %d, want 20 and 20", report.Ticks, report.FinalTick)
+	}
+	if report.Units != (unit.UnitCounts{Total: 2, Mobile: 1, Static: 1}) {
+		t.Fatalf("report units = %+v, want one runner and one wall", report.Units)
+	}
+	if report.Counters["updates"] != 20 {
+		t.Fatalf("report counters = %v, want updates=20", report.Counters)
+	}
+	if report.TickTime.Min > report.TickTime.P50 || report.TickTime.P50 > report.TickTime.P99 || report.TickTime.P99 > report.TickTime.Max {
+		t.Fatalf("tick time stats are not ordered: %+v", report.TickTime)
+	}
+
+	snapshot, ok := runner.Manager().UnitSnapshot(scenario.runnerID)
+	if !ok {
+		t.Fatal("UnitSnapshot() did not find the runner")
+	}
+	if !snapshot.IsMoving && !snapshot.HasActiveMoveOrder {
+		t.Fatalf("runner snapshot = %+v, want the move order from tick 1 to be in progress", snapshot)
+	}
+}
+
+// TestRunnerRunStopsOnCanceledContext verifies that cancellation ends the run between ticks and
+// still returns the partial report.
+func TestRunnerRunStopsOnCanceledContext(t *testing.T) {
+	runner := NewRunner(world.New(world.Config{Columns: 16, Rows: 16, TileSize: 16}), &recordingScenario{}, 1)
+	defer runner.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := runner.Run(ctx, 10)
+	if err == nil {
+		t.Fatal("Run() error = nil, want context cancellation")
+	}
+	if report.Ticks != 0 {
+		t.Fatalf("report ticks = %d, want 0", report.Ticks)
+	}
+	if report.Units.Total != 2 {
+		t.Fatalf("report units = %+v, want the seeded units", report.Units)
+	}
+}
+
+func TestSummarizeTickTimesUsesNearestRankPercentiles(t *testing.T) {
+	durations := make([]time.Duration, 0, 100)
+	for index := 100; index >= 1; index-- {
+		durations = append(durations, time.Duration(index)*time.Millisecond)
+	}
+
+	stats := summarizeTickTimes(durations)
+	want := TickTimeStats{
+		Min:  time.Millisecond,
+		Mean: 50500 * time.Microsecond,
+		P50:  50 * time.Millisecond,
+		P99:  99 * time.Millisecond,
+		Max:  100 * time.Millisecond,
+	}
+	if stats != want {
+		t.Fatalf("summarizeTickTimes() = %+v, want %+v", stats, want)
+	}
+}
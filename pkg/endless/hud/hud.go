@@ -0,0 +1,323 @@
+package hud
+
+import (
+	"image/color"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+const (
+	// GlyphWidth and LineHeight are the cell size of Ebiten's debug font, which draws every
+	// label. Layout code sizes panels from them.
+	GlyphWidth = 6
+	LineHeight = 16
+
+	// tooltipDelayFrames is how long the cursor has to rest on a button before its tooltip
+	// shows, so sweeping across the command card does not flash every tooltip.
+	tooltipDelayFrames = 30
+	tooltipPadding     = 4
+	tooltipOffset      = 18
+	accentHeight       = 3
+	shadowOffset       = 4
+)
+
+var (
+	panelColor          = color.NRGBA{R: 19, G: 23, B: 30, A: 230}
+	accentColor         = color.NRGBA{R: 255, G: 214, B: 102, A: 255}
+	shadowColor         = color.NRGBA{R: 0, G: 0, B: 0, A: 90}
+	buttonColor         = color.NRGBA{R: 44, G: 52, B: 64, A: 255}
+	buttonHoverColor    = color.NRGBA{R: 66, G: 78, B: 96, A: 255}
+	buttonPressedColor  = color.NRGBA{R: 30, G: 36, B: 44, A: 255}
+	buttonActiveColor   = color.NRGBA{R: 120, G: 96, B: 40, A: 255}
+	buttonDisabledColor = color.NRGBA{R: 32, G: 36, B: 42, A: 200}
+	tooltipColor        = color.NRGBA{R: 8, G: 12, B: 16, A: 240}
+)
+
+// TextSize returns the size of a label in the debug font: the longest line times the glyph
+// width, and one line height per line.
+func TextSize(text string) (float64, float64) {
+	lines := strings.Split(text, "\n")
+	longest := 0
+	for _, line := range lines {
+		longest = max(longest, len([]rune(line)))
+	}
+	return float64(longest * GlyphWidth), float64(len(lines) * LineHeight)
+}
+
+// Truncate shortens a single line of text to fit the width in the debug font, marking the cut
+// with an ellipsis.
+func Truncate(text string, width float64) string {
+	runes := []rune(text)
+	fits := int(width) / GlyphWidth
+	if len(runes) <= fits {
+		return text
+	}
+	if fits <= 3 {
+		return ""
+	}
+	return string(runes[:fits-3]) + "..."
+}
+
+// Widget is one element inside a panel. The set is closed: panels hold labels and buttons.
+type Widget interface {
+	// Bounds is the screen rectangle the widget covers.
+	Bounds() geom.Rect
+	draw(screen *ebiten.Image, layer *Layer)
+}
+
+// Panel is a filled rectangle with widgets on top. Rect and the widgets are positioned by the
+// owner, usually once per frame from the screen size; Hidden panels neither draw nor take
+// clicks.
+type Panel struct {
+	Rect   geom.Rect
+	Hidden bool
+	// Accent draws a strip along the top edge, the look of the old unit info panel.
+	Accent  bool
+	Widgets []Widget
+}
+
+// Label is static text. Position is the top-left corner of its first line.
+type Label struct {
+	Position geom.Point
+	Text     string
+	Hidden   bool
+}
+
+func (l *Label) Bounds() geom.Rect {
+	width, height := TextSize(l.Text)
+	return geom.Rect{Min: l.Position, Max: geom.Point{X: l.Position.X + width, Y: l.Position.Y + height}}
+}
+
+func (l *Label) draw(screen *ebiten.Image, _ *Layer) {
+	if l.Hidden || l.Text == "" {
+		return
+	}
+	ebitenutil.DebugPrintAt(screen, l.Text, int(l.Position.X), int(l.Position.Y))
+}
+
+// Button runs OnClick when the select button is pressed and released over it. Disabled buttons
+// still show their tooltip, which is where the player learns why they are disabled.
+type Button struct {
+	Rect     geom.Rect
+	Text     string
+	Tooltip  string
+	Hidden   bool
+	Disabled bool
+	// Active marks a toggled or armed state, such as Hold on units that already hold position.
+	Active  bool
+	OnClick func()
+}
+
+func (b *Button) Bounds() geom.Rect {
+	return b.Rect
+}
+
+func (b *Button) draw(screen *ebiten.Image, layer *Layer) {
+	if b.Hidden {
+		return
+	}
+
+	fill := buttonColor
+	switch {
+	case b.Disabled:
+		fill = buttonDisabledColor
+	case layer.pressed == b:
+		fill = buttonPressedColor
+	case b.Active:
+		fill = buttonActiveColor
+	case layer.hovered == b:
+		fill = buttonHoverColor
+	}
+	layer.fillRect(screen, b.Rect, fill)
+	if b.Active && !b.Disabled {
+		layer.fillRect(screen, geom.Rect{Min: b.Rect.Min, Max: geom.Point{X: b.Rect.Max.X, Y: b.Rect.Min.Y + 2}}, accentColor)
+	}
+
+	width, height := TextSize(b.Text)
+	x := b.Rect.Min.X + (b.Rect.Max.X-b.Rect.Min.X-width)/2
+	y := b.Rect.Min.Y + (b.Rect.Max.Y-b.Rect.Min.Y-height)/2
+	ebitenutil.DebugPrintAt(screen, b.Text, int(x), int(y))
+}
+
+// Layer is the retained HUD: the panels, which button is hovered or held, and how long the
+// cursor has rested on it. Panels added later are drawn above earlier ones and win hit tests.
+type Layer struct {
+	panels []*Panel
+
+	cursor      geom.Point
+	hovered     *Button
+	hoverFrames int
+	pressed     *Button
+
+	solid *ebiten.Image
+}
+
+// NewLayer returns a layer with the given panels, bottom first.
+func NewLayer(panels ...*Panel) *Layer {
+	return &Layer{panels: panels}
+}
+
+// Add puts panels on top of the existing ones.
+func (l *Layer) Add(panels ...*Panel) {
+	l.panels = append(l.panels, panels...)
+}
+
+// Contains reports whether the point is over any shown panel. The game hands it to the unit
+// manager as the panel hit test, so clicks on the HUD never reach the map.
+func (l *Layer) Contains(point geom.Point) bool {
+	if l == nil {
+		return false
+	}
+	for _, panel := range l.panels {
+		if !panel.Hidden && rectContains(panel.Rect, point) {
+			return true
+		}
+	}
+	return false
+}
+
+// ButtonAt returns the topmost shown button under the point, or nil.
+func (l *Layer) ButtonAt(point geom.Point) *Button {
+	if l == nil {
+		return nil
+	}
+	for _, panel := range slices.Backward(l.panels) {
+		if panel.Hidden || !rectContains(panel.Rect, point) {
+			continue
+		}
+		for _, widget := range slices.Backward(panel.Widgets) {
+			if button, ok := widget.(*Button); ok && !button.Hidden && rectContains(button.Rect, point) {
+				return button
+			}
+		}
+		// A panel covers whatever lies below it, buttons of lower panels included.
+		return nil
+	}
+	return nil
+}
+
+// Update tracks the cursor and the select button for one frame and runs the OnClick of a
+// button that was pressed and released over it. It reports whether the HUD took the press, so
+// the caller can skip its own handling of it.
+func (l *Layer) Update(cursor geom.Point, justPressed, justReleased bool) bool {
+	if l == nil {
+		return false
+	}
+
+	l.cursor = cursor
+	hovered := l.ButtonAt(cursor)
+	if hovered != l.hovered {
+		l.hovered = hovered
+		l.hoverFrames = 0
+	}
+	if hovered != nil {
+		l.hoverFrames++
+	}
+
+	if justReleased && l.pressed != nil {
+		pressed := l.pressed
+		l.pressed = nil
+		if pressed == hovered && !pressed.Disabled && pressed.OnClick != nil {
+			pressed.OnClick()
+		}
+	}
+	if !justPressed || !l.Contains(cursor) {
+		return false
+	}
+	if hovered != nil && !hovered.Disabled {
+		l.pressed = hovered
+	}
+	// Clicking hides the tooltip until the cursor settles again.
+	l.hoverFrames = 0
+	return true
+}
+
+// Tooltip returns the tooltip of the hovered button once the cursor has rested on it long
+// enough.
+func (l *Layer) Tooltip() (string, bool) {
+	if l == nil || l.hovered == nil || l.hovered.Tooltip == "" || l.hoverFrames < tooltipDelayFrames {
+		return "", false
+	}
+	return l.hovered.Tooltip, true
+}
+
+// Draw renders the shown panels bottom first and the tooltip over everything.
+func (l *Layer) Draw(screen *ebiten.Image) {
+	if l == nil {
+		return
+	}
+
+	for _, panel := range l.panels {
+		if panel.Hidden {
+			continue
+		}
+		shadow := panel.Rect
+		shadow.Min.X += shadowOffset
+		shadow.Min.Y += shadowOffset
+		shadow.Max.X += shadowOffset
+		shadow.Max.Y += shadowOffset
+		l.fillRect(screen, shadow, shadowColor)
+		l.fillRect(screen, panel.Rect, panelColor)
+		if panel.Accent {
+			l.fillRect(screen, geom.Rect{Min: panel.Rect.Min, Max: geom.Point{X: panel.Rect.Max.X, Y: panel.Rect.Min.Y + accentHeight}}, accentColor)
+		}
+		for _, widget := range panel.Widgets {
+			widget.draw(screen, l)
+		}
+	}
+	l.drawTooltip(screen)
+}
+
+// drawTooltip places the tooltip below and right of the cursor, flipped to the other side when
+// it would leave the screen.
+func (l *Layer) drawTooltip(screen *ebiten.Image) {
+	text, ok := l.Tooltip()
+	if !ok {
+		return
+	}
+
+	width, height := TextSize(text)
+	width += tooltipPadding * 2
+	height += tooltipPadding * 2
+	bounds := screen.Bounds()
+	x := l.cursor.X + tooltipOffset
+	y := l.cursor.Y + tooltipOffset
+	if x+width > float64(bounds.Max.X) {
+		x = l.cursor.X - width - tooltipPadding
+	}
+	if y+height > float64(bounds.Max.Y) {
+		y = l.cursor.Y - height - tooltipPadding
+	}
+	x = max(x, 0)
+	y = max(y, 0)
+
+	l.fillRect(screen, geom.Rect{Min: geom.Point{X: x, Y: y}, Max: geom.Point{X: x + width, Y: y + height}}, tooltipColor)
+	ebitenutil.DebugPrintAt(screen, text, int(x+tooltipPadding), int(y+tooltipPadding))
+}
+
+func (l *Layer) fillRect(screen *ebiten.Image, rect geom.Rect, fill color.Color) {
+	width := rect.Max.X - rect.Min.X
+	height := rect.Max.Y - rect.Min.Y
+	if width <= 0 || height <= 0 {
+		return
+	}
+	if l.solid == nil {
+		l.solid = ebiten.NewImage(1, 1)
+		l.solid.Fill(color.White)
+	}
+
+	var op ebiten.DrawImageOptions
+	op.GeoM.Scale(width, height)
+	op.GeoM.Translate(rect.Min.X, rect.Min.Y)
+	op.ColorScale.ScaleWithColor(fill)
+	screen.DrawImage(l.solid, &op)
+}
+
+func rectContains(rect geom.Rect, point geom.Point) bool {
+	return point.X >= rect.Min.X && point.X < rect.Max.X && point.Y >= rect.Min.Y && point.Y < rect.Max.Y
+}
@@ -0,0 +1,97 @@
+package hud
+
+import (
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+func rect(x0, y0, x1, y1 float64) geom.Rect {
+	return geom.Rect{Min: geom.Point{X: x0, Y: y0}, Max: geom.Point{X: x1, Y: y1}}
+}
+
+func TestLayerHitTestsTopmostShownPanel(t *testing.T) {
+	lower := &Button{Rect: rect(10, 10, 40, 30), Text: "Lower"}
+	upper := &Button{Rect: rect(60, 10, 90, 30), Text: "Upper"}
+	layer := NewLayer(
+		&Panel{Rect: rect(0, 0, 100, 40), Widgets: []Widget{lower}},
+		&Panel{Rect: rect(50, 0, 100, 40), Widgets: []Widget{upper}},
+	)
+
+	if got := layer.ButtonAt(geom.Point{X: 20, Y: 20}); got != lower {
+		t.Fatalf("ButtonAt(lower) = %v, want the lower button", got)
+	}
+	if got := layer.ButtonAt(geom.Point{X: 70, Y: 20}); got != upper {
+		t.Fatalf("ButtonAt(upper) = %v, want the upper button", got)
+	}
+	if got := layer.ButtonAt(geom.Point{X: 55, Y: 35}); got != nil {
+		t.Fatalf("ButtonAt(panel background) = %v, want nil", got)
+	}
+	if !layer.Contains(geom.Point{X: 55, Y: 35}) || layer.Contains(geom.Point{X: 100, Y: 20}) {
+		t.Fatal("Contains() does not match the panel rectangles")
+	}
+
+	layer.panels[1].Hidden = true
+	upper.Hidden = true
+	if layer.Contains(geom.Point{X: 120, Y: 20}) || layer.ButtonAt(geom.Point{X: 70, Y: 20}) != nil {
+		t.Fatal("hidden panel or button still takes hits")
+	}
+}
+
+func TestLayerClicksOnReleaseOverTheSameButtonAndDelaysTooltips(t *testing.T) {
+	clicks := 0
+	button := &Button{Rect: rect(10, 10, 40, 30), Text: "Stop", Tooltip: "Stop (X)", OnClick: func() { clicks++ }}
+	disabled := &Button{Rect: rect(50, 10, 80, 30), Text: "Fire", Disabled: true, OnClick: func() { clicks += 100 }}
+	layer := NewLayer(&Panel{Rect: rect(0, 0, 100, 40), Widgets: []Widget{button, disabled}})
+	over := geom.Point{X: 20, Y: 20}
+
+	if !layer.Update(over, true, false) {
+		t.Fatal("Update(press on button) = false, want the HUD to take the press")
+	}
+	layer.Update(over, false, true)
+	if clicks != 1 {
+		t.Fatalf("clicks after press and release = %d, want 1", clicks)
+	}
+
+	layer.Update(over, true, false)
+	layer.Update(geom.Point{X: 200, Y: 200}, false, true)
+	if clicks != 1 {
+		t.Fatalf("clicks after releasing off the button = %d, want still 1", clicks)
+	}
+
+	if !layer.Update(geom.Point{X: 60, Y: 20}, true, false) {
+		t.Fatal("Update(press on disabled button) = false, want the panel to still take the press")
+	}
+	layer.Update(geom.Point{X: 60, Y: 20}, false, true)
+	if clicks != 1 {
+		t.Fatalf("clicks after pressing a disabled button = %d, want still 1", clicks)
+	}
+	if layer.Update(geom.Point{X: 200, Y: 200}, true, false) {
+		t.Fatal("Update(press outside) = true, want the press left to the map")
+	}
+
+	for frame := 0; frame < tooltipDelayFrames; frame++ {
+		if _, ok := layer.Tooltip(); ok {
+			t.Fatalf("tooltip shown after %d frames, want a delay of %d", frame, tooltipDelayFrames)
+		}
+		layer.Update(over, false, false)
+	}
+	if text, ok := layer.Tooltip(); !ok || text != "Stop (X)" {
+		t.Fatalf("Tooltip() = %q, %t, want the hovered button's tooltip", text, ok)
+	}
+}
+
+func TestTextSizeUsesLongestLine(t *testing.T) {
+	if width, height := TextSize("ab\nabcd"); width != 4*GlyphWidth || height != 2*LineHeight {
+		t.Fatalf("TextSize() = %.0fx%.0f, want %dx%d", width, height, 4*GlyphWidth, 2*LineHeight)
+	}
+}
+
+func TestTruncateMarksTheCut(t *testing.T) {
+	if got := Truncate("Scene: basic", 12*GlyphWidth); got != "Scene: basic" {
+		t.Fatalf("Truncate(fitting) = %q, want the text unchanged", got)
+	}
+	if got := Truncate("Scene: basic", 8*GlyphWidth); got != "Scene..." {
+		t.Fatalf("Truncate(long) = %q, want %q", got, "Scene...")
+	}
+}
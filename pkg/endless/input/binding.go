@@ -0,0 +1,215 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// sourceKind tells which device a Source reads.
+type sourceKind uint8
+
+const (
+	sourceKey sourceKind = iota + 1
+	sourceMouse
+	sourceWheel
+	sourceGamepadButton
+	sourceGamepadAxis
+)
+
+// Wheel directions stored in Source.code.
+const (
+	wheelUp   = 1
+	wheelDown = -1
+)
+
+// Source is one physical input: a key, a mouse button, one wheel direction, a button of a
+// standard-layout gamepad or one direction of a gamepad stick. Sources are comparable, so they
+// can key the per-frame state maps.
+type Source struct {
+	kind sourceKind
+	code int
+}
+
+// KeySource returns the source for one keyboard key.
+func KeySource(key ebiten.Key) Source {
+	return Source{kind: sourceKey, code: int(key)}
+}
+
+// MouseSource returns the source for one mouse button.
+func MouseSource(button ebiten.MouseButton) Source {
+	return Source{kind: sourceMouse, code: int(button)}
+}
+
+// GamepadButtonSource returns the source for one standard-layout gamepad button.
+func GamepadButtonSource(button ebiten.StandardGamepadButton) Source {
+	return Source{kind: sourceGamepadButton, code: int(button)}
+}
+
+// GamepadAxisSource returns the source for one direction of a standard-layout stick axis;
+// negative selects left or up, positive right or down.
+func GamepadAxisSource(axis ebiten.StandardGamepadAxis, positive bool) Source {
+	code := int(axis) * 2
+	if positive {
+		code++
+	}
+	return Source{kind: sourceGamepadAxis, code: code}
+}
+
+var (
+	// WheelUp and WheelDown are the two directions of the vertical mouse wheel.
+	WheelUp   = Source{kind: sourceWheel, code: wheelUp}
+	WheelDown = Source{kind: sourceWheel, code: wheelDown}
+)
+
+// mouseButtonNames are the binding names of the mouse buttons; Back and Forward are the side
+// buttons many mice and some touchpads report.
+var mouseButtonNames = map[ebiten.MouseButton]string{
+	ebiten.MouseButtonLeft:   "MouseLeft",
+	ebiten.MouseButtonMiddle: "MouseMiddle",
+	ebiten.MouseButtonRight:  "MouseRight",
+	ebiten.MouseButton3:      "MouseBack",
+	ebiten.MouseButton4:      "MouseForward",
+}
+
+// gamepadButtonNames follow Ebiten's standard layout: RightBottom is A on an Xbox pad and Cross
+// on a PlayStation pad, FrontTop* are the bumpers and LeftTop through LeftRight the d-pad.
+var gamepadButtonNames = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "GamepadRightBottom",
+	ebiten.StandardGamepadButtonRightRight:       "GamepadRightRight",
+	ebiten.StandardGamepadButtonRightLeft:        "GamepadRightLeft",
+	ebiten.StandardGamepadButtonRightTop:         "GamepadRightTop",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "GamepadFrontTopLeft",
+	ebiten.StandardGamepadButtonFrontTopRight:    "GamepadFrontTopRight",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "GamepadFrontBottomLeft",
+	ebiten.StandardGamepadButtonFrontBottomRight: "GamepadFrontBottomRight",
+	ebiten.StandardGamepadButtonCenterLeft:       "GamepadCenterLeft",
+	ebiten.StandardGamepadButtonCenterRight:      "GamepadCenterRight",
+	ebiten.StandardGamepadButtonLeftStick:        "GamepadLeftStick",
+	ebiten.StandardGamepadButtonRightStick:       "GamepadRightStick",
+	ebiten.StandardGamepadButtonLeftTop:          "GamepadLeftTop",
+	ebiten.StandardGamepadButtonLeftBottom:       "GamepadLeftBottom",
+	ebiten.StandardGamepadButtonLeftLeft:         "GamepadLeftLeft",
+	ebiten.StandardGamepadButtonLeftRight:        "GamepadLeftRight",
+	ebiten.StandardGamepadButtonCenterCenter:     "GamepadCenterCenter",
+}
+
+// gamepadAxisNames name both directions of every stick axis.
+var gamepadAxisNames = map[Source]string{
+	GamepadAxisSource(ebiten.StandardGamepadAxisLeftStickHorizontal, false):  "GamepadLeftStickLeft",
+	GamepadAxisSource(ebiten.StandardGamepadAxisLeftStickHorizontal, true):   "GamepadLeftStickRight",
+	GamepadAxisSource(ebiten.StandardGamepadAxisLeftStickVertical, false):    "GamepadLeftStickUp",
+	GamepadAxisSource(ebiten.StandardGamepadAxisLeftStickVertical, true):     "GamepadLeftStickDown",
+	GamepadAxisSource(ebiten.StandardGamepadAxisRightStickHorizontal, false): "GamepadRightStickLeft",
+	GamepadAxisSource(ebiten.StandardGamepadAxisRightStickHorizontal, true):  "GamepadRightStickRight",
+	GamepadAxisSource(ebiten.StandardGamepadAxisRightStickVertical, false):   "GamepadRightStickUp",
+	GamepadAxisSource(ebiten.StandardGamepadAxisRightStickVertical, true):    "GamepadRightStickDown",
+}
+
+// String returns the name used in bindings files.
+func (s Source) String() string {
+	switch s.kind {
+	case sourceKey:
+		return ebiten.Key(s.code).String()
+	case sourceMouse:
+		return mouseButtonNames[ebiten.MouseButton(s.code)]
+	case sourceWheel:
+		if s.code == wheelUp {
+			return "WheelUp"
+		}
+		return "WheelDown"
+	case sourceGamepadButton:
+		return gamepadButtonNames[ebiten.StandardGamepadButton(s.code)]
+	case sourceGamepadAxis:
+		return gamepadAxisNames[s]
+	default:
+		return ""
+	}
+}
+
+// parseSource resolves a source name. Keys use Ebiten's key names, which are matched without
+// regard to case; the other devices use the names listed above.
+func parseSource(name string) (Source, error) {
+	switch strings.ToLower(name) {
+	case "wheelup":
+		return WheelUp, nil
+	case "wheeldown":
+		return WheelDown, nil
+	}
+	for button, buttonName := range mouseButtonNames {
+		if strings.EqualFold(name, buttonName) {
+			return MouseSource(button), nil
+		}
+	}
+	for button, buttonName := range gamepadButtonNames {
+		if strings.EqualFold(name, buttonName) {
+			return GamepadButtonSource(button), nil
+		}
+	}
+	for source, axisName := range gamepadAxisNames {
+		if strings.EqualFold(name, axisName) {
+			return source, nil
+		}
+	}
+
+	var key ebiten.Key
+	if err := key.UnmarshalText([]byte(name)); err != nil {
+		return Source{}, fmt.Errorf("unknown input %q", name)
+	}
+	return KeySource(key), nil
+}
+
+// Binding is one way to trigger an action: a source, optionally held together with modifier
+// keys, written as "Control+Digit1" or "Alt+MouseLeft".
+type Binding struct {
+	Modifiers []ebiten.Key
+	Source    Source
+}
+
+// Bind returns a binding of the source with the given modifier keys.
+func Bind(source Source, modifiers ...ebiten.Key) Binding {
+	return Binding{Modifiers: modifiers, Source: source}
+}
+
+// ParseBinding reads the "Modifier+Modifier+Source" notation of the bindings file.
+func ParseBinding(text string) (Binding, error) {
+	parts := strings.Split(strings.TrimSpace(text), "+")
+	source, err := parseSource(strings.TrimSpace(parts[len(parts)-1]))
+	if err != nil {
+		return Binding{}, fmt.Errorf("binding %q: %w", text, err)
+	}
+
+	binding := Binding{Source: source}
+	for _, part := range parts[:len(parts)-1] {
+		var modifier ebiten.Key
+		if err := modifier.UnmarshalText([]byte(strings.TrimSpace(part))); err != nil {
+			return Binding{}, fmt.Errorf("binding %q: unknown modifier key %q", text, part)
+		}
+		binding.Modifiers = append(binding.Modifiers, modifier)
+	}
+	return binding, nil
+}
+
+func (b Binding) String() string {
+	var text strings.Builder
+	for _, modifier := range b.Modifiers {
+		text.WriteString(modifier.String())
+		text.WriteByte('+')
+	}
+	text.WriteString(b.Source.String())
+	return text.String()
+}
+
+func (b Binding) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b *Binding) UnmarshalText(text []byte) error {
+	parsed, err := ParseBinding(string(text))
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
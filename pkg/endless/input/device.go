@@ -0,0 +1,70 @@
+package input
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// stickThreshold is how far a stick has to lean before its direction counts as pressed. It sits
+// well above the resting drift of worn sticks.
+const stickThreshold = 0.5
+
+// Device is the raw input the map samples. Ebiten returns the real one; tests substitute a fake.
+type Device interface {
+	// Poll refreshes per-frame device state such as the list of connected gamepads.
+	Poll()
+	KeyPressed(key ebiten.Key) bool
+	MouseButtonPressed(button ebiten.MouseButton) bool
+	Wheel() (float64, float64)
+	// GamepadButtonPressed and GamepadAxis merge every connected gamepad with a standard
+	// layout, so any pad can drive the game.
+	GamepadButtonPressed(button ebiten.StandardGamepadButton) bool
+	GamepadAxis(axis ebiten.StandardGamepadAxis) float64
+}
+
+// Ebiten returns the device backed by Ebiten's input state.
+func Ebiten() Device {
+	return &ebitenDevice{}
+}
+
+type ebitenDevice struct {
+	gamepads []ebiten.GamepadID
+}
+
+func (d *ebitenDevice) Poll() {
+	d.gamepads = d.gamepads[:0]
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) {
+			d.gamepads = append(d.gamepads, id)
+		}
+	}
+}
+
+func (d *ebitenDevice) KeyPressed(key ebiten.Key) bool {
+	return ebiten.IsKeyPressed(key)
+}
+
+func (d *ebitenDevice) MouseButtonPressed(button ebiten.MouseButton) bool {
+	return ebiten.IsMouseButtonPressed(button)
+}
+
+func (d *ebitenDevice) Wheel() (float64, float64) {
+	return ebiten.Wheel()
+}
+
+func (d *ebitenDevice) GamepadButtonPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range d.gamepads {
+		if ebiten.IsStandardGamepadButtonPressed(id, button) {
+			return true
+		}
+	}
+	return false
+}
+
+// GamepadAxis returns the value that leans furthest from the centre across all pads.
+func (d *ebitenDevice) GamepadAxis(axis ebiten.StandardGamepadAxis) float64 {
+	value := 0.0
+	for _, id := range d.gamepads {
+		if current := ebiten.StandardGamepadAxisValue(id, axis); current*current > value*value {
+			value = current
+		}
+	}
+	return value
+}
@@ -0,0 +1,64 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+// bindingsFileVersion is bumped when the file layout changes incompatibly.
+const bindingsFileVersion = 1
+
+// bindingsFile is the JSON layout of a bindings file. It only needs to list the actions a player
+// wants to change; every other action keeps its default, so later default additions still reach
+// players who customised a few keys.
+type bindingsFile struct {
+	Version  int                  `json:"version"`
+	Bindings map[Action][]Binding `json:"bindings"`
+}
+
+// LoadBindings applies the overrides of the bindings file at path on top of a copy of the
+// defaults. An empty path or a missing file yields the defaults. Unknown actions are rejected
+// so a typo does not silently leave the old binding in place.
+func LoadBindings(path string, defaults Bindings) (Bindings, error) {
+	bindings := defaults.Clone()
+	if path == "" {
+		return bindings, nil
+	}
+
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return bindings, nil
+	}
+	if err != nil {
+		return bindings, fmt.Errorf("read bindings %s: %w", path, err)
+	}
+
+	var loaded bindingsFile
+	if err := json.Unmarshal(payload, &loaded); err != nil {
+		return defaults.Clone(), fmt.Errorf("decode bindings %s: %w", path, err)
+	}
+	if loaded.Version != bindingsFileVersion {
+		return bindings, fmt.Errorf("bindings %s: unsupported version %d, want %d", path, loaded.Version, bindingsFileVersion)
+	}
+	for action, actionBindings := range loaded.Bindings {
+		if _, ok := defaults[action]; !ok {
+			return defaults.Clone(), fmt.Errorf("bindings %s: unknown action %q", path, action)
+		}
+		bindings[action] = slices.Clone(actionBindings)
+	}
+
+	return bindings, nil
+}
+
+// MarshalBindings encodes a complete bindings file, which players can use as a template.
+func MarshalBindings(bindings Bindings) ([]byte, error) {
+	payload, err := json.MarshalIndent(bindingsFile{Version: bindingsFileVersion, Bindings: bindings}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode bindings: %w", err)
+	}
+	return append(payload, '\n'), nil
+}
@@ -0,0 +1,218 @@
+package input
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Action names one thing the player can do, independent of the keys or buttons that do it.
+// Names are stable snake_case strings because they are the keys of the bindings file.
+type Action string
+
+// Bindings lists the bindings of every action. An action with an empty list is unbound.
+type Bindings map[Action][]Binding
+
+// Clone returns a deep copy, so overriding a loaded set never edits the defaults it came from.
+func (b Bindings) Clone() Bindings {
+	cloned := make(Bindings, len(b))
+	for action, bindings := range b {
+		cloned[action] = slices.Clone(bindings)
+	}
+	return cloned
+}
+
+// Map turns raw device state into per-action state once per frame. Pressed reports actions
+// held right now, JustPressed the actions that started this frame and JustReleased those that
+// ended.
+//
+// When several bindings start on the same source in one frame, only the ones with the most
+// satisfied modifiers fire. Alt+Digit1 therefore toggles an overlay without also selecting
+// control group 1, while Digit1 alone still selects it.
+type Map struct {
+	bindings Bindings
+	device   Device
+
+	sources  []Source
+	pressed  map[Source]bool
+	previous map[Source]bool
+	wheel    float64
+
+	held      map[Action]bool
+	triggered map[Action]float64
+	released  map[Action]bool
+	// best holds the modifier count of the most specific binding that started on each source
+	// this frame.
+	best map[Source]int
+}
+
+// NewMap returns a map reading the device through the given bindings.
+func NewMap(bindings Bindings, device Device) *Map {
+	m := &Map{
+		bindings:  bindings,
+		device:    device,
+		pressed:   make(map[Source]bool),
+		previous:  make(map[Source]bool),
+		held:      make(map[Action]bool),
+		triggered: make(map[Action]float64),
+		released:  make(map[Action]bool),
+		best:      make(map[Source]int),
+	}
+	for _, actionBindings := range bindings {
+		for _, binding := range actionBindings {
+			if !slices.Contains(m.sources, binding.Source) {
+				m.sources = append(m.sources, binding.Source)
+			}
+		}
+	}
+	return m
+}
+
+// Update samples the device and recomputes every action. Call it once at the start of each
+// Ebiten update; edges are measured against the previous call.
+func (m *Map) Update() {
+	if m == nil {
+		return
+	}
+
+	m.device.Poll()
+	m.previous, m.pressed = m.pressed, m.previous
+	clear(m.pressed)
+	_, m.wheel = m.device.Wheel()
+	for _, source := range m.sources {
+		m.pressed[source] = m.sourcePressed(source)
+	}
+
+	clear(m.held)
+	clear(m.triggered)
+	clear(m.released)
+	clear(m.best)
+	for _, actionBindings := range m.bindings {
+		for _, binding := range actionBindings {
+			if m.justPressed(binding.Source) && m.modifiersHeld(binding) {
+				m.best[binding.Source] = max(m.best[binding.Source], len(binding.Modifiers))
+			}
+		}
+	}
+	for action, actionBindings := range m.bindings {
+		for _, binding := range actionBindings {
+			source := binding.Source
+			switch {
+			case m.pressed[source] && m.modifiersHeld(binding):
+				m.held[action] = true
+				if m.justPressed(source) && len(binding.Modifiers) == m.best[source] {
+					m.triggered[action] = max(m.triggered[action], m.strength(source))
+				}
+			case m.previous[source] && !m.pressed[source]:
+				m.released[action] = true
+			}
+		}
+	}
+}
+
+// Consume drops the action state of the current frame, for frames in which something else,
+// such as the developer console, owns the keyboard. Raw source state is still tracked, so a key
+// held through the consumed frames does not fire as a fresh press afterwards.
+func (m *Map) Consume() {
+	if m == nil {
+		return
+	}
+
+	clear(m.held)
+	clear(m.triggered)
+	clear(m.released)
+}
+
+// Pressed reports whether any binding of the action is held.
+func (m *Map) Pressed(action Action) bool {
+	return m != nil && m.held[action]
+}
+
+// JustPressed reports whether the action started this frame.
+func (m *Map) JustPressed(action Action) bool {
+	return m != nil && m.triggered[action] > 0
+}
+
+// JustReleased reports whether a source bound to the action was let go this frame.
+func (m *Map) JustReleased(action Action) bool {
+	return m != nil && m.released[action]
+}
+
+// Strength is how strongly the action started this frame: the number of wheel notches for a
+// wheel binding and one for any other source, or zero when it did not start.
+func (m *Map) Strength(action Action) float64 {
+	if m == nil {
+		return 0
+	}
+	return m.triggered[action]
+}
+
+// Label lists the bindings of the action for help text, such as "F" or "A/ArrowLeft", or
+// "unbound" when it has none.
+func (m *Map) Label(action Action) string {
+	if m == nil || len(m.bindings[action]) == 0 {
+		return "unbound"
+	}
+
+	names := make([]string, 0, len(m.bindings[action]))
+	for _, binding := range m.bindings[action] {
+		names = append(names, binding.String())
+	}
+	return strings.Join(names, "/")
+}
+
+// FirstLabel returns only the primary binding of the action, for compact help text.
+func (m *Map) FirstLabel(action Action) string {
+	if m == nil || len(m.bindings[action]) == 0 {
+		return "unbound"
+	}
+	return m.bindings[action][0].String()
+}
+
+// justPressed reports a press edge. The wheel has no held state, so every frame that scrolls
+// counts as a fresh press.
+func (m *Map) justPressed(source Source) bool {
+	if source.kind == sourceWheel {
+		return m.pressed[source]
+	}
+	return m.pressed[source] && !m.previous[source]
+}
+
+func (m *Map) modifiersHeld(binding Binding) bool {
+	for _, modifier := range binding.Modifiers {
+		if !m.device.KeyPressed(modifier) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Map) strength(source Source) float64 {
+	if source.kind == sourceWheel {
+		return math.Abs(m.wheel)
+	}
+	return 1
+}
+
+func (m *Map) sourcePressed(source Source) bool {
+	switch source.kind {
+	case sourceKey:
+		return m.device.KeyPressed(ebiten.Key(source.code))
+	case sourceMouse:
+		return m.device.MouseButtonPressed(ebiten.MouseButton(source.code))
+	case sourceWheel:
+		return m.wheel*float64(source.code) > 0
+	case sourceGamepadButton:
+		return m.device.GamepadButtonPressed(ebiten.StandardGamepadButton(source.code))
+	case sourceGamepadAxis:
+		value := m.device.GamepadAxis(ebiten.StandardGamepadAxis(source.code / 2))
+		if source.code%2 == 0 {
+			value = -value
+		}
+		return value >= stickThreshold
+	default:
+		return false
+	}
+}
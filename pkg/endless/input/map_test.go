@@ -0,0 +1,176 @@
+package input
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type fakeDevice struct {
+	keys    map[ebiten.Key]bool
+	buttons map[ebiten.MouseButton]bool
+	wheel   float64
+	pad     map[ebiten.StandardGamepadButton]bool
+	axes    map[ebiten.StandardGamepadAxis]float64
+}
+
+func newFakeDevice() *fakeDevice {
+	return &fakeDevice{
+		keys:    make(map[ebiten.Key]bool),
+		buttons: make(map[ebiten.MouseButton]bool),
+		pad:     make(map[ebiten.StandardGamepadButton]bool),
+		axes:    make(map[ebiten.StandardGamepadAxis]float64),
+	}
+}
+
+func (d *fakeDevice) Poll()                                        {}
+func (d *fakeDevice) KeyPressed(key ebiten.Key) bool               { return d.keys[key] }
+func (d *fakeDevice) MouseButtonPressed(b ebiten.MouseButton) bool { return d.buttons[b] }
+func (d *fakeDevice) Wheel() (float64, float64)                    { return 0, d.wheel }
+func (d *fakeDevice) GamepadButtonPressed(b ebiten.StandardGamepadButton) bool {
+	return d.pad[b]
+}
+func (d *fakeDevice) GamepadAxis(axis ebiten.StandardGamepadAxis) float64 { return d.axes[axis] }
+
+func TestMapPrefersTheBindingWithMostModifiers(t *testing.T) {
+	device := newFakeDevice()
+	m := NewMap(Bindings{
+		"group_1":        {Bind(KeySource(ebiten.KeyDigit1))},
+		"assign_group_1": {Bind(KeySource(ebiten.KeyDigit1), ebiten.KeyControl)},
+		"pan_fast":       {Bind(KeySource(ebiten.KeyControl))},
+	}, device)
+
+	device.keys[ebiten.KeyDigit1] = true
+	m.Update()
+	if !m.JustPressed("group_1") || m.JustPressed("assign_group_1") {
+		t.Fatal("plain digit did not select the group alone")
+	}
+
+	device.keys[ebiten.KeyDigit1] = false
+	m.Update()
+	if !m.JustReleased("group_1") || m.Pressed("group_1") {
+		t.Fatal("releasing the digit was not reported")
+	}
+
+	device.keys[ebiten.KeyControl] = true
+	device.keys[ebiten.KeyDigit1] = true
+	m.Update()
+	if m.JustPressed("group_1") || !m.JustPressed("assign_group_1") {
+		t.Fatal("Control+Digit1 did not fire only the assign action")
+	}
+	if !m.Pressed("pan_fast") || !m.Pressed("group_1") {
+		t.Fatal("held state lost while the chord fired")
+	}
+
+	m.Update()
+	if m.JustPressed("assign_group_1") {
+		t.Fatal("held chord fired again on the next frame")
+	}
+}
+
+func TestMapReportsWheelNotchesAndStickDirections(t *testing.T) {
+	device := newFakeDevice()
+	m := NewMap(Bindings{
+		"zoom_in":  {Bind(WheelUp)},
+		"zoom_out": {Bind(WheelDown)},
+		"pan_left": {Bind(GamepadAxisSource(ebiten.StandardGamepadAxisLeftStickHorizontal, false))},
+		"fire":     {Bind(GamepadButtonSource(ebiten.StandardGamepadButtonFrontBottomRight))},
+	}, device)
+
+	device.wheel = 2
+	device.axes[ebiten.StandardGamepadAxisLeftStickHorizontal] = -0.8
+	device.pad[ebiten.StandardGamepadButtonFrontBottomRight] = true
+	m.Update()
+	if got := m.Strength("zoom_in"); got != 2 {
+		t.Fatalf("Strength(zoom_in) = %v, want 2", got)
+	}
+	if m.JustPressed("zoom_out") || !m.Pressed("pan_left") || !m.JustPressed("fire") {
+		t.Fatal("wheel, stick or gamepad button state is wrong")
+	}
+
+	m.Update()
+	if !m.JustPressed("zoom_in") {
+		t.Fatal("continued scrolling did not count as another press")
+	}
+
+	device.wheel = 0
+	device.axes[ebiten.StandardGamepadAxisLeftStickHorizontal] = -0.2
+	m.Update()
+	if m.JustPressed("zoom_in") || m.Pressed("pan_left") || !m.JustReleased("pan_left") {
+		t.Fatal("stick inside the threshold still pans")
+	}
+}
+
+func TestMapConsumeHidesKeysHeldWhileConsumed(t *testing.T) {
+	device := newFakeDevice()
+	m := NewMap(Bindings{"pan_left": {Bind(KeySource(ebiten.KeyA))}}, device)
+
+	device.keys[ebiten.KeyA] = true
+	m.Update()
+	m.Consume()
+	if m.Pressed("pan_left") || m.JustPressed("pan_left") {
+		t.Fatal("consumed frame still reports the action")
+	}
+
+	m.Update()
+	if !m.Pressed("pan_left") || m.JustPressed("pan_left") {
+		t.Fatal("key held through a consumed frame should be held but not freshly pressed")
+	}
+}
+
+func TestParseBindingRoundTripsEveryDevice(t *testing.T) {
+	for _, text := range []string{"A", "Control+Digit1", "Alt+MouseLeft", "MouseBack", "WheelDown", "GamepadRightBottom", "GamepadRightStickUp"} {
+		binding, err := ParseBinding(text)
+		if err != nil {
+			t.Fatalf("ParseBinding(%q) error = %v", text, err)
+		}
+		if got := binding.String(); got != text {
+			t.Fatalf("ParseBinding(%q).String() = %q", text, got)
+		}
+	}
+
+	if binding, err := ParseBinding("shift+mouseright"); err != nil || binding.String() != "Shift+MouseRight" {
+		t.Fatalf("ParseBinding(shift+mouseright) = %v, %v", binding, err)
+	}
+	if _, err := ParseBinding("Hyper+A"); err == nil {
+		t.Fatal("ParseBinding() accepted an unknown modifier")
+	}
+	if _, err := ParseBinding("MouseSixth"); err == nil {
+		t.Fatal("ParseBinding() accepted an unknown source")
+	}
+}
+
+func TestLoadBindingsOverridesListedActionsOnly(t *testing.T) {
+	defaults := Bindings{
+		"fire":     {Bind(KeySource(ebiten.KeyF))},
+		"drag_pan": {Bind(MouseSource(ebiten.MouseButtonMiddle))},
+	}
+	path := filepath.Join(t.TempDir(), "bindings.json")
+	if err := os.WriteFile(path, []byte(`{"version": 1, "bindings": {"drag_pan": ["Alt+MouseLeft", "MouseBack"]}}`), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	bindings, err := LoadBindings(path, defaults)
+	if err != nil {
+		t.Fatalf("LoadBindings() error = %v", err)
+	}
+	m := NewMap(bindings, newFakeDevice())
+	if got := m.Label("drag_pan"); got != "Alt+MouseLeft/MouseBack" {
+		t.Fatalf("drag_pan = %q, want the file bindings", got)
+	}
+	if got := m.Label("fire"); got != "F" {
+		t.Fatalf("fire = %q, want the default", got)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 1, "bindings": {"fly": ["Space"]}}`), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := LoadBindings(path, defaults); err == nil {
+		t.Fatal("LoadBindings() accepted an unknown action")
+	}
+	if bindings, err := LoadBindings(filepath.Join(t.TempDir(), "missing.json"), defaults); err != nil || len(bindings) != 2 {
+		t.Fatalf("LoadBindings(missing) = %v, %v, want the defaults", bindings, err)
+	}
+}
@@ -0,0 +1,176 @@
+package endless
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/unng-lab/endless/pkg/endless/input"
+)
+
+// Actions of the desktop client. Cursor-driven actions such as select and move still aim with
+// the mouse pointer; only the trigger is rebindable.
+const (
+	actionPanLeft  input.Action = "pan_left"
+	actionPanRight input.Action = "pan_right"
+	actionPanUp    input.Action = "pan_up"
+	actionPanDown  input.Action = "pan_down"
+	actionPanFast  input.Action = "pan_fast"
+	actionDragPan  input.Action = "drag_pan"
+	actionZoomIn   input.Action = "zoom_in"
+	actionZoomOut  input.Action = "zoom_out"
+	actionCenter   input.Action = "center"
+	actionFollow   input.Action = "follow"
+
+	actionSelect     input.Action = "select"
+	actionSelectAdd  input.Action = "select_add"
+	actionSelectKind input.Action = "select_kind"
+	actionMove       input.Action = "move"
+	actionFire       input.Action = "fire"
+	actionStop       input.Action = "stop"
+	actionHold       input.Action = "hold"
+	actionCancel     input.Action = "cancel"
+
+	actionPause    input.Action = "pause"
+	actionStep     input.Action = "step"
+	actionSpeedUp  input.Action = "speed_up"
+	actionSlowDown input.Action = "slow_down"
+
+	actionReplayBack    input.Action = "replay_back"
+	actionReplayForward input.Action = "replay_forward"
+	actionReplayRestart input.Action = "replay_restart"
+
+	actionConsole input.Action = "console"
+)
+
+func groupAction(group int) input.Action {
+	return input.Action(fmt.Sprintf("group_%d", group))
+}
+
+func assignGroupAction(group int) input.Action {
+	return input.Action(fmt.Sprintf("assign_group_%d", group))
+}
+
+func bookmarkAction(slot int) input.Action {
+	return input.Action(fmt.Sprintf("bookmark_%d", slot))
+}
+
+func saveBookmarkAction(slot int) input.Action {
+	return input.Action(fmt.Sprintf("save_bookmark_%d", slot))
+}
+
+func overlayAction(name string) input.Action {
+	return input.Action("overlay_" + name)
+}
+
+// DefaultBindingsPath returns the bindings file next to the settings file, or an empty path,
+// which keeps the defaults, when the platform has no configuration directory.
+func DefaultBindingsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "endless", "bindings.json")
+}
+
+// DefaultInputBindings returns the stock layout. Every action has at least one keyboard or
+// mouse binding; the common camera and clock actions also get a standard gamepad binding.
+func DefaultInputBindings() input.Bindings {
+	key := func(key ebiten.Key, modifiers ...ebiten.Key) input.Binding {
+		return input.Bind(input.KeySource(key), modifiers...)
+	}
+	mouse := func(button ebiten.MouseButton) input.Binding {
+		return input.Bind(input.MouseSource(button))
+	}
+	pad := func(button ebiten.StandardGamepadButton) input.Binding {
+		return input.Bind(input.GamepadButtonSource(button))
+	}
+	stick := func(axis ebiten.StandardGamepadAxis, positive bool) input.Binding {
+		return input.Bind(input.GamepadAxisSource(axis, positive))
+	}
+
+	bindings := input.Bindings{
+		actionPanLeft:  {key(ebiten.KeyA), key(ebiten.KeyArrowLeft), stick(ebiten.StandardGamepadAxisLeftStickHorizontal, false)},
+		actionPanRight: {key(ebiten.KeyD), key(ebiten.KeyArrowRight), stick(ebiten.StandardGamepadAxisLeftStickHorizontal, true)},
+		actionPanUp:    {key(ebiten.KeyW), key(ebiten.KeyArrowUp), stick(ebiten.StandardGamepadAxisLeftStickVertical, false)},
+		actionPanDown:  {key(ebiten.KeyS), key(ebiten.KeyArrowDown), stick(ebiten.StandardGamepadAxisLeftStickVertical, true)},
+		actionPanFast:  {key(ebiten.KeyShift), pad(ebiten.StandardGamepadButtonLeftStick)},
+		actionDragPan:  {mouse(ebiten.MouseButtonMiddle)},
+		actionZoomIn:   {input.Bind(input.WheelUp), pad(ebiten.StandardGamepadButtonFrontTopRight)},
+		actionZoomOut:  {input.Bind(input.WheelDown), pad(ebiten.StandardGamepadButtonFrontTopLeft)},
+		actionCenter:   {key(ebiten.KeySpace), pad(ebiten.StandardGamepadButtonRightStick)},
+		actionFollow:   {key(ebiten.KeyC), pad(ebiten.StandardGamepadButtonRightTop)},
+
+		actionSelect:     {mouse(ebiten.MouseButtonLeft)},
+		actionSelectAdd:  {key(ebiten.KeyShift)},
+		actionSelectKind: {key(ebiten.KeyControl)},
+		actionMove:       {mouse(ebiten.MouseButtonRight)},
+		actionFire:       {key(ebiten.KeyF), pad(ebiten.StandardGamepadButtonFrontBottomRight)},
+		actionStop:       {key(ebiten.KeyX)},
+		actionHold:       {key(ebiten.KeyH)},
+		actionCancel:     {key(ebiten.KeyEscape)},
+
+		actionPause:    {key(ebiten.KeyP), pad(ebiten.StandardGamepadButtonCenterRight)},
+		actionStep:     {key(ebiten.KeyPeriod), pad(ebiten.StandardGamepadButtonCenterLeft)},
+		actionSpeedUp:  {key(ebiten.KeyEqual), key(ebiten.KeyNumpadAdd), pad(ebiten.StandardGamepadButtonLeftRight)},
+		actionSlowDown: {key(ebiten.KeyMinus), key(ebiten.KeyNumpadSubtract), pad(ebiten.StandardGamepadButtonLeftLeft)},
+
+		actionReplayBack:    {key(ebiten.KeyBracketLeft)},
+		actionReplayForward: {key(ebiten.KeyBracketRight)},
+		actionReplayRestart: {key(ebiten.KeyHome)},
+
+		actionConsole: {key(ebiten.KeyBackquote)},
+	}
+	for group, digit := range controlGroupKeys {
+		bindings[groupAction(group)] = []input.Binding{key(digit)}
+		bindings[assignGroupAction(group)] = []input.Binding{key(digit, ebiten.KeyControl)}
+	}
+	for index, function := range cameraBookmarkKeys {
+		bindings[bookmarkAction(index+1)] = []input.Binding{key(function)}
+		bindings[saveBookmarkAction(index+1)] = []input.Binding{key(function, ebiten.KeyControl)}
+	}
+	for _, toggle := range debugOverlayToggles {
+		bindings[overlayAction(toggle.name)] = []input.Binding{key(toggle.key, ebiten.KeyAlt)}
+	}
+	return bindings
+}
+
+// loadInputBindings reads the player's bindings file, falling back to the defaults with a log
+// line when it cannot be used.
+func loadInputBindings(path string) input.Bindings {
+	bindings, err := input.LoadBindings(path, DefaultInputBindings())
+	if err != nil {
+		log.Printf("[input] %v; using default bindings", err)
+	}
+	return bindings
+}
+
+// controlsHelpText lists the controls under their current bindings. Only the first binding of
+// each action is shown to keep the lines short.
+func (g *Game) controlsHelpText() string {
+	label := g.input.FirstLabel
+	return fmt.Sprintf(
+		"%s/%s/%s/%s: move  %s: faster  %s: center  %s: drag  %s/%s: zoom to cursor  %s: select/drag box  %s+select: add/remove  %s+select: select kind  %s: move selection  %s: fire to cursor  %s: stop  %s: hold\n"+
+			"%s: select group (%s: assign, twice: jump)  %s: camera bookmark (%s: save)  Minimap: select or drag to move camera  %s: follow (%s)\n"+
+			"%s: pause  %s: step  %s/%s: speed  %s: console",
+		label(actionPanUp), label(actionPanLeft), label(actionPanDown), label(actionPanRight),
+		label(actionPanFast), label(actionCenter), label(actionDragPan), label(actionZoomIn), label(actionZoomOut),
+		label(actionSelect), label(actionSelectAdd), label(actionSelectKind), label(actionMove), label(actionFire), label(actionStop), label(actionHold),
+		g.actionRangeLabel(groupAction(0), groupAction(len(controlGroupKeys)-1)),
+		g.actionRangeLabel(assignGroupAction(0), assignGroupAction(len(controlGroupKeys)-1)),
+		g.actionRangeLabel(bookmarkAction(1), bookmarkAction(len(cameraBookmarkKeys))),
+		g.actionRangeLabel(saveBookmarkAction(1), saveBookmarkAction(len(cameraBookmarkKeys))),
+		label(actionFollow), g.cameraFollow,
+		label(actionPause), label(actionStep), label(actionSlowDown), label(actionSpeedUp), label(actionConsole),
+	)
+}
+
+// actionRangeLabel describes a numbered action family for the help text, such as
+// "Digit0..Digit9", by the first binding of its first and last member.
+func (g *Game) actionRangeLabel(first, last input.Action) string {
+	return g.input.FirstLabel(first) + ".." + g.input.FirstLabel(last)
+}
@@ -0,0 +1,40 @@
+package endless
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/endless/input"
+)
+
+// TestDefaultInputBindingsSurviveTheBindingsFile writes the printed template and loads it back,
+// which catches default bindings whose names the file format cannot express.
+func TestDefaultInputBindingsSurviveTheBindingsFile(t *testing.T) {
+	defaults := DefaultInputBindings()
+	payload, err := input.MarshalBindings(defaults)
+	if err != nil {
+		t.Fatalf("MarshalBindings() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "bindings.json")
+	if err := os.WriteFile(path, payload, 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	loaded, err := input.LoadBindings(path, defaults)
+	if err != nil {
+		t.Fatalf("LoadBindings() error = %v", err)
+	}
+	want := input.NewMap(defaults, input.Ebiten())
+	got := input.NewMap(loaded, input.Ebiten())
+	for action := range defaults {
+		if got.Label(action) != want.Label(action) {
+			t.Fatalf("%s = %q after the round trip, want %q", action, got.Label(action), want.Label(action))
+		}
+	}
+	for _, toggle := range debugOverlayToggles {
+		if _, ok := defaults[overlayAction(toggle.name)]; !ok {
+			t.Fatalf("overlay %s has no default binding", toggle.name)
+		}
+	}
+}
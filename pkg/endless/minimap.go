@@ -0,0 +1,273 @@
+package endless
+
+import (
+	"hash/fnv"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+const (
+	// minimapMaxSize bounds the longer side of the minimap in screen pixels.
+	minimapMaxSize = 200
+	minimapMargin  = 16.0
+	minimapBorder  = 2.0
+	// minimapMarkerRefreshFrames is how many frames the unit layer is reused before it is
+	// sampled again. Markers move at most a pixel or two in that time on a large map, and the
+	// column scan stays off most frames of the stress scene.
+	minimapMarkerRefreshFrames = 10
+)
+
+var (
+	minimapFrameColor = color.NRGBA{R: 19, G: 23, B: 30, A: 230}
+	minimapViewColor  = color.NRGBA{R: 255, G: 255, B: 255, A: 230}
+)
+
+// minimapTeamColors tint the markers of units that play for a team. Teams are free-form names,
+// so each one is hashed onto this palette.
+var minimapTeamColors = [...]color.NRGBA{
+	{R: 64, G: 140, B: 255, A: 255},
+	{R: 80, G: 220, B: 120, A: 255},
+	{R: 220, G: 90, B: 230, A: 255},
+	{R: 70, G: 220, B: 230, A: 255},
+	{R: 255, G: 150, B: 40, A: 255},
+	{R: 240, G: 240, B: 240, A: 255},
+}
+
+// minimapMarkerColor picks the marker colour of a unit kind and team. Mobile kinds stand out
+// against the terrain, obstacles are muted so a dense wall field reads as structure rather than
+// noise. A team blends its colour over the kind colour, so two sides fielding the same kind
+// still tell apart while runners and walls of one side keep their contrast.
+func minimapMarkerColor(kind unit.Kind, team unit.Team) color.NRGBA {
+	var base color.NRGBA
+	switch kind {
+	case unit.KindRunner:
+		base = color.NRGBA{R: 255, G: 96, B: 72, A: 255}
+	case unit.KindRunnerFocused:
+		base = color.NRGBA{R: 255, G: 214, B: 102, A: 255}
+	case unit.KindBarricade:
+		base = color.NRGBA{R: 150, G: 112, B: 74, A: 255}
+	default:
+		base = color.NRGBA{R: 66, G: 66, B: 70, A: 255}
+	}
+	if team == "" {
+		return base
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(team))
+	tint := minimapTeamColors[hash.Sum32()%uint32(len(minimapTeamColors))]
+	blend := func(a, b uint8) uint8 { return uint8((uint16(a) + 2*uint16(b)) / 3) }
+	return color.NRGBA{R: blend(base.R, tint.R), G: blend(base.G, tint.G), B: blend(base.B, tint.B), A: base.A}
+}
+
+// minimap renders the whole world at a coarse scale in the bottom-right corner. The terrain
+// layer is sampled once per world and size and then reused; terrain only changes when the world
+// itself does, for example when replay playback starts on another world. Units are painted into
+// a separate pixel layer that is refreshed every few frames, so the per-frame cost is three
+// image draws regardless of the map and population size.
+type minimap struct {
+	terrainWorld  world.World
+	terrain       *ebiten.Image
+	markers       *ebiten.Image
+	markerPixels  []byte
+	markerBuffer  []unit.UnitMarker
+	markerFrames  int
+	width, height int
+
+	dragging bool
+}
+
+// minimapLayout returns the on-screen rectangle of the map image for a world and screen. The
+// world aspect ratio is kept and the map never takes more than a quarter of the shorter screen
+// side.
+func minimapLayout(gameWorld world.World, screenWidth, screenHeight int) (geom.Rect, bool) {
+	longest := math.Min(minimapMaxSize, float64(min(screenWidth, screenHeight))/4)
+	if longest < 16 || gameWorld.Width() <= 0 || gameWorld.Height() <= 0 {
+		return geom.Rect{}, false
+	}
+
+	width, height := longest, longest
+	if gameWorld.Width() > gameWorld.Height() {
+		height = math.Max(math.Round(longest*gameWorld.Height()/gameWorld.Width()), 1)
+	} else {
+		width = math.Max(math.Round(longest*gameWorld.Width()/gameWorld.Height()), 1)
+	}
+
+	right := float64(screenWidth) - minimapMargin
+	bottom := float64(screenHeight) - minimapMargin
+	return geom.Rect{
+		Min: geom.Point{X: right - width, Y: bottom - height},
+		Max: geom.Point{X: right, Y: bottom},
+	}, true
+}
+
+// minimapWorldPoint maps a screen point inside the map rectangle to the world point it shows.
+// Points outside the rectangle are clamped onto its edge so a drag that leaves the map keeps
+// the camera at the world border.
+func minimapWorldPoint(gameWorld world.World, rect geom.Rect, cursor geom.Point) geom.Point {
+	u := geom.ClampFloat((cursor.X-rect.Min.X)/(rect.Max.X-rect.Min.X), 0, 1)
+	v := geom.ClampFloat((cursor.Y-rect.Min.Y)/(rect.Max.Y-rect.Min.Y), 0, 1)
+	return geom.Point{X: u * gameWorld.Width(), Y: v * gameWorld.Height()}
+}
+
+// terrainPixels samples one tile colour per map pixel at the centre of the tiles that pixel
+// covers.
+func terrainPixels(gameWorld world.World, width, height int) []byte {
+	pixels := make([]byte, width*height*4)
+	for y := range height {
+		tileY := min(int((float64(y)+0.5)*float64(gameWorld.Rows())/float64(height)), gameWorld.Rows()-1)
+		for x := range width {
+			tileX := min(int((float64(x)+0.5)*float64(gameWorld.Columns())/float64(width)), gameWorld.Columns()-1)
+			tileColor := gameWorld.TileColor(tileX, tileY)
+			offset := (y*width + x) * 4
+			pixels[offset] = tileColor.R
+			pixels[offset+1] = tileColor.G
+			pixels[offset+2] = tileColor.B
+			pixels[offset+3] = 255
+		}
+	}
+	return pixels
+}
+
+// paintMarkers clears the RGBA buffer and paints one pixel per static marker and a 2x2 dot per
+// mobile marker. Projectiles are left out; they live for a few ticks and would only flicker.
+func paintMarkers(pixels []byte, width, height int, gameWorld world.World, markers []unit.UnitMarker) {
+	clear(pixels)
+	scaleX := float64(width) / gameWorld.Width()
+	scaleY := float64(height) / gameWorld.Height()
+	for _, marker := range markers {
+		if marker.Kind == unit.KindProjectile {
+			continue
+		}
+
+		x := int(marker.Position.X * scaleX)
+		y := int(marker.Position.Y * scaleY)
+		size := 1
+		if marker.Kind == unit.KindRunner || marker.Kind == unit.KindRunnerFocused {
+			size = 2
+		}
+		markerColor := minimapMarkerColor(marker.Kind, marker.Team)
+		for dy := range size {
+			for dx := range size {
+				px, py := x+dx, y+dy
+				if px < 0 || py < 0 || px >= width || py >= height {
+					continue
+				}
+				offset := (py*width + px) * 4
+				pixels[offset] = markerColor.R
+				pixels[offset+1] = markerColor.G
+				pixels[offset+2] = markerColor.B
+				pixels[offset+3] = markerColor.A
+			}
+		}
+	}
+}
+
+// prepare makes sure both layers exist for the current world and map size, re-sampling the
+// terrain only when either changed and the unit layer once every few frames.
+func (m *minimap) prepare(gameWorld world.World, units *unit.Manager, width, height int) {
+	if m.terrain == nil || m.terrainWorld != gameWorld || m.width != width || m.height != height {
+		if m.terrain != nil {
+			m.terrain.Deallocate()
+			m.markers.Deallocate()
+		}
+		m.terrainWorld = gameWorld
+		m.width, m.height = width, height
+		m.terrain = ebiten.NewImage(width, height)
+		m.terrain.WritePixels(terrainPixels(gameWorld, width, height))
+		m.markers = ebiten.NewImage(width, height)
+		m.markerPixels = make([]byte, width*height*4)
+		m.markerFrames = 0
+	}
+
+	if m.markerFrames%minimapMarkerRefreshFrames == 0 {
+		m.markerBuffer = units.AppendUnitMarkers(m.markerBuffer[:0])
+		paintMarkers(m.markerPixels, width, height, gameWorld, m.markerBuffer)
+		m.markers.WritePixels(m.markerPixels)
+	}
+	m.markerFrames++
+}
+
+// drawMinimap draws the map with its unit markers and the outline of the area the main camera
+// currently shows.
+func (g *Game) drawMinimap(screen *ebiten.Image) {
+	rect, ok := minimapLayout(g.world, g.screenWidth, g.screenHeight)
+	if !ok {
+		return
+	}
+
+	width := int(rect.Max.X - rect.Min.X)
+	height := int(rect.Max.Y - rect.Min.Y)
+	g.minimap.prepare(g.world, g.units, width, height)
+
+	g.drawFilledRect(
+		screen,
+		rect.Min.X-minimapBorder,
+		rect.Min.Y-minimapBorder,
+		float64(width)+minimapBorder*2,
+		float64(height)+minimapBorder*2,
+		minimapFrameColor,
+	)
+	var op ebiten.DrawImageOptions
+	op.GeoM.Translate(rect.Min.X, rect.Min.Y)
+	screen.DrawImage(g.minimap.terrain, &op)
+	screen.DrawImage(g.minimap.markers, &op)
+
+	view := g.cam.ViewRect(float64(g.screenWidth), float64(g.screenHeight))
+	scaleX := float64(width) / g.world.Width()
+	scaleY := float64(height) / g.world.Height()
+	left := rect.Min.X + geom.ClampFloat(view.Min.X*scaleX, 0, float64(width))
+	top := rect.Min.Y + geom.ClampFloat(view.Min.Y*scaleY, 0, float64(height))
+	right := rect.Min.X + geom.ClampFloat(view.Max.X*scaleX, 0, float64(width))
+	bottom := rect.Min.Y + geom.ClampFloat(view.Max.Y*scaleY, 0, float64(height))
+	viewWidth := math.Max(right-left, 1)
+	viewHeight := math.Max(bottom-top, 1)
+
+	g.drawFilledRect(screen, left, top, viewWidth, 1, minimapViewColor)
+	g.drawFilledRect(screen, left, top+viewHeight-1, viewWidth, 1, minimapViewColor)
+	g.drawFilledRect(screen, left, top, 1, viewHeight, minimapViewColor)
+	g.drawFilledRect(screen, left+viewWidth-1, top, 1, viewHeight, minimapViewColor)
+}
+
+// handleMinimapInput centers the camera on the clicked map point and keeps following the cursor
+// while the left button stays down. It reports whether the minimap owns the mouse this frame,
+// so selection and commands do not also react to the same click.
+func (g *Game) handleMinimapInput() bool {
+	x, y := ebiten.CursorPosition()
+	cursor := geom.Point{X: float64(x), Y: float64(y)}
+	rect, ok := minimapLayout(g.world, g.screenWidth, g.screenHeight)
+	if !ok {
+		g.minimap.dragging = false
+		return false
+	}
+
+	if g.input.JustPressed(actionSelect) && rectContains(rect, cursor) {
+		g.minimap.dragging = true
+	}
+	if !g.minimap.dragging {
+		return false
+	}
+	if !g.input.Pressed(actionSelect) {
+		g.minimap.dragging = false
+		return true
+	}
+
+	g.centerCameraOn(minimapWorldPoint(g.world, rect, cursor))
+	return true
+}
+
+// pointInMinimap reports whether a screen point lies on the minimap.
+func (g *Game) pointInMinimap(cursor geom.Point) bool {
+	rect, ok := minimapLayout(g.world, g.screenWidth, g.screenHeight)
+	return ok && rectContains(rect, cursor)
+}
+
+func rectContains(rect geom.Rect, point geom.Point) bool {
+	return point.X >= rect.Min.X && point.X < rect.Max.X && point.Y >= rect.Min.Y && point.Y < rect.Max.Y
+}
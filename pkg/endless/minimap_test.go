@@ -0,0 +1,89 @@
+package endless
+
+import (
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// TestMinimapLayoutKeepsWorldAspectInBottomRightCorner verifies the map rectangle for a square
+// and a wide world, and that the clicked map point maps back onto the world proportionally.
+func TestMinimapLayoutKeepsWorldAspectInBottomRightCorner(t *testing.T) {
+	square := world.New(world.Config{Columns: 256, Rows: 256, TileSize: 16})
+	rect, ok := minimapLayout(square, 1280, 960)
+	if !ok {
+		t.Fatal("minimapLayout() = false, want a map on a desktop-sized screen")
+	}
+	want := geom.Rect{Min: geom.Point{X: 1064, Y: 744}, Max: geom.Point{X: 1264, Y: 944}}
+	if rect != want {
+		t.Fatalf("minimapLayout(square) = %+v, want %+v", rect, want)
+	}
+	if got := minimapWorldPoint(square, rect, geom.Point{X: 1114, Y: 944}); got != (geom.Point{X: 1024, Y: 4096}) {
+		t.Fatalf("minimapWorldPoint() = %+v, want a quarter across and the bottom edge", got)
+	}
+	if got := minimapWorldPoint(square, rect, geom.Point{X: 0, Y: 0}); got != (geom.Point{}) {
+		t.Fatalf("minimapWorldPoint(outside) = %+v, want the point clamped to the world origin", got)
+	}
+
+	wide := world.New(world.Config{Columns: 400, Rows: 100, TileSize: 16})
+	rect, _ = minimapLayout(wide, 1280, 960)
+	if width, height := rect.Max.X-rect.Min.X, rect.Max.Y-rect.Min.Y; width != 200 || height != 50 {
+		t.Fatalf("minimapLayout(wide) size = %.0fx%.0f, want 200x50", width, height)
+	}
+	if _, ok := minimapLayout(square, 40, 40); ok {
+		t.Fatal("minimapLayout() on a tiny screen = true, want the map hidden")
+	}
+}
+
+// TestPaintMarkersScalesPositionsAndSkipsProjectiles verifies marker placement, the larger dot
+// for runners and that projectiles stay off the map.
+func TestPaintMarkersScalesPositionsAndSkipsProjectiles(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 64, Rows: 64, TileSize: 16})
+	pixels := make([]byte, 16*16*4)
+	pixels[0] = 99
+	paintMarkers(pixels, 16, 16, gameWorld, []unit.UnitMarker{
+		{Kind: unit.KindWall, Position: geom.Point{X: 520, Y: 8}},
+		{Kind: unit.KindRunner, Position: geom.Point{X: 520, Y: 520}},
+		{Kind: unit.KindProjectile, Position: geom.Point{X: 8, Y: 520}},
+	})
+
+	alpha := func(x, y int) byte { return pixels[(y*16+x)*4+3] }
+	if pixels[0] != 0 {
+		t.Fatal("paintMarkers() kept stale pixels, want the buffer cleared first")
+	}
+	if alpha(8, 0) == 0 || alpha(9, 0) != 0 {
+		t.Fatal("wall marker missing or wider than one pixel at (8, 0)")
+	}
+	if alpha(8, 8) == 0 || alpha(9, 9) == 0 || alpha(10, 10) != 0 {
+		t.Fatal("runner marker is not a 2x2 dot at (8, 8)")
+	}
+	if alpha(0, 8) != 0 {
+		t.Fatal("projectile painted, want projectiles skipped")
+	}
+}
+
+// TestPaintMarkersTintsTheSameKindByTeam paints one runner per side and a runner without a team
+// and checks that all three come out in different colours.
+func TestPaintMarkersTintsTheSameKindByTeam(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 64, Rows: 64, TileSize: 16})
+	pixels := make([]byte, 16*16*4)
+	paintMarkers(pixels, 16, 16, gameWorld, []unit.UnitMarker{
+		{Kind: unit.KindRunner, Team: "red", Position: geom.Point{X: 8, Y: 8}},
+		{Kind: unit.KindRunner, Team: "blue", Position: geom.Point{X: 520, Y: 8}},
+		{Kind: unit.KindRunner, Position: geom.Point{X: 8, Y: 520}},
+	})
+
+	pixel := func(x, y int) [4]byte {
+		offset := (y*16 + x) * 4
+		return [4]byte(pixels[offset : offset+4])
+	}
+	red, blue, neutral := pixel(0, 0), pixel(8, 0), pixel(0, 8)
+	if red == blue || red == neutral || blue == neutral {
+		t.Fatalf("runner markers red %v, blue %v, no team %v, want three different colours", red, blue, neutral)
+	}
+	if red[3] == 0 || blue[3] == 0 {
+		t.Fatal("team runner marker missing")
+	}
+}
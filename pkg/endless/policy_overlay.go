@@ -0,0 +1,175 @@
+package endless
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	gamescenario "github.com/unng-lab/endless/pkg/endless/scenario"
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/rl"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// policyFireRayTiles is how far a fire candidate's ray reaches from the shooter. It only has to
+// be long enough to tell the directions apart, not to show the real projectile range.
+const policyFireRayTiles = 4.0
+
+var (
+	policyUnscoredColor     = color.NRGBA{R: 150, G: 150, B: 150, A: 200}
+	policyChosenColor       = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	policyFriendlyShotColor = color.NRGBA{R: 96, G: 220, B: 120, A: 230}
+	policyHostileShotColor  = color.NRGBA{R: 255, G: 80, B: 64, A: 230}
+)
+
+// policyOccupancyColor tints one observation patch cell by the occupancy code the policy saw.
+// Empty cells stay clear so the terrain underneath remains visible.
+func policyOccupancyColor(code int16) (color.NRGBA, bool) {
+	switch code {
+	case rl.OccupancyUnknown:
+		return color.NRGBA{R: 20, G: 20, B: 20, A: 170}, true
+	case rl.OccupancyShooter:
+		return color.NRGBA{R: 255, G: 214, B: 102, A: 110}, true
+	case rl.OccupancyTarget:
+		return color.NRGBA{R: 255, G: 96, B: 72, A: 110}, true
+	case rl.OccupancyFriendlyShot:
+		return color.NRGBA{R: 96, G: 220, B: 120, A: 110}, true
+	case rl.OccupancyHostileShot:
+		return color.NRGBA{R: 255, G: 150, B: 40, A: 130}, true
+	case rl.OccupancyMovementBlocker:
+		return color.NRGBA{R: 150, G: 112, B: 74, A: 150}, true
+	default:
+		return color.NRGBA{}, false
+	}
+}
+
+// policyValueColor ramps from red for the worst scored candidate to green for the best. A
+// single scored candidate, or a tie, is drawn green.
+func policyValueColor(value, lowest, highest float32) color.NRGBA {
+	t := float32(1)
+	if highest > lowest {
+		t = (value - lowest) / (highest - lowest)
+	}
+	return color.NRGBA{R: uint8(255 * (1 - t)), G: uint8(200*t + 40), B: 60, A: 230}
+}
+
+// drawPolicyOverlay explains the last decision of a policy-driven scenario: the local patch
+// the observation encoded, the nearest shots it reported and every candidate the model scored,
+// coloured by predicted value with the chosen one outlined.
+func (g *Game) drawPolicyOverlay(screen *ebiten.Image) {
+	policyScenario, ok := g.scenario.(gamescenario.PolicyScenario)
+	if !ok {
+		return
+	}
+	inspection, ok := policyScenario.PolicyInspection()
+	if !ok {
+		return
+	}
+
+	observation := inspection.Observation
+	shooter := observation.Snapshot.Shooter.Position
+	g.drawObservationPatch(screen, observation)
+	g.drawShotFeature(screen, shooter, observation.NearestFriendlyShot, policyFriendlyShotColor)
+	g.drawShotFeature(screen, shooter, observation.NearestHostileShot, policyHostileShotColor)
+
+	if len(inspection.Candidates) == 0 {
+		// Scripted policies score nothing; show what they picked so the overlay still explains
+		// the move.
+		g.drawPolicyCandidate(screen, shooter, observation.TileSize, inspection.Chosen, policyUnscoredColor, "scripted", true)
+		return
+	}
+	lowest, highest := float32(math.Inf(1)), float32(math.Inf(-1))
+	for _, candidate := range inspection.Candidates {
+		if candidate.Scored {
+			lowest = min(lowest, candidate.Value)
+			highest = max(highest, candidate.Value)
+		}
+	}
+	for _, candidate := range inspection.Candidates {
+		stroke := policyUnscoredColor
+		label := "n/a"
+		if candidate.Scored {
+			stroke = policyValueColor(candidate.Value, lowest, highest)
+			label = fmt.Sprintf("%.3f", candidate.Value)
+		}
+		g.drawPolicyCandidate(screen, shooter, observation.TileSize, candidate.Action, stroke, label, inspection.IsChosen(candidate.Action))
+	}
+}
+
+// drawObservationPatch tints the cells of the local occupancy patch around the shooter and, once
+// tiles are big enough, prints the first letter of the terrain the patch recorded.
+func (g *Game) drawObservationPatch(screen *ebiten.Image, observation rl.Observation) {
+	radius := observation.PatchRadius
+	width := radius*2 + 1
+	if radius <= 0 || len(observation.LocalOccupancyPatch) != width*width {
+		return
+	}
+
+	scale := g.cam.Scale()
+	camPos := g.cam.Position()
+	tileScreenSize := g.world.TileSize() * scale
+	drawSize := math.Max(tileScreenSize, 1)
+	for index, code := range observation.LocalOccupancyPatch {
+		tileX := observation.Snapshot.Shooter.TileX + index%width - radius
+		tileY := observation.Snapshot.Shooter.TileY + index/width - radius
+		x, y := g.tileScreenPosition(tileX, tileY, scale, camPos)
+		if fill, ok := policyOccupancyColor(code); ok {
+			g.drawFilledRect(screen, x, y, drawSize, drawSize, fill)
+		}
+		if tileScreenSize >= overlayCountMinTileSize && index < len(observation.LocalTerrainPatch) && observation.LocalTerrainPatch[index] >= 0 {
+			ebitenutil.DebugPrintAt(screen, world.TileType(observation.LocalTerrainPatch[index]).String()[:1], int(x)+2, int(y))
+		}
+	}
+
+	left, top := g.tileScreenPosition(observation.Snapshot.Shooter.TileX-radius, observation.Snapshot.Shooter.TileY-radius, scale, camPos)
+	side := float32(tileScreenSize * float64(width))
+	vector.StrokeRect(screen, float32(left), float32(top), side, side, 1, policyChosenColor, false)
+}
+
+// drawShotFeature draws the nearest-shot feature as a line from the shooter to the projectile.
+func (g *Game) drawShotFeature(screen *ebiten.Image, shooter geom.Point, feature rl.ProjectileFeature, stroke color.Color) {
+	if !feature.Exists {
+		return
+	}
+
+	shot := geom.Point{X: shooter.X + feature.RelativeX, Y: shooter.Y + feature.RelativeY}
+	g.drawWorldPolyline(screen, shooter, []geom.Point{shot}, stroke)
+	g.drawWaypoints(screen, []geom.Point{shot}, stroke)
+}
+
+// drawPolicyCandidate draws one candidate: a line to the move target, a short ray along the
+// fire direction or a ring around the shooter for doing nothing. The chosen candidate gets a
+// white outline underneath so it stands out whatever its value colour.
+func (g *Game) drawPolicyCandidate(screen *ebiten.Image, shooter geom.Point, tileSize float64, action rl.Action, stroke color.Color, label string, chosen bool) {
+	from := g.cam.WorldToScreen(shooter)
+	width := float32(math.Max(1.5, g.cam.Scale()))
+	var to geom.Point
+	switch action.Type {
+	case rl.ActionTypeMove:
+		to = g.cam.WorldToScreen(action.MoveTarget)
+	case rl.ActionTypeFire:
+		to = g.cam.WorldToScreen(geom.Point{
+			X: shooter.X + action.FireDirection.X*tileSize*policyFireRayTiles,
+			Y: shooter.Y + action.FireDirection.Y*tileSize*policyFireRayTiles,
+		})
+	default:
+		ring := float32(math.Max(6, tileSize*g.cam.Scale()))
+		if chosen {
+			vector.StrokeCircle(screen, float32(from.X), float32(from.Y), ring, width+2, policyChosenColor, true)
+		}
+		vector.StrokeCircle(screen, float32(from.X), float32(from.Y), ring, width, stroke, true)
+		ebitenutil.DebugPrintAt(screen, "none "+label, int(from.X+float64(ring)), int(from.Y-float64(ring)))
+		return
+	}
+
+	if chosen {
+		vector.StrokeLine(screen, float32(from.X), float32(from.Y), float32(to.X), float32(to.Y), width+2, policyChosenColor, true)
+	}
+	vector.StrokeLine(screen, float32(from.X), float32(from.Y), float32(to.X), float32(to.Y), width, stroke, true)
+	vector.DrawFilledCircle(screen, float32(to.X), float32(to.Y), width*2, stroke, true)
+	ebitenutil.DebugPrintAt(screen, string(action.Type)+" "+label, int(to.X)+4, int(to.Y)+2)
+}
@@ -0,0 +1,95 @@
+package endless
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/unng-lab/endless/pkg/replay"
+)
+
+// replaySeekTicks is the jump size of the replay seek actions during playback, ten seconds at
+// the default Ebiten tick rate.
+const replaySeekTicks = 600
+
+// updateReplayPlayback handles the seek actions and otherwise lets the simulation clock drive the
+// player, so pause, single steps and speed multipliers behave exactly as in a live session.
+// Seeking backwards rebuilds the manager inside the player, so the cached manager pointer is
+// refreshed after every input and the previous selection is carried over when the unit exists.
+func (g *Game) updateReplayPlayback() error {
+	player := g.replayPlayer
+	selectedID := int64(0)
+	if selected, ok := g.units.Selected(); ok {
+		selectedID = selected.UnitID()
+	}
+
+	var err error
+	switch {
+	case g.input.JustPressed(actionReplayBack):
+		err = player.SeekTick(player.Tick() - replaySeekTicks)
+	case g.input.JustPressed(actionReplayForward):
+		err = player.SeekTick(player.Tick() + replaySeekTicks)
+	case g.input.JustPressed(actionReplayRestart):
+		err = player.SeekTick(0)
+	default:
+		g.clock.advanceFrame(func() bool {
+			if err != nil {
+				return false
+			}
+			var advanced bool
+			advanced, err = player.Step()
+			return advanced && err == nil
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("replay playback: %w", err)
+	}
+
+	if g.units != player.Manager() {
+		g.units = player.Manager()
+		g.units.SelectUnitByID(selectedID)
+	}
+	g.tickCounter = player.Tick()
+	return nil
+}
+
+// replayDebugText describes the playback position and controls, or the active recording.
+func (g *Game) replayDebugText() string {
+	if g.replayPlayer != nil {
+		state := "playing"
+		switch {
+		case g.replayPlayer.Finished():
+			state = "finished"
+		case g.clock.paused:
+			state = "paused"
+		}
+		return fmt.Sprintf(
+			"Replay: tick %d/%d  %s  %s %s: seek %d ticks  %s: restart",
+			g.replayPlayer.Tick(),
+			g.replayPlayer.Replay().Ticks,
+			state,
+			g.input.Label(actionReplayBack),
+			g.input.Label(actionReplayForward),
+			replaySeekTicks,
+			g.input.Label(actionReplayRestart),
+		)
+	}
+	if g.replayRecorder != nil {
+		return fmt.Sprintf("Recording replay: tick %d -> %s", g.tickCounter, g.recordReplayPath)
+	}
+	return ""
+}
+
+// SaveReplayRecording writes the command stream recorded during this session. Launchers call it
+// after the Ebiten loop returns; without a configured record path it does nothing.
+func (g *Game) SaveReplayRecording() error {
+	if g == nil || g.replayRecorder == nil {
+		return nil
+	}
+
+	recorded := g.replayRecorder.Replay()
+	if err := replay.Save(g.recordReplayPath, recorded); err != nil {
+		return err
+	}
+	log.Printf("replay written: %s (%d ticks, %d commands)", g.recordReplayPath, recorded.Ticks, len(recorded.Commands))
+	return nil
+}
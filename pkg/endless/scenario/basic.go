@@ -28,12 +28,16 @@ type basicScenario struct {
 // newBasicScenario records the center anchor used for the lightweight default scene. The
 // actual unit creation is deferred until SeedUnits so the manager still owns all IDs and
 // registration side effects.
-func newBasicScenario(gameWorld world.World) *basicScenario {
+func newBasicScenario(gameWorld world.World, seed int64) *basicScenario {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
 	return &basicScenario{
 		world:       gameWorld,
 		centerTileX: gameWorld.Columns() / 2,
 		centerTileY: gameWorld.Rows() / 2,
-		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng:         rand.New(rand.NewSource(seed)),
 	}
 }
 
@@ -77,6 +81,18 @@ func (s *basicScenario) DebugText() string {
 	return fmt.Sprintf("Scene: basic  units %d  static objects %d", s.spawnedUnits, s.staticObjects)
 }
 
+// Counters exposes the seeded inventory for headless runs.
+func (s *basicScenario) Counters() map[string]int64 {
+	if s == nil {
+		return nil
+	}
+
+	return map[string]int64{
+		"spawned_units":  int64(s.spawnedUnits),
+		"static_objects": int64(s.staticObjects),
+	}
+}
+
 // basicTileAnchor names one tile coordinate pair used by the basic scenario seed layout.
 // Keeping the tile coordinates together avoids passing loosely related ints between helpers.
 type basicTileAnchor struct {
@@ -1,6 +1,7 @@
 package scenario
 
 import (
+	"github.com/unng-lab/endless/pkg/endless/console"
 	"github.com/unng-lab/endless/pkg/rl"
 	"github.com/unng-lab/endless/pkg/unit"
 	"github.com/unng-lab/endless/pkg/world"
@@ -26,11 +27,56 @@ type Scenario interface {
 	DebugText() string
 }
 
+// CounterReporter is implemented by scenarios that track their own progress numbers, such as
+// spawned runners or finished jobs. Headless runs and benchmark reports copy these counters
+// verbatim, so keys should stay stable snake_case names.
+type CounterReporter interface {
+	Counters() map[string]int64
+}
+
+// FramedScenario is implemented by scenarios built around a few known units, such as the two
+// duellists of the RL duel. The desktop camera can keep all of them in frame at once.
+type FramedScenario interface {
+	CameraSubjects() []int64
+}
+
+// PolicyScenario is implemented by scenarios driven by an RL policy. The desktop overlay draws
+// the observation and the scored candidates of the latest decision.
+type PolicyScenario interface {
+	PolicyInspection() (rl.PolicyInspection, bool)
+}
+
+// FinishedScenario is implemented by scenarios that reach an outcome, such as an RL duel that
+// ends in a kill or a timeout. Frame captures stop once Finished reports true.
+type FinishedScenario interface {
+	Finished() bool
+}
+
+// ConsoleScenario is implemented by scenarios that add their own developer console commands,
+// such as swapping the policy of the RL duel. They are registered next to the client commands
+// when the game starts.
+type ConsoleScenario interface {
+	ConsoleCommands() []console.Command
+}
+
+// Counters returns the scenario counters when the scenario exposes any, or nil otherwise.
+func Counters(current Scenario) map[string]int64 {
+	reporter, ok := current.(CounterReporter)
+	if !ok || reporter == nil {
+		return nil
+	}
+
+	return reporter.Counters()
+}
+
 // Config groups every scenario-side option the game constructor may pass into the selected
 // bootstrapper without exposing individual scenario internals to the launcher layer.
 type Config struct {
 	Mode   Mode
 	RLDuel rl.VisualDuelScenarioConfig
+	// Seed fixes the random layout and job targets of the basic and stress scenes. Zero keeps
+	// each scene's historical default: a time-based seed for basic and seed 1 for stress.
+	Seed int64
 }
 
 // New chooses the concrete scene bootstrapper for the requested launch mode. Falling back to
@@ -38,12 +84,12 @@ type Config struct {
 func New(config Config, gameWorld world.World) (Scenario, error) {
 	switch config.Mode {
 	case ModeStress:
-		return newStressScenario(gameWorld), nil
+		return newStressScenario(gameWorld, config.Seed), nil
 	case ModeRLDuel:
 		return rl.NewVisualDuelScenario(gameWorld, config.RLDuel)
 	case ModeBasic:
 		fallthrough
 	default:
-		return newBasicScenario(gameWorld), nil
+		return newBasicScenario(gameWorld, config.Seed), nil
 	}
 }
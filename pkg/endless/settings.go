@@ -0,0 +1,111 @@
+package endless
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// settingsVersion is bumped when the settings layout changes incompatibly; older files are then
+// ignored instead of being half-applied.
+const settingsVersion = 1
+
+// CameraBookmark is one saved viewpoint: the world position of the screen's top-left corner and
+// the zoom level.
+type CameraBookmark struct {
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	Zoom float64 `json:"zoom"`
+}
+
+// userSettings is the small per-user file that survives between sessions. Bookmarks are kept
+// per scene mode because the desktop scene and the RL duel use worlds of different sizes, so a
+// viewpoint from one makes no sense in the other.
+type userSettings struct {
+	Version         int                               `json:"version"`
+	CameraBookmarks map[string]map[int]CameraBookmark `json:"camera_bookmarks,omitempty"`
+}
+
+// DefaultSettingsPath returns the settings file under the user configuration directory, or an
+// empty path, which disables persistence, when the platform has no such directory.
+func DefaultSettingsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "endless", "settings.json")
+}
+
+// loadUserSettings reads the settings file. A missing file or one written by an incompatible
+// version yields empty settings without an error, so a first start looks like any other.
+func loadUserSettings(path string) (userSettings, error) {
+	settings := userSettings{Version: settingsVersion}
+	if path == "" {
+		return settings, nil
+	}
+
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return settings, fmt.Errorf("read settings %s: %w", path, err)
+	}
+
+	var loaded userSettings
+	if err := json.Unmarshal(payload, &loaded); err != nil {
+		return settings, fmt.Errorf("decode settings %s: %w", path, err)
+	}
+	if loaded.Version != settingsVersion {
+		return settings, nil
+	}
+
+	return loaded, nil
+}
+
+// save writes the settings through a temporary file in the same directory, so a crash in the
+// middle of a write never leaves a truncated file behind.
+func (s userSettings) save(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	s.Version = settingsVersion
+	payload, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode settings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create settings directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(payload, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write settings %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("replace settings %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (s userSettings) cameraBookmark(mode string, slot int) (CameraBookmark, bool) {
+	bookmark, ok := s.CameraBookmarks[mode][slot]
+	return bookmark, ok
+}
+
+func (s *userSettings) setCameraBookmark(mode string, slot int, bookmark CameraBookmark) {
+	if s.CameraBookmarks == nil {
+		s.CameraBookmarks = make(map[string]map[int]CameraBookmark)
+	}
+	if s.CameraBookmarks[mode] == nil {
+		s.CameraBookmarks[mode] = make(map[int]CameraBookmark)
+	}
+
+	s.CameraBookmarks[mode][slot] = bookmark
+}
@@ -0,0 +1,48 @@
+package endless
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestUserSettingsRoundTripKeepsBookmarksPerScene verifies that bookmarks written for one scene
+// come back from disk under the same scene and slot only.
+func TestUserSettingsRoundTripKeepsBookmarksPerScene(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "settings.json")
+
+	var settings userSettings
+	settings.setCameraBookmark("rl_duel", 3, CameraBookmark{X: 120, Y: 64, Zoom: 2})
+	if err := settings.save(path); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	loaded, err := loadUserSettings(path)
+	if err != nil {
+		t.Fatalf("loadUserSettings() error = %v", err)
+	}
+	if got, ok := loaded.cameraBookmark("rl_duel", 3); !ok || got != (CameraBookmark{X: 120, Y: 64, Zoom: 2}) {
+		t.Fatalf("cameraBookmark(rl_duel, 3) = %+v, %v, want the saved view", got, ok)
+	}
+	if _, ok := loaded.cameraBookmark("basic", 3); ok {
+		t.Fatal("cameraBookmark(basic, 3) found, want bookmarks kept per scene")
+	}
+}
+
+// TestLoadUserSettingsIgnoresMissingAndForeignFiles verifies that a first start and a file from
+// another settings version both begin with empty settings instead of failing.
+func TestLoadUserSettingsIgnoresMissingAndForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+	if settings, err := loadUserSettings(filepath.Join(dir, "missing.json")); err != nil || len(settings.CameraBookmarks) != 0 {
+		t.Fatalf("loadUserSettings(missing) = %+v, %v, want empty settings", settings, err)
+	}
+
+	foreign := filepath.Join(dir, "foreign.json")
+	payload := []byte(`{"version": 99, "camera_bookmarks": {"basic": {"1": {"x": 1, "y": 2, "zoom": 1}}}}`)
+	if err := os.WriteFile(foreign, payload, 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if settings, err := loadUserSettings(foreign); err != nil || len(settings.CameraBookmarks) != 0 {
+		t.Fatalf("loadUserSettings(foreign) = %+v, %v, want empty settings", settings, err)
+	}
+}
@@ -0,0 +1,154 @@
+package endless
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	// unlimitedSimulationSpeed marks the "as fast as possible" entry of simulationSpeeds.
+	unlimitedSimulationSpeed = 0
+
+	defaultSimulationSpeedIndex = 2
+
+	// unlimitedFrameBudget bounds how long one Ebiten update may spend on simulation ticks in
+	// the unlimited mode, leaving the rest of the 60 Hz frame for input and rendering.
+	unlimitedFrameBudget = 12 * time.Millisecond
+	// maxTicksPerFrame caps catch-up work so a slow frame never snowballs into a stall.
+	maxTicksPerFrame = 4096
+)
+
+// simulationSpeeds lists the multipliers reachable with the speed keys, from quarter speed up
+// to 16x and finally the unlimited mode that runs as many ticks as the frame budget allows.
+var simulationSpeeds = []float64{0.25, 0.5, 1, 2, 4, 8, 16, unlimitedSimulationSpeed}
+
+// simulationClock decouples gameplay ticks from the Ebiten update rate. Every frame it adds the
+// current multiplier to an accumulator and runs one tick per whole unit, so 0.25x advances one
+// tick every fourth frame and 16x runs sixteen ticks per frame. The fractional remainder is the
+// render interpolation factor between the last completed tick and the next one.
+type simulationClock struct {
+	speedIndex   int
+	paused       bool
+	accumulator  float64
+	pendingSteps int
+	lastTicks    int
+	now          func() time.Time
+}
+
+func newSimulationClock() *simulationClock {
+	return &simulationClock{
+		speedIndex: defaultSimulationSpeedIndex,
+		now:        time.Now,
+	}
+}
+
+// advanceFrame runs the ticks owed for one Ebiten update through runTick and returns how many
+// ran. runTick reports false when the simulation cannot advance any further, such as at the
+// end of a replay, which stops the frame early. While paused only explicit single steps run.
+func (c *simulationClock) advanceFrame(runTick func() bool) int {
+	ticks := 0
+	defer func() {
+		c.lastTicks = ticks
+	}()
+
+	if c.paused {
+		for c.pendingSteps > 0 {
+			c.pendingSteps--
+			if !runTick() {
+				c.pendingSteps = 0
+				break
+			}
+			ticks++
+		}
+		return ticks
+	}
+	c.pendingSteps = 0
+
+	speed := simulationSpeeds[c.speedIndex]
+	if speed == unlimitedSimulationSpeed {
+		c.accumulator = 0
+		deadline := c.now().Add(unlimitedFrameBudget)
+		for ticks < maxTicksPerFrame && runTick() {
+			ticks++
+			if !c.now().Before(deadline) {
+				break
+			}
+		}
+		return ticks
+	}
+
+	c.accumulator += speed
+	owed := min(int(c.accumulator), maxTicksPerFrame)
+	c.accumulator -= float64(owed)
+	for ticks < owed {
+		if !runTick() {
+			c.accumulator = 0
+			break
+		}
+		ticks++
+	}
+	if c.accumulator >= 1 {
+		c.accumulator = 0
+	}
+	return ticks
+}
+
+// interpolation reports how far wall-clock time has progressed towards the next tick. The
+// unlimited mode has no stable tick duration, so it always renders the last completed tick.
+func (c *simulationClock) interpolation() float64 {
+	if simulationSpeeds[c.speedIndex] == unlimitedSimulationSpeed {
+		return 0
+	}
+	return c.accumulator
+}
+
+func (c *simulationClock) togglePause() {
+	c.paused = !c.paused
+	c.pendingSteps = 0
+}
+
+// step queues one tick for the next frame and pauses the clock, so repeated presses walk the
+// simulation forward one tick at a time.
+func (c *simulationClock) step() {
+	c.paused = true
+	c.pendingSteps++
+}
+
+func (c *simulationClock) faster() {
+	if c.speedIndex < len(simulationSpeeds)-1 {
+		c.speedIndex++
+	}
+}
+
+func (c *simulationClock) slower() {
+	if c.speedIndex > 0 {
+		c.speedIndex--
+		c.accumulator = 0
+	}
+}
+
+// setSpeed selects the listed multiplier equal to speed, with unlimitedSimulationSpeed for
+// the unlimited mode, and reports false for values the speed keys cannot reach.
+func (c *simulationClock) setSpeed(speed float64) bool {
+	index := slices.Index(simulationSpeeds, speed)
+	if index < 0 {
+		return false
+	}
+	c.speedIndex = index
+	c.accumulator = 0
+	return true
+}
+
+// speedLabel formats the current multiplier for the debug overlay.
+func (c *simulationClock) speedLabel() string {
+	speed := simulationSpeeds[c.speedIndex]
+	label := "max"
+	if speed != unlimitedSimulationSpeed {
+		label = fmt.Sprintf("%gx", speed)
+	}
+	if c.paused {
+		label += " paused"
+	}
+	return label
+}
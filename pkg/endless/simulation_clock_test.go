@@ -0,0 +1,122 @@
+package endless
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestSimulationClockRunsTicksProportionalToSpeed verifies that fractional multipliers spread
+// ticks over several frames, integer multipliers batch them into one frame, and the leftover
+// fraction is exposed as the render interpolation factor.
+func TestSimulationClockRunsTicksProportionalToSpeed(t *testing.T) {
+	tests := []struct {
+		name      string
+		speed     float64
+		frames    int
+		wantTicks int
+	}{
+		{name: "quarter speed", speed: 0.25, frames: 8, wantTicks: 2},
+		{name: "half speed", speed: 0.5, frames: 8, wantTicks: 4},
+		{name: "normal speed", speed: 1, frames: 8, wantTicks: 8},
+		{name: "sixteen times", speed: 16, frames: 3, wantTicks: 48},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newSimulationClock()
+			clock.speedIndex = simulationSpeedIndex(t, tt.speed)
+
+			ticks := 0
+			for range tt.frames {
+				clock.advanceFrame(func() bool {
+					ticks++
+					return true
+				})
+			}
+			if ticks != tt.wantTicks {
+				t.Fatalf("ticks = %d, want %d", ticks, tt.wantTicks)
+			}
+		})
+	}
+
+	clock := newSimulationClock()
+	clock.speedIndex = simulationSpeedIndex(t, 0.25)
+	clock.advanceFrame(func() bool { return true })
+	clock.advanceFrame(func() bool { return true })
+	if got := clock.interpolation(); math.Abs(got-0.5) > 1e-9 {
+		t.Fatalf("interpolation() = %.3f, want 0.5", got)
+	}
+}
+
+// TestSimulationClockPauseOnlyRunsRequestedSteps verifies that a paused clock ignores the
+// accumulator and runs exactly the number of single steps queued since the previous frame.
+func TestSimulationClockPauseOnlyRunsRequestedSteps(t *testing.T) {
+	clock := newSimulationClock()
+	clock.togglePause()
+
+	run := func() bool { return true }
+	if got := clock.advanceFrame(run); got != 0 {
+		t.Fatalf("paused advanceFrame() = %d, want 0", got)
+	}
+
+	clock.step()
+	clock.step()
+	if got := clock.advanceFrame(run); got != 2 {
+		t.Fatalf("advanceFrame() after two steps = %d, want 2", got)
+	}
+	if got := clock.advanceFrame(run); got != 0 {
+		t.Fatalf("advanceFrame() after consumed steps = %d, want 0", got)
+	}
+
+	clock.togglePause()
+	if got := clock.advanceFrame(run); got != 1 {
+		t.Fatalf("resumed advanceFrame() = %d, want 1", got)
+	}
+}
+
+// TestSimulationClockUnlimitedSpeedStopsAtFrameBudget verifies that the unlimited mode keeps
+// running ticks until the injected clock passes the frame budget and never interpolates.
+func TestSimulationClockUnlimitedSpeedStopsAtFrameBudget(t *testing.T) {
+	clock := newSimulationClock()
+	for range simulationSpeeds {
+		clock.faster()
+	}
+	if got := clock.speedLabel(); got != "max" {
+		t.Fatalf("speedLabel() = %q, want %q", got, "max")
+	}
+
+	now := time.Unix(0, 0)
+	clock.now = func() time.Time { return now }
+	ticks := clock.advanceFrame(func() bool {
+		now = now.Add(time.Millisecond)
+		return true
+	})
+	if want := int(unlimitedFrameBudget / time.Millisecond); ticks != want {
+		t.Fatalf("advanceFrame() = %d, want %d", ticks, want)
+	}
+	if got := clock.interpolation(); got != 0 {
+		t.Fatalf("interpolation() = %.3f, want 0", got)
+	}
+
+	stopAfter := 3
+	ticks = clock.advanceFrame(func() bool {
+		stopAfter--
+		return stopAfter >= 0
+	})
+	if ticks != 3 {
+		t.Fatalf("advanceFrame() with exhausted simulation = %d, want 3", ticks)
+	}
+}
+
+func simulationSpeedIndex(t *testing.T, speed float64) int {
+	t.Helper()
+
+	for index, candidate := range simulationSpeeds {
+		if candidate == speed {
+			return index
+		}
+	}
+	t.Fatalf("speed %.2f is not configured", speed)
+	return 0
+}
@@ -10,10 +10,15 @@ import (
 
 	"github.com/unng-lab/endless/pkg/assets"
 	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/world"
 )
 
 const tileRenderBatchSize = 32
 
+// autotileMinTileSize is the smallest on-screen tile size, in pixels, that still gets terrain
+// transitions. Below it the blended borders are a pixel or two wide and only cost draw calls.
+const autotileMinTileSize = 12.0
+
 // tileDrawCommand stores one prepared terrain draw call that can be replayed onto any Ebiten
 // image target without recalculating the tile's asset choice, tint or screen transform.
 type tileDrawCommand struct {
@@ -183,6 +188,8 @@ func (g *Game) drawTileRange(dst *ebiten.Image, offset int, workerCount int, req
 		return
 	}
 
+	drawTransitions := req.drawSize >= autotileMinTileSize
+	var transitions []world.Transition
 	stride := workerCount * tileRenderBatchSize
 	for blockStart := req.minIndex + offset*tileRenderBatchSize; blockStart < req.maxIndex; blockStart += stride {
 		for indexOffset := 0; indexOffset < tileRenderBatchSize; indexOffset++ {
@@ -197,8 +204,42 @@ func (g *Game) drawTileRange(dst *ebiten.Image, offset int, workerCount int, req
 				req.errSink.store(err)
 			}
 			dst.DrawImage(command.image, &command.options)
+			if drawTransitions && command.image != g.tile {
+				transitions = g.world.AppendTransitions(transitions[:0], tileX, tileY)
+				if err := g.drawTileTransitions(dst, transitions, command.options.GeoM, req.quality); err != nil {
+					req.errSink.store(err)
+				}
+			}
+		}
+	}
+}
+
+// drawTileTransitions blends the neighbouring terrains into one tile. Each transition draws its
+// edge and corner sub-tiles with the tile's own transform, tinted like the neighbour terrain, in
+// the ascending blend priority AppendTransitions sorted them by.
+func (g *Game) drawTileTransitions(dst *ebiten.Image, transitions []world.Transition, geoM ebiten.GeoM, quality assets.Quality) error {
+	for _, transition := range transitions {
+		for _, part := range [...]struct {
+			shape assets.TransitionShape
+			mask  uint8
+		}{
+			{shape: assets.TransitionEdge, mask: uint8(transition.Edges)},
+			{shape: assets.TransitionCorner, mask: uint8(transition.Corners)},
+		} {
+			if part.mask == 0 {
+				continue
+			}
+			overlay, err := g.atlas.TransitionImage(part.shape, part.mask, quality)
+			if err != nil {
+				return err
+			}
+
+			options := ebiten.DrawImageOptions{GeoM: geoM}
+			options.ColorScale.ScaleWithColor(transition.Terrain.Tint())
+			dst.DrawImage(overlay, &options)
 		}
 	}
+	return nil
 }
 
 // buildTileDrawCommand resolves every per-tile render decision up front so a caller can execute
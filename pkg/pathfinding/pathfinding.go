@@ -35,7 +35,20 @@ var neighbors = [...]neighbor{
 	{dx: -1, dy: 1, cost: math.Sqrt2},
 }
 
+// Trace records what one search did, for debug overlays that show why a route looks the way
+// it does or why none was found.
+type Trace struct {
+	// Expanded lists the tiles taken off the open set, in the order they were expanded.
+	Expanded []Step
+}
+
 func FindPath(grid Grid, start, goal Step) ([]Step, error) {
+	return FindPathTraced(grid, start, goal, nil)
+}
+
+// FindPathTraced is FindPath that also appends every expanded tile to the trace. A nil trace
+// costs nothing, which keeps the plain entry point free of any bookkeeping.
+func FindPathTraced(grid Grid, start, goal Step, trace *Trace) ([]Step, error) {
 	if !isWalkable(grid, start.X, start.Y) || !isWalkable(grid, goal.X, goal.Y) {
 		return nil, ErrNoPath
 	}
@@ -64,6 +77,9 @@ func FindPath(grid Grid, start, goal Step) ([]Step, error) {
 			return reconstructPath(cameFrom, start, goal), nil
 		}
 		closed[current.step] = true
+		if trace != nil {
+			trace.Expanded = append(trace.Expanded, current.step)
+		}
 
 		for _, dir := range neighbors {
 			next := Step{
@@ -83,3 +83,22 @@ func TestFindPathSameStartAndGoal(t *testing.T) {
 		t.Fatalf("FindPath len = %d, want 0", len(path))
 	}
 }
+
+func TestFindPathTracedRecordsExpandedTiles(t *testing.T) {
+	grid := testGrid{
+		".#.",
+		".#.",
+		".#.",
+	}
+
+	var trace Trace
+	if _, err := FindPathTraced(grid, Step{X: 0, Y: 0}, Step{X: 2, Y: 2}, &trace); err != ErrNoPath {
+		t.Fatalf("FindPathTraced error = %v, want %v", err, ErrNoPath)
+	}
+	if len(trace.Expanded) != 3 {
+		t.Fatalf("expanded tiles = %v, want the three reachable tiles of the left column", trace.Expanded)
+	}
+	if trace.Expanded[0] != (Step{X: 0, Y: 0}) {
+		t.Fatalf("first expanded tile = %+v, want the start", trace.Expanded[0])
+	}
+}
@@ -0,0 +1,177 @@
+package replay
+
+import (
+	"fmt"
+
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// Player re-drives a fresh unit.Manager from one recorded command stream. It owns the manager
+// because seeking backwards has to rebuild the simulation from tick zero; callers that render
+// the playback must therefore fetch Manager again after every SeekTick instead of caching it.
+type Player struct {
+	replay  Replay
+	world   world.World
+	manager *unit.Manager
+
+	tick        int64
+	nextCommand int
+	paused      bool
+}
+
+// NewPlayer validates the replay, builds its world and applies the tick-zero commands, which
+// usually contain the complete initial spawn layout of the recorded scenario.
+func NewPlayer(r Replay) (*Player, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Player{
+		replay: r,
+		world:  world.New(r.World),
+	}
+	if err := p.reset(); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// Manager returns the manager that currently holds the playback state.
+func (p *Player) Manager() *unit.Manager {
+	return p.manager
+}
+
+// World returns the world rebuilt from the replay configuration.
+func (p *Player) World() world.World {
+	return p.world
+}
+
+// Replay returns the recording that drives this player.
+func (p *Player) Replay() Replay {
+	return p.replay
+}
+
+// Tick reports the last simulation tick the playback has completed.
+func (p *Player) Tick() int64 {
+	return p.tick
+}
+
+// Finished reports whether playback has reached the last recorded tick.
+func (p *Player) Finished() bool {
+	return p.tick >= p.replay.Ticks
+}
+
+// Paused reports whether Advance is currently suspended.
+func (p *Player) Paused() bool {
+	return p.paused
+}
+
+// SetPaused suspends or resumes Advance. Step and SeekTick keep working while paused so viewers can
+// inspect the recording frame by frame.
+func (p *Player) SetPaused(paused bool) {
+	p.paused = paused
+}
+
+// TogglePause flips the paused state and returns the new value.
+func (p *Player) TogglePause() bool {
+	p.paused = !p.paused
+	return p.paused
+}
+
+// Advance runs one playback tick unless the player is paused or already finished. Interactive
+// launchers call it once per frame in place of their own scenario and manager updates.
+func (p *Player) Advance() error {
+	if p.paused || p.Finished() {
+		return nil
+	}
+
+	_, err := p.Step()
+	return err
+}
+
+// Step runs exactly one simulation tick and then applies the commands recorded right after that
+// tick. It reports false once the recording is exhausted.
+func (p *Player) Step() (bool, error) {
+	if p.Finished() {
+		return false, nil
+	}
+
+	p.tick++
+	p.manager.Update(p.tick)
+	if err := p.applyCommandsThroughTick(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SeekTick moves playback to the requested tick, clamped to the recording length. Forward
+// seeks keep stepping the current manager, while backward seeks rebuild it from tick zero
+// because the simulation has no snapshots to rewind to.
+func (p *Player) SeekTick(tick int64) error {
+	tick = max(0, min(tick, p.replay.Ticks))
+	if tick < p.tick {
+		if err := p.reset(); err != nil {
+			return err
+		}
+	}
+
+	for p.tick < tick {
+		if _, err := p.Step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close releases the manager worker pool owned by the player.
+func (p *Player) Close() {
+	if p == nil || p.manager == nil {
+		return
+	}
+
+	p.manager.Close()
+	p.manager = nil
+}
+
+func (p *Player) reset() error {
+	p.Close()
+	p.manager = unit.NewManager(p.world)
+	p.tick = 0
+	p.nextCommand = 0
+	return p.applyCommandsThroughTick()
+}
+
+// applyCommandsThroughTick replays every command stamped with the current tick. Rejected unit
+// orders are expected because the recording keeps failed orders too, so only commands that
+// cannot be applied at all abort playback.
+func (p *Player) applyCommandsThroughTick() error {
+	for p.nextCommand < len(p.replay.Commands) {
+		command := p.replay.Commands[p.nextCommand]
+		if command.Tick > p.tick {
+			return nil
+		}
+		p.nextCommand++
+
+		err := p.manager.ApplyCommand(command)
+		if err == nil {
+			continue
+		}
+		if orderCommand(command.Type) {
+			continue
+		}
+		return fmt.Errorf("apply replay command %d at tick %d: %w", p.nextCommand-1, command.Tick, err)
+	}
+	return nil
+}
+
+// orderCommand reports the command types whose rejection is part of the recorded session.
+func orderCommand(commandType unit.CommandType) bool {
+	switch commandType {
+	case unit.CommandMoveOrder, unit.CommandFireOrder, unit.CommandStopOrder, unit.CommandHoldOrder:
+		return true
+	default:
+		return false
+	}
+}
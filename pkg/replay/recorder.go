@@ -0,0 +1,98 @@
+package replay
+
+import (
+	"sync"
+	"time"
+
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// Recorder accumulates the command stream of one manager. It is installed through Attach and
+// keeps the highest completed tick separately, because the final ticks of a session usually
+// carry no commands but still belong to the recording.
+type Recorder struct {
+	mu       sync.Mutex
+	scenario string
+	seed     int64
+	world    world.Config
+	ticks    int64
+	commands []unit.Command
+}
+
+// NewRecorder prepares an empty recording for one world configuration. Scenario and seed are
+// stored as metadata so a replay file can be traced back to the session that produced it.
+func NewRecorder(scenario string, seed int64, worldConfig world.Config) *Recorder {
+	return &Recorder{
+		scenario: scenario,
+		seed:     seed,
+		world:    worldConfig,
+		commands: make([]unit.Command, 0),
+	}
+}
+
+// Attach starts recording every external command accepted by the manager. Callers must attach
+// before seeding units, otherwise the initial spawns are missing from the replay.
+func (r *Recorder) Attach(manager *unit.Manager) {
+	if r == nil || manager == nil {
+		return
+	}
+
+	manager.SetCommandRecorder(r.Record)
+}
+
+// MarkTick records that the simulation has completed the given tick. Launchers call it after
+// every manager Update so the replay length covers trailing command-free ticks as well.
+func (r *Recorder) MarkTick(tick int64) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tick > r.ticks {
+		r.ticks = tick
+	}
+}
+
+// Replay returns a snapshot of everything recorded so far. The snapshot owns its command slice
+// so the live recorder may keep appending while the caller saves the copy.
+func (r *Recorder) Replay() Replay {
+	if r == nil {
+		return Replay{Version: replayVersion}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return Replay{
+		Version:    replayVersion,
+		RecordedAt: time.Now().UTC(),
+		Scenario:   r.scenario,
+		Seed:       r.seed,
+		World:      r.world,
+		Ticks:      r.ticks,
+		Commands:   append([]unit.Command(nil), r.commands...),
+	}
+}
+
+// Record appends one command to the recording. Attach wires it into a manager directly; it is
+// exported for owners such as the RL environment that rebuild their manager on every reset.
+func (r *Recorder) Record(command unit.Command) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if command.Unit != nil {
+		spec := *command.Unit
+		command.Unit = &spec
+	}
+	r.commands = append(r.commands, command)
+	if command.Tick > r.ticks {
+		r.ticks = command.Tick
+	}
+}
@@ -0,0 +1,91 @@
+// Package replay records the external command stream of one unit.Manager session and plays it
+// back deterministically. A replay file stores only the world configuration and the commands
+// that entered through the public manager API, so even long sessions stay small while the
+// simulation itself is re-derived tick by tick.
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+const replayVersion = 1
+
+// Replay is the serialized form of one recorded session. Seed and Scenario are informational
+// metadata for humans and launchers; playback depends only on World, Ticks and Commands.
+type Replay struct {
+	Version    int            `json:"version"`
+	RecordedAt time.Time      `json:"recorded_at"`
+	Scenario   string         `json:"scenario"`
+	Seed       int64          `json:"seed"`
+	World      world.Config   `json:"world"`
+	Ticks      int64          `json:"ticks"`
+	Commands   []unit.Command `json:"commands"`
+}
+
+// Validate rejects replays that cannot be played back faithfully, such as an unknown format
+// version, an empty world or commands that are not sorted by tick.
+func (r Replay) Validate() error {
+	if r.Version != replayVersion {
+		return fmt.Errorf("unsupported replay version %d, want %d", r.Version, replayVersion)
+	}
+	if r.World.Columns <= 0 || r.World.Rows <= 0 || r.World.TileSize <= 0 {
+		return fmt.Errorf("replay world config %+v is empty", r.World)
+	}
+	if r.Ticks < 0 {
+		return fmt.Errorf("replay tick count %d is negative", r.Ticks)
+	}
+	sorted := sort.SliceIsSorted(r.Commands, func(i, j int) bool {
+		return r.Commands[i].Tick < r.Commands[j].Tick
+	})
+	if !sorted {
+		return fmt.Errorf("replay commands are not ordered by tick")
+	}
+	return nil
+}
+
+// Save writes one replay as indented JSON after validating that it can be played back.
+func Save(path string, r Replay) error {
+	if path == "" {
+		return fmt.Errorf("replay path is empty")
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	payload, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal replay: %w", err)
+	}
+	if err := os.WriteFile(path, payload, 0o644); err != nil {
+		return fmt.Errorf("write replay %q: %w", path, err)
+	}
+	return nil
+}
+
+// Load restores one replay file and validates it before any manager is created from it.
+func Load(path string) (Replay, error) {
+	if path == "" {
+		return Replay{}, fmt.Errorf("replay path is empty")
+	}
+
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return Replay{}, fmt.Errorf("read replay %q: %w", path, err)
+	}
+
+	var r Replay
+	if err := json.Unmarshal(payload, &r); err != nil {
+		return Replay{}, fmt.Errorf("unmarshal replay %q: %w", path, err)
+	}
+	if err := r.Validate(); err != nil {
+		return Replay{}, fmt.Errorf("validate replay %q: %w", path, err)
+	}
+	return r, nil
+}
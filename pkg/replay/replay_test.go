@@ -0,0 +1,125 @@
+package replay
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+func TestPlayerReproducesRecordedStateHashes(t *testing.T) {
+	recorded, hashes := recordTestSession(t, 90)
+
+	player, err := NewPlayer(recorded)
+	if err != nil {
+		t.Fatalf("NewPlayer() error = %v", err)
+	}
+	defer player.Close()
+
+	if got := player.Manager().StateHash(); got != hashes[0] {
+		t.Fatalf("tick 0: StateHash() = %x, want %x", got, hashes[0])
+	}
+	for tick := int64(1); tick <= recorded.Ticks; tick++ {
+		stepped, err := player.Step()
+		if err != nil {
+			t.Fatalf("Step() error = %v", err)
+		}
+		if !stepped {
+			t.Fatalf("Step() = false at tick %d, want true", tick)
+		}
+		if got := player.Manager().StateHash(); got != hashes[tick] {
+			t.Fatalf("tick %d: StateHash() = %x, want %x", tick, got, hashes[tick])
+		}
+	}
+	if stepped, _ := player.Step(); stepped {
+		t.Fatal("Step() = true after the last recorded tick, want false")
+	}
+}
+
+func TestPlayerSeekRebuildsStateWhenMovingBackwards(t *testing.T) {
+	recorded, hashes := recordTestSession(t, 60)
+
+	player, err := NewPlayer(recorded)
+	if err != nil {
+		t.Fatalf("NewPlayer() error = %v", err)
+	}
+	defer player.Close()
+
+	if err := player.SeekTick(50); err != nil {
+		t.Fatalf("SeekTick(50) error = %v", err)
+	}
+	if err := player.SeekTick(12); err != nil {
+		t.Fatalf("SeekTick(12) error = %v", err)
+	}
+	if player.Tick() != 12 {
+		t.Fatalf("Tick() = %d, want 12", player.Tick())
+	}
+	if got := player.Manager().StateHash(); got != hashes[12] {
+		t.Fatalf("StateHash() after backward seek = %x, want %x", got, hashes[12])
+	}
+
+	player.SetPaused(true)
+	if err := player.Advance(); err != nil {
+		t.Fatalf("Advance() error = %v", err)
+	}
+	if player.Tick() != 12 {
+		t.Fatalf("Tick() after paused Advance = %d, want 12", player.Tick())
+	}
+}
+
+func TestSaveAndLoadRoundTripReplay(t *testing.T) {
+	recorded, _ := recordTestSession(t, 20)
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	if err := Save(path, recorded); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Ticks != recorded.Ticks || len(loaded.Commands) != len(recorded.Commands) {
+		t.Fatalf("Load() = %d ticks / %d commands, want %d / %d", loaded.Ticks, len(loaded.Commands), recorded.Ticks, len(recorded.Commands))
+	}
+	if loaded.World != recorded.World {
+		t.Fatalf("Load() world = %+v, want %+v", loaded.World, recorded.World)
+	}
+}
+
+// recordTestSession drives one small skirmish through the public manager API and returns the
+// recording together with the live state hash after every tick, indexed by tick. Commands are
+// issued between updates, so each hash already includes the commands stamped with its tick,
+// which is exactly the state a player exposes after Step.
+func recordTestSession(t *testing.T, ticks int64) (Replay, []uint64) {
+	t.Helper()
+
+	worldConfig := world.Config{Columns: 32, Rows: 32, TileSize: 16}
+	recorder := NewRecorder("test", 7, worldConfig)
+	manager := unit.NewManager(world.New(worldConfig))
+	defer manager.Close()
+	recorder.Attach(manager)
+
+	shooterID := manager.AddUnit(unit.NewRunner(geom.Point{X: 24, Y: 24}, false, 0))
+	targetID := manager.AddUnit(unit.NewRunner(geom.Point{X: 120, Y: 24}, true, 5))
+	manager.AddUnit(unit.NewWall(geom.Point{X: 72, Y: 72}))
+
+	hashes := []uint64{manager.StateHash()}
+	for tick := int64(1); tick <= ticks; tick++ {
+		manager.Update(tick)
+		recorder.MarkTick(tick)
+		switch tick {
+		case 3:
+			_ = manager.IssueFireOrder(shooterID, geom.Point{X: 1, Y: 0})
+		case 10:
+			_ = manager.IssueMoveOrder(targetID, geom.Point{X: 120, Y: 200})
+		case 25:
+			_ = manager.IssueMoveOrder(shooterID, geom.Point{X: 72, Y: 72})
+			manager.AddUnit(unit.NewBarricade(geom.Point{X: 200, Y: 200}))
+		}
+		hashes = append(hashes, manager.StateHash())
+	}
+
+	return recorder.Replay(), hashes
+}
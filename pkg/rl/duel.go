@@ -20,6 +20,15 @@ type DuelRunConfig struct {
 	WorldRows          int
 	TileSize           float64
 	Scenario           string
+	// UpdateWorkers overrides the unit manager worker count. Zero keeps the manager default;
+	// desync checks set it explicitly to compare the same episode across worker layouts.
+	UpdateWorkers int
+	// ShooterLives and TargetLives give each duellist that many respawns at its spawn point,
+	// RespawnDelayTicks after it was killed, for multi-life episodes. An episode then ends on
+	// the death that spends the last life. Zero keeps the one-kill episode.
+	ShooterLives      int
+	TargetLives       int
+	RespawnDelayTicks int64
 }
 
 // RunDuelCollection executes deterministic duel episodes and streams their resulting
@@ -134,6 +143,9 @@ func normalizedDuelRunConfig(config DuelRunConfig) DuelRunConfig {
 	if config.TileSize <= 0 {
 		config.TileSize = 16
 	}
+	if config.RespawnDelayTicks <= 0 {
+		config.RespawnDelayTicks = defaultDuelRespawnDelayTicks
+	}
 	config.Scenario = normalizedDuelScenarioName(config.Scenario)
 	return config
 }
@@ -160,10 +172,18 @@ func resolvePostTickSnapshot(manager *unit.Manager, shooterID, targetID int64, f
 
 	resolved := fallback
 	if manager != nil {
-		if shooterSnapshot, ok := manager.UnitSnapshot(shooterID); ok {
+		// A duellist missing from the manager is dead, either killed this tick or waiting for
+		// its respawn.
+		if shooterSnapshot, ok := manager.UnitSnapshot(shooterID); !ok {
+			resolved.Shooter.Alive = false
+			resolved.Shooter.Health = 0
+		} else {
 			resolved.Shooter = shooterSnapshot
 		}
-		if targetSnapshot, ok := manager.UnitSnapshot(targetID); ok {
+		if targetSnapshot, ok := manager.UnitSnapshot(targetID); !ok {
+			resolved.Target.Alive = false
+			resolved.Target.Health = 0
+		} else {
 			resolved.Target = targetSnapshot
 		}
 	}
@@ -0,0 +1,157 @@
+package rl
+
+import (
+	"context"
+	"fmt"
+)
+
+// DuelDesyncRun describes one replay of the checked episode suite. The first run is the
+// reference, the second repeats it with the same worker count and every further run changes
+// only the manager worker count.
+type DuelDesyncRun struct {
+	Label         string
+	UpdateWorkers int
+	TicksExecuted int64
+	FinalHash     uint64
+}
+
+// DuelDesyncReport summarizes one determinism check. When Diverged is set, the Diverged*
+// fields identify the first episode, the first tick and the run whose state hash differed from
+// the reference; tick zero means the freshly reset state already disagreed.
+type DuelDesyncReport struct {
+	EpisodesChecked int
+	Runs            []DuelDesyncRun
+
+	Diverged        bool
+	DivergedEpisode int
+	DivergedSeed    int64
+	DivergedTick    int64
+	DivergedRun     string
+	ReferenceHash   uint64
+	CandidateHash   uint64
+}
+
+// RunDuelDesyncCheck replays the same deterministic episode suite several times and compares
+// the environment state hash after every tick. The suite runs twice with the first worker
+// count to catch nondeterminism that does not depend on parallelism, and then once per each
+// remaining worker count. Policies are rebuilt by name for every run so stateful policies such
+// as the random baseline start from the same seed each time.
+func RunDuelDesyncCheck(ctx context.Context, config DuelRunConfig, policyName string, workerCounts []int) (DuelDesyncReport, error) {
+	config = normalizedDuelRunConfig(config)
+	if len(workerCounts) == 0 {
+		workerCounts = []int{1, 4}
+	}
+
+	runs := []DuelDesyncRun{
+		{Label: "reference", UpdateWorkers: workerCounts[0]},
+		{Label: "repeat", UpdateWorkers: workerCounts[0]},
+	}
+	for _, workerCount := range workerCounts[1:] {
+		runs = append(runs, DuelDesyncRun{
+			Label:         fmt.Sprintf("workers=%d", workerCount),
+			UpdateWorkers: workerCount,
+		})
+	}
+
+	report := DuelDesyncReport{Runs: runs}
+	for episodeIndex, episodeSeed := range generateDuelEvaluationEpisodeSeeds(config.Seed, config.Episodes) {
+		select {
+		case <-ctx.Done():
+			return report, ctx.Err()
+		default:
+		}
+
+		var reference []uint64
+		for runIndex := range report.Runs {
+			run := &report.Runs[runIndex]
+			runConfig := config
+			runConfig.UpdateWorkers = run.UpdateWorkers
+			trace, err := traceDuelEpisodeStateHashes(runConfig, policyName, episodeSeed)
+			if err != nil {
+				return report, fmt.Errorf("trace duel episode %d (%s): %w", episodeIndex+1, run.Label, err)
+			}
+			run.TicksExecuted += int64(len(trace) - 1)
+			run.FinalHash = trace[len(trace)-1]
+
+			if runIndex == 0 {
+				reference = trace
+				continue
+			}
+			tick, diverged := firstDivergedTick(reference, trace)
+			if !diverged {
+				continue
+			}
+
+			report.Diverged = true
+			report.DivergedEpisode = episodeIndex + 1
+			report.DivergedSeed = episodeSeed
+			report.DivergedTick = tick
+			report.DivergedRun = run.Label
+			report.ReferenceHash = hashAtTick(reference, tick)
+			report.CandidateHash = hashAtTick(trace, tick)
+			return report, nil
+		}
+		report.EpisodesChecked++
+	}
+
+	return report, nil
+}
+
+// traceDuelEpisodeStateHashes runs one episode with the same policy/environment loop as
+// evaluation mode and records the state hash after reset and after every executed tick.
+func traceDuelEpisodeStateHashes(config DuelRunConfig, policyName string, episodeSeed int64) ([]uint64, error) {
+	policy, err := NewPolicyByName(policyName, config.Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	environment := NewDuelEnvironment(config)
+	defer environment.Close()
+
+	before, err := environment.Reset(episodeSeed)
+	if err != nil {
+		return nil, err
+	}
+
+	trace := make([]uint64, 0, config.MaxTicksPerEpisode+1)
+	trace = append(trace, environment.StateHash())
+	for tick := int64(1); tick <= config.MaxTicksPerEpisode; tick++ {
+		_, _ = environment.ApplyAction(policy.ChooseAction(before))
+
+		stepResult, err := environment.Step()
+		if err != nil {
+			return nil, err
+		}
+		trace = append(trace, environment.StateHash())
+
+		before = stepResult.After
+		if stepResult.Done {
+			break
+		}
+	}
+
+	return trace, nil
+}
+
+// firstDivergedTick returns the first trace index whose hashes differ. A trace that ends
+// earlier than the other diverges at the first tick that only one of them executed.
+func firstDivergedTick(reference, candidate []uint64) (int64, bool) {
+	shared := min(len(reference), len(candidate))
+	for index := 0; index < shared; index++ {
+		if reference[index] != candidate[index] {
+			return int64(index), true
+		}
+	}
+	if len(reference) != len(candidate) {
+		return int64(shared), true
+	}
+
+	return 0, false
+}
+
+func hashAtTick(trace []uint64, tick int64) uint64 {
+	if tick < 0 || tick >= int64(len(trace)) {
+		return 0
+	}
+	return trace[tick]
+}
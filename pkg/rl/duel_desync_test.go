@@ -0,0 +1,49 @@
+package rl
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRunDuelDesyncCheckReportsNoDivergenceAcrossWorkerCounts(t *testing.T) {
+	config := DuelRunConfig{
+		Episodes:           2,
+		MaxTicksPerEpisode: 120,
+		Seed:               5,
+		WorldColumns:       64,
+		WorldRows:          64,
+		TileSize:           16,
+		Scenario:           DuelScenarioWithCover,
+	}
+
+	report, err := RunDuelDesyncCheck(context.Background(), config, PolicyRandom, []int{1, 3})
+	if err != nil {
+		t.Fatalf("RunDuelDesyncCheck() error = %v", err)
+	}
+	if report.Diverged {
+		t.Fatalf("unexpected divergence in %s at episode %d tick %d", report.DivergedRun, report.DivergedEpisode, report.DivergedTick)
+	}
+	if report.EpisodesChecked != config.Episodes {
+		t.Fatalf("EpisodesChecked = %d, want %d", report.EpisodesChecked, config.Episodes)
+	}
+	if len(report.Runs) != 3 {
+		t.Fatalf("len(Runs) = %d, want 3", len(report.Runs))
+	}
+	for _, run := range report.Runs[1:] {
+		if run.FinalHash != report.Runs[0].FinalHash || run.TicksExecuted != report.Runs[0].TicksExecuted {
+			t.Fatalf("run %s = %+v, want final state of reference %+v", run.Label, run, report.Runs[0])
+		}
+	}
+}
+
+func TestFirstDivergedTickReportsEarliestMismatch(t *testing.T) {
+	if tick, diverged := firstDivergedTick([]uint64{1, 2, 3}, []uint64{1, 2, 3}); diverged {
+		t.Fatalf("firstDivergedTick() = %d, true; want no divergence", tick)
+	}
+	if tick, diverged := firstDivergedTick([]uint64{1, 2, 3}, []uint64{1, 9, 4}); !diverged || tick != 1 {
+		t.Fatalf("firstDivergedTick() = %d, %v; want 1, true", tick, diverged)
+	}
+	if tick, diverged := firstDivergedTick([]uint64{1, 2, 3}, []uint64{1, 2}); !diverged || tick != 2 {
+		t.Fatalf("firstDivergedTick() = %d, %v; want 2, true", tick, diverged)
+	}
+}
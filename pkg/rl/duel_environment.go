@@ -1,7 +1,9 @@
 package rl
 
 import (
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"time"
 
@@ -17,6 +19,7 @@ type DuelEnvironment struct {
 	config DuelRunConfig
 
 	manager   *unit.Manager
+	respawner *unit.Respawner
 	gameWorld world.World
 	shooterID int64
 	targetID  int64
@@ -30,6 +33,7 @@ type DuelEnvironment struct {
 	recentShooterMoveFailure bool
 	lastObservation          Observation
 	hasLastObservation       bool
+	commandRecorder          func(unit.Command)
 }
 
 // NewDuelEnvironment prepares one episode-scoped environment wrapper around the current duel
@@ -49,7 +53,8 @@ func (e *DuelEnvironment) Reset(seed int64) (Observation, error) {
 		Rows:     e.config.WorldRows,
 		TileSize: e.config.TileSize,
 	})
-	e.manager = unit.NewManager(e.gameWorld)
+	e.manager = unit.NewManagerWithWorkers(e.gameWorld, e.config.UpdateWorkers)
+	e.manager.SetCommandRecorder(e.commandRecorder)
 	e.tick = 0
 	e.targetMoveInFlight = false
 	e.nextTargetWaypoint = 0
@@ -64,9 +69,14 @@ func (e *DuelEnvironment) Reset(seed int64) (Observation, error) {
 	for _, staticUnit := range layout.StaticUnits {
 		e.manager.AddUnit(staticUnit)
 	}
-	e.shooterID = e.manager.AddUnit(unit.NewRunner(layout.ShooterSpawn, false, 0))
-	e.targetID = e.manager.AddUnit(unit.NewRunner(layout.TargetSpawn, true, 6))
+	shooterID, targetID, err := spawnDuelRunners(e.manager, layout)
+	if err != nil {
+		return Observation{}, err
+	}
+	e.shooterID = shooterID
+	e.targetID = targetID
 	e.targetWaypoints = append([]geom.Point(nil), layout.TargetWaypoints...)
+	e.attachRespawner(seed)
 
 	observation, err := e.Observe()
 	if err != nil {
@@ -77,6 +87,19 @@ func (e *DuelEnvironment) Reset(seed int64) (Observation, error) {
 	return observation, nil
 }
 
+// SetCommandRecorder installs a manager command recorder that survives Reset. The recorder is
+// attached before the spawn layout is seeded, so a recording covers the whole episode.
+func (e *DuelEnvironment) SetCommandRecorder(recorder func(unit.Command)) {
+	if e == nil {
+		return
+	}
+
+	e.commandRecorder = recorder
+	if e.manager != nil {
+		e.manager.SetCommandRecorder(recorder)
+	}
+}
+
 // Observe returns the current duel snapshot plus the environment metadata that policies use
 // for action generation.
 func (e *DuelEnvironment) Observe() (Observation, error) {
@@ -142,6 +165,7 @@ func (e *DuelEnvironment) Step() (StepResult, error) {
 	before := e.lastObservation
 
 	e.tick++
+	e.respawner.Update(e.tick)
 	e.issueTargetPatrolOrder()
 	e.manager.Update(e.tick)
 
@@ -161,13 +185,19 @@ func (e *DuelEnvironment) Step() (StepResult, error) {
 		e.lastObservation.Snapshot,
 		combatEvents,
 	)
+	if !afterSnapshot.Target.Alive {
+		// A dead target's patrol order is gone; the respawned target starts a fresh one.
+		e.targetMoveInFlight = false
+	}
 	reward := rewardForTick(e.shooterID, e.targetID, combatEvents)
-	done := !afterSnapshot.Shooter.Alive || !afterSnapshot.Target.Alive || e.tick >= e.config.MaxTicksPerEpisode
+	shooterOut := !afterSnapshot.Shooter.Alive && !e.livesLeft(e.shooterID, e.config.ShooterLives)
+	targetOut := !afterSnapshot.Target.Alive && !e.livesLeft(e.targetID, e.config.TargetLives)
+	done := shooterOut || targetOut || e.tick >= e.config.MaxTicksPerEpisode
 	outcome := "in_progress"
 	switch {
-	case !afterSnapshot.Target.Alive:
+	case targetOut:
 		outcome = "target_killed"
-	case !afterSnapshot.Shooter.Alive:
+	case shooterOut:
 		outcome = "shooter_killed"
 	case e.tick >= e.config.MaxTicksPerEpisode:
 		outcome = "timeout"
@@ -200,6 +230,30 @@ func (e *DuelEnvironment) Step() (StepResult, error) {
 	}, nil
 }
 
+// StateHash combines the manager simulation digest with the scripted target patrol state that
+// lives in the environment itself, so two runs that agree on this value will also issue the
+// same target orders on the next tick.
+func (e *DuelEnvironment) StateHash() uint64 {
+	if e == nil || e.manager == nil {
+		return 0
+	}
+
+	h := fnv.New64a()
+	var buf [8]byte
+	for _, value := range []uint64{
+		e.manager.StateHash(),
+		uint64(e.tick),
+		uint64(e.nextTargetWaypoint),
+		boolHashValue(e.targetMoveInFlight),
+		boolHashValue(e.recentShooterMoveFailure),
+		uint64(e.respawner.PendingRespawns()),
+	} {
+		binary.LittleEndian.PutUint64(buf[:], value)
+		_, _ = h.Write(buf[:])
+	}
+	return h.Sum64()
+}
+
 // Close releases the underlying unit manager worker pool so repeated episode generation does
 // not accumulate background goroutines.
 func (e *DuelEnvironment) Close() {
@@ -207,10 +261,36 @@ func (e *DuelEnvironment) Close() {
 		return
 	}
 
+	e.respawner.Close()
+	e.respawner = nil
 	e.manager.Close()
 	e.manager = nil
 }
 
+// attachRespawner gives each duellist's team the respawn policy for its lives. One-kill
+// episodes run without a respawner.
+func (e *DuelEnvironment) attachRespawner(seed int64) {
+	if e.config.ShooterLives <= 0 && e.config.TargetLives <= 0 {
+		return
+	}
+
+	e.respawner = unit.NewRespawner(e.manager, seed)
+	for team, lives := range map[unit.Team]int{duelTeamShooter: e.config.ShooterLives, duelTeamTarget: e.config.TargetLives} {
+		if lives > 0 {
+			e.respawner.SetTeamPolicy(team, unit.RespawnPolicy{
+				Mode:       unit.RespawnAtOrigin,
+				DelayTicks: e.config.RespawnDelayTicks,
+				Lives:      lives,
+			})
+		}
+	}
+}
+
+// livesLeft reports whether a dead duellist still has a respawn coming.
+func (e *DuelEnvironment) livesLeft(unitID int64, lives int) bool {
+	return lives > 0 && e.respawner.RespawnsUsed(unitID) < lives
+}
+
 func (e *DuelEnvironment) issueTargetPatrolOrder() {
 	if e == nil || e.manager == nil || e.targetMoveInFlight || len(e.targetWaypoints) == 0 {
 		return
@@ -233,3 +313,10 @@ func shooterMoveFailed(reports []unit.OrderReport) bool {
 	}
 	return false
 }
+
+func boolHashValue(value bool) uint64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -1,6 +1,7 @@
 package rl
 
 import (
+	"fmt"
 	"math/rand"
 
 	"github.com/unng-lab/endless/pkg/geom"
@@ -13,6 +14,15 @@ const (
 	DuelScenarioWithCover = "duel_with_cover"
 )
 
+// The duellists play on their own teams, which is what the respawn policies of multi-life
+// episodes key on.
+const (
+	duelTeamShooter unit.Team = "shooter"
+	duelTeamTarget  unit.Team = "target"
+
+	defaultDuelRespawnDelayTicks = 30
+)
+
 type duelScenarioLayout struct {
 	ShooterSpawn    geom.Point
 	TargetSpawn     geom.Point
@@ -20,6 +30,31 @@ type duelScenarioLayout struct {
 	StaticUnits     []unit.Unit
 }
 
+// spawnDuelRunners places the shooter and the focused target of the layout through the
+// validated manager spawn path, so a layout that puts either runner onto cover fails loudly
+// instead of starting an episode with a runner stuck inside a wall.
+func spawnDuelRunners(manager *unit.Manager, layout duelScenarioLayout) (int64, int64, error) {
+	shooterID, err := manager.SpawnUnit(unit.UnitSpec{
+		Kind:     unit.KindRunner,
+		Position: layout.ShooterSpawn,
+		Team:     duelTeamShooter,
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("spawn duel shooter: %w", err)
+	}
+	targetID, err := manager.SpawnUnit(unit.UnitSpec{
+		Kind:            unit.KindRunnerFocused,
+		Position:        layout.TargetSpawn,
+		Team:            duelTeamTarget,
+		AnimationOffset: 6,
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("spawn duel target: %w", err)
+	}
+
+	return shooterID, targetID, nil
+}
+
 func normalizedDuelScenarioName(name string) string {
 	switch name {
 	case "", DuelScenarioOpen:
@@ -0,0 +1,55 @@
+package rl
+
+import (
+	"github.com/unng-lab/endless/pkg/replay"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// RecordDuelReplay runs one duel episode with the named policy and returns its command-stream
+// replay. The scripted target patrol and every policy action reach the manager through the
+// public order API, so the replay reproduces the episode without the policy or the
+// environment being present during playback.
+func RecordDuelReplay(config DuelRunConfig, policyName string, episodeSeed int64) (replay.Replay, error) {
+	config = normalizedDuelRunConfig(config)
+	policy, err := NewPolicyByName(policyName, config.Seed)
+	if err != nil {
+		return replay.Replay{}, err
+	}
+
+	recorder := replay.NewRecorder(config.Scenario, episodeSeed, world.Config{
+		Columns:  config.WorldColumns,
+		Rows:     config.WorldRows,
+		TileSize: config.TileSize,
+	})
+	environment := NewDuelEnvironment(config)
+	environment.SetCommandRecorder(recorder.Record)
+	defer environment.Close()
+
+	before, err := environment.Reset(episodeSeed)
+	if err != nil {
+		return replay.Replay{}, err
+	}
+
+	for tick := int64(1); tick <= config.MaxTicksPerEpisode; tick++ {
+		_, _ = environment.ApplyAction(policy.ChooseAction(before))
+
+		stepResult, err := environment.Step()
+		if err != nil {
+			return replay.Replay{}, err
+		}
+		recorder.MarkTick(tick)
+
+		before = stepResult.After
+		if stepResult.Done {
+			break
+		}
+	}
+
+	return recorder.Replay(), nil
+}
+
+// FirstDuelEpisodeSeed resolves the seed of the first episode that collection and evaluation
+// derive from one session seed, so launchers can record the same episode a suite would run.
+func FirstDuelEpisodeSeed(sessionSeed int64) int64 {
+	return generateDuelEvaluationEpisodeSeeds(sessionSeed, 1)[0]
+}
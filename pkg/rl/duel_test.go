@@ -3,6 +3,8 @@ package rl
 import (
 	"context"
 	"testing"
+
+	"github.com/unng-lab/endless/pkg/unit"
 )
 
 type memoryRecorder struct {
@@ -372,3 +374,56 @@ func TestRunDuelEvaluationAggregatesEpisodes(t *testing.T) {
 		t.Fatalf("invalid negative combat counters: %+v", summary)
 	}
 }
+
+// TestDuelEnvironmentTargetLivesRespawnTargetUntilTheLastDeath verifies that a multi-life
+// episode brings the killed target back on its team policy and ends on the death that spends
+// its last life.
+func TestDuelEnvironmentTargetLivesRespawnTargetUntilTheLastDeath(t *testing.T) {
+	environment := NewDuelEnvironment(DuelRunConfig{
+		Episodes:           1,
+		MaxTicksPerEpisode: 60,
+		WorldColumns:       64,
+		WorldRows:          64,
+		TileSize:           16,
+		TargetLives:        1,
+		RespawnDelayTicks:  3,
+	})
+	defer environment.Close()
+
+	observation, err := environment.Reset(11)
+	if err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	spawn := observation.Snapshot.Target.Position
+	if err := environment.manager.RemoveUnit(environment.targetID, unit.RemovalReasonKilled); err != nil {
+		t.Fatalf("RemoveUnit() error = %v", err)
+	}
+
+	for tick := 1; tick < 3; tick++ {
+		result, err := environment.Step()
+		if err != nil {
+			t.Fatalf("Step() error = %v", err)
+		}
+		if result.Done || result.After.Snapshot.Target.Alive {
+			t.Fatalf("tick %d: done %t, target alive %t, want the target waiting for its respawn", tick, result.Done, result.After.Snapshot.Target.Alive)
+		}
+	}
+	result, err := environment.Step()
+	if err != nil {
+		t.Fatalf("Step() error = %v", err)
+	}
+	if !result.After.Snapshot.Target.Alive || result.After.Snapshot.Target.Position != spawn {
+		t.Fatalf("target after its delay = %+v, want it alive at %+v", result.After.Snapshot.Target, spawn)
+	}
+
+	if err := environment.manager.RemoveUnit(environment.targetID, unit.RemovalReasonKilled); err != nil {
+		t.Fatalf("RemoveUnit() error = %v", err)
+	}
+	result, err = environment.Step()
+	if err != nil {
+		t.Fatalf("Step() error = %v", err)
+	}
+	if !result.Done || result.Outcome != "target_killed" {
+		t.Fatalf("second death: done %t, outcome %q, want target_killed", result.Done, result.Outcome)
+	}
+}
@@ -26,6 +26,15 @@ type Action struct {
 	FireDirection geom.Point
 }
 
+// ScoredAction is one candidate a runtime policy evaluated, with the value its model predicted.
+// Scored is false when the candidate could not be vectorized or the model rejected it, in which
+// case Value carries no meaning.
+type ScoredAction struct {
+	Action Action
+	Value  float32
+	Scored bool
+}
+
 const duelObservationPatchRadius = 2
 
 // ProjectileFeature keeps one compact nearest-projectile descriptor relative to the shooter.
@@ -29,6 +29,7 @@ type GoMLXCriticRuntimePolicy struct {
 	fallback Policy
 
 	lastDecisionDebug string
+	lastCandidates    []ScoredAction
 }
 
 type goMLXCriticRuntimeArtifact struct {
@@ -549,6 +550,7 @@ func (p *GoMLXCriticRuntimePolicy) ChooseAction(observation Observation) Action
 	if p == nil {
 		return Action{Type: ActionTypeNone}
 	}
+	p.lastCandidates = p.lastCandidates[:0]
 
 	spec := p.artifact.Manifest.NormalizationSpec.Normalized()
 	obsVector, err := vectorizeRuntimeObservation(observation, spec)
@@ -569,6 +571,7 @@ func (p *GoMLXCriticRuntimePolicy) ChooseAction(observation Observation) Action
 	for _, candidate := range candidates {
 		actionVector, err := vectorizeRuntimeAction(spec, candidate, true)
 		if err != nil {
+			p.lastCandidates = append(p.lastCandidates, ScoredAction{Action: candidate})
 			continue
 		}
 
@@ -577,8 +580,10 @@ func (p *GoMLXCriticRuntimePolicy) ChooseAction(observation Observation) Action
 		input = append(input, actionVector...)
 		score, err := p.artifact.Model.Predict(input)
 		if err != nil {
+			p.lastCandidates = append(p.lastCandidates, ScoredAction{Action: candidate})
 			continue
 		}
+		p.lastCandidates = append(p.lastCandidates, ScoredAction{Action: candidate, Value: score, Scored: true})
 		successfulCandidates++
 		if score > bestScore {
 			bestScore = score
@@ -609,6 +614,16 @@ func (p *GoMLXCriticRuntimePolicy) LastDecisionDebugText() string {
 	return p.lastDecisionDebug
 }
 
+// LastDecisionCandidates returns every candidate of the last scoring pass with its predicted
+// value, in evaluation order, or nil when the pass fell back to the scripted policy before any
+// candidate was scored.
+func (p *GoMLXCriticRuntimePolicy) LastDecisionCandidates() []ScoredAction {
+	if p == nil || len(p.lastCandidates) == 0 {
+		return nil
+	}
+	return append([]ScoredAction(nil), p.lastCandidates...)
+}
+
 func (p *GoMLXCriticRuntimePolicy) fallbackAction(observation Observation) Action {
 	if p == nil || p.fallback == nil {
 		return Action{Type: ActionTypeNone}
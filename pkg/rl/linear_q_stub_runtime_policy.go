@@ -14,6 +14,7 @@ type LinearQStubRuntimePolicy struct {
 	fallback Policy
 
 	lastDecisionDebug string
+	lastCandidates    []ScoredAction
 }
 
 // LoadLinearQStubRuntimePolicy restores one saved stub model from disk and prepares it for
@@ -47,6 +48,7 @@ func (p *LinearQStubRuntimePolicy) ChooseAction(observation Observation) Action
 	if p == nil {
 		return Action{Type: ActionTypeNone}
 	}
+	p.lastCandidates = p.lastCandidates[:0]
 
 	spec := p.artifact.NormalizationSpec.Normalized()
 	obsVector, err := vectorizeRuntimeObservation(observation, spec)
@@ -67,13 +69,16 @@ func (p *LinearQStubRuntimePolicy) ChooseAction(observation Observation) Action
 	for _, candidate := range candidates {
 		actionVector, err := vectorizeRuntimeAction(spec, candidate, true)
 		if err != nil {
+			p.lastCandidates = append(p.lastCandidates, ScoredAction{Action: candidate})
 			continue
 		}
 
 		score, err := p.artifact.Model.Predict(obsVector, actionVector)
 		if err != nil {
+			p.lastCandidates = append(p.lastCandidates, ScoredAction{Action: candidate})
 			continue
 		}
+		p.lastCandidates = append(p.lastCandidates, ScoredAction{Action: candidate, Value: score, Scored: true})
 		successfulCandidates++
 		if score > bestScore {
 			bestScore = score
@@ -104,6 +109,16 @@ func (p *LinearQStubRuntimePolicy) LastDecisionDebugText() string {
 	return p.lastDecisionDebug
 }
 
+// LastDecisionCandidates returns every candidate of the last scoring pass with its predicted
+// value, in evaluation order, or nil when the pass fell back to the scripted policy before any
+// candidate was scored.
+func (p *LinearQStubRuntimePolicy) LastDecisionCandidates() []ScoredAction {
+	if p == nil || len(p.lastCandidates) == 0 {
+		return nil
+	}
+	return append([]ScoredAction(nil), p.lastCandidates...)
+}
+
 func (p *LinearQStubRuntimePolicy) fallbackAction(observation Observation) Action {
 	if p == nil || p.fallback == nil {
 		return Action{Type: ActionTypeNone}
@@ -18,6 +18,19 @@ const (
 	occupancyMovementBlocker int16 = 5
 )
 
+// Occupancy codes of Observation.LocalOccupancyPatch, for tools that draw the patch. Out-of-world
+// cells are OccupancyUnknown; a tile holding several bodies reports the first code in the order
+// shooter, target, movement blocker, hostile shot, friendly shot.
+const (
+	OccupancyUnknown         = occupancyUnknown
+	OccupancyEmpty           = occupancyEmpty
+	OccupancyShooter         = occupancyShooter
+	OccupancyTarget          = occupancyTarget
+	OccupancyFriendlyShot    = occupancyFriendlyShot
+	OccupancyHostileShot     = occupancyHostileShot
+	OccupancyMovementBlocker = occupancyMovementBlocker
+)
+
 func buildObservation(
 	gameWorld world.World,
 	snapshot unit.DuelSnapshot,
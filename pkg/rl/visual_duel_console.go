@@ -0,0 +1,74 @@
+package rl
+
+import (
+	"fmt"
+
+	"github.com/unng-lab/endless/pkg/endless/console"
+)
+
+// LoadPolicy swaps the duel policy for a runtime model loaded from path, as the -model-path
+// flag would at startup. The next tick decides with the new model; orders already issued by
+// the old one run to completion. A failed load keeps the current policy.
+func (s *VisualDuelScenario) LoadPolicy(path string) (string, error) {
+	policy, label, err := LoadRuntimePolicyFromPath(path)
+	if err != nil {
+		return "", fmt.Errorf("load runtime policy: %w", err)
+	}
+	s.setPolicy(policy, label)
+	return label, nil
+}
+
+// UsePolicy swaps the duel policy for one of the scripted policies known to NewPolicyByName,
+// seeded with the scenario seed.
+func (s *VisualDuelScenario) UsePolicy(name string) (string, error) {
+	policy, err := NewPolicyByName(name, s.config.Seed)
+	if err != nil {
+		return "", err
+	}
+	label := normalizedPolicyName(name)
+	s.setPolicy(policy, label)
+	return label, nil
+}
+
+func (s *VisualDuelScenario) setPolicy(policy Policy, label string) {
+	s.policy = policy
+	s.runtimePolicyLabel = label
+	s.lastPolicyDebug = ""
+	s.lastCandidates = nil
+}
+
+// ConsoleCommands adds the policy commands of the duel to the developer console.
+func (s *VisualDuelScenario) ConsoleCommands() []console.Command {
+	return []console.Command{
+		{
+			Name:  "policy load",
+			Usage: "policy load <path>",
+			Help:  "drive the shooter with a runtime model file",
+			Run: func(args []string) (string, error) {
+				if len(args) != 1 {
+					return "", fmt.Errorf("usage: policy load <path>")
+				}
+				label, err := s.LoadPolicy(args[0])
+				if err != nil {
+					return "", err
+				}
+				return "policy " + label, nil
+			},
+		},
+		{
+			Name:  "policy use",
+			Usage: "policy use <" + PolicyLeadAndStrafe + "|" + PolicyRandom + ">",
+			Help:  "drive the shooter with a scripted policy",
+			Run: func(args []string) (string, error) {
+				if len(args) != 1 {
+					return "", fmt.Errorf("usage: policy use <name>")
+				}
+				label, err := s.UsePolicy(args[0])
+				if err != nil {
+					return "", err
+				}
+				return "policy " + label, nil
+			},
+		},
+	}
+}
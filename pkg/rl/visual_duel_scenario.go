@@ -2,6 +2,7 @@ package rl
 
 import (
 	"fmt"
+	"log"
 	"math"
 	"math/rand"
 
@@ -44,6 +45,7 @@ type VisualDuelScenario struct {
 	lastObservation      Observation
 	hasLastObservation   bool
 	lastPolicyDebug      string
+	lastCandidates       []ScoredAction
 	done                 bool
 	spawnedUnits         int
 	staticObjects        int
@@ -110,8 +112,15 @@ func (s *VisualDuelScenario) SeedUnits(manager *unit.Manager) {
 		s.staticObjects++
 	}
 
-	s.shooterID = manager.AddUnit(unit.NewRunner(layout.ShooterSpawn, false, 0))
-	s.targetID = manager.AddUnit(unit.NewRunner(layout.TargetSpawn, true, 6))
+	shooterID, targetID, err := spawnDuelRunners(manager, layout)
+	if err != nil {
+		log.Printf("[duel] seed units failed: %v", err)
+		s.lastOutcome = "spawn_failed"
+		s.done = true
+		return
+	}
+	s.shooterID = shooterID
+	s.targetID = targetID
 	s.targetWaypoints = append([]geom.Point(nil), layout.TargetWaypoints...)
 	s.spawnedUnits = 2
 	s.lastOutcome = "in_progress"
@@ -120,6 +129,7 @@ func (s *VisualDuelScenario) SeedUnits(manager *unit.Manager) {
 	s.lastObservation = Observation{}
 	s.hasLastObservation = false
 	s.lastPolicyDebug = ""
+	s.lastCandidates = nil
 	s.done = false
 	manager.SelectUnitByID(s.shooterID)
 }
@@ -162,6 +172,7 @@ func (s *VisualDuelScenario) Update(gameTick int64, manager *unit.Manager) {
 
 	action := s.policy.ChooseAction(observation)
 	s.lastPolicyDebug = policyDebugText(s.policy)
+	s.lastCandidates = policyDecisionCandidates(s.policy)
 	actionAccepted := s.applyAction(manager, action)
 	s.lastAction = action
 	s.lastActionAccepted = actionAccepted
@@ -171,6 +182,44 @@ func (s *VisualDuelScenario) Update(gameTick int64, manager *unit.Manager) {
 	s.hasPreviousTargetPos = observation.Snapshot.Target.Alive
 }
 
+// CameraSubjects names the shooter and the target so the desktop camera can keep both in frame.
+func (s *VisualDuelScenario) CameraSubjects() []int64 {
+	subjects := make([]int64, 0, 2)
+	for _, unitID := range []int64{s.shooterID, s.targetID} {
+		if unitID != 0 {
+			subjects = append(subjects, unitID)
+		}
+	}
+	return subjects
+}
+
+// PolicyInspection is what the desktop overlay draws to explain one duel decision: the
+// observation the policy saw and, for learned policies, every candidate it scored. Scripted
+// policies score nothing, so Candidates stays empty for them.
+type PolicyInspection struct {
+	Observation Observation
+	Candidates  []ScoredAction
+	Chosen      Action
+}
+
+// IsChosen reports whether the candidate is the action the policy returned.
+func (i PolicyInspection) IsChosen(candidate Action) bool {
+	return actionKey(candidate) == actionKey(i.Chosen)
+}
+
+// PolicyInspection returns the last decision, or false before the first observation.
+func (s *VisualDuelScenario) PolicyInspection() (PolicyInspection, bool) {
+	if s == nil || !s.hasLastObservation {
+		return PolicyInspection{}, false
+	}
+
+	return PolicyInspection{
+		Observation: s.lastObservation,
+		Candidates:  append([]ScoredAction(nil), s.lastCandidates...),
+		Chosen:      s.lastAction,
+	}, true
+}
+
 // DebugText exposes the current duel state and the last chosen action in the on-screen overlay.
 func (s *VisualDuelScenario) DebugText() string {
 	if s == nil {
@@ -209,6 +258,30 @@ func (s *VisualDuelScenario) DebugText() string {
 	)
 }
 
+// Finished reports whether the duel has reached an outcome and stopped issuing actions.
+func (s *VisualDuelScenario) Finished() bool {
+	return s == nil || s.done
+}
+
+// Counters reports the duel inventory and whether the episode has reached an outcome, so
+// headless runs of the visual scene can tell a finished duel from one that is still going.
+func (s *VisualDuelScenario) Counters() map[string]int64 {
+	if s == nil {
+		return nil
+	}
+
+	done := int64(0)
+	if s.done {
+		done = 1
+	}
+	return map[string]int64{
+		"spawned_units":  int64(s.spawnedUnits),
+		"static_objects": int64(s.staticObjects),
+		"last_tick":      s.lastTick,
+		"done":           done,
+	}
+}
+
 func (s *VisualDuelScenario) observe(manager *unit.Manager) (Observation, error) {
 	if s == nil || manager == nil {
 		return Observation{}, fmt.Errorf("visual duel scenario is not initialized")
@@ -285,6 +358,17 @@ func policyDebugText(policy Policy) string {
 	return ""
 }
 
+type runtimeDecisionCandidateProvider interface {
+	LastDecisionCandidates() []ScoredAction
+}
+
+func policyDecisionCandidates(policy Policy) []ScoredAction {
+	if provider, ok := policy.(runtimeDecisionCandidateProvider); ok {
+		return provider.LastDecisionCandidates()
+	}
+	return nil
+}
+
 func composeVisualPolicyDebugText(policyDebug string, staticObjects int) string {
 	if policyDebug == "" {
 		return fmt.Sprintf("policy_debug: unavailable  static %d", staticObjects)
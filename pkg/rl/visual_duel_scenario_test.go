@@ -0,0 +1,87 @@
+package rl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/endless/console"
+	"github.com/unng-lab/endless/pkg/unit"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+func TestVisualDuelPolicyInspectionReportsScoredCandidates(t *testing.T) {
+	spec := DefaultTransitionNormalizationSpec()
+	model, err := NewLinearQStubModel(spec.ObservationDim(), spec.ActionDim())
+	if err != nil {
+		t.Fatalf("NewLinearQStubModel() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "linear_q_stub_artifact.json")
+	if err := SaveLinearQStubArtifact(path, spec, model); err != nil {
+		t.Fatalf("SaveLinearQStubArtifact() error = %v", err)
+	}
+
+	gameWorld := world.New(world.Config{Columns: 64, Rows: 64, TileSize: 16})
+	scenario, err := NewVisualDuelScenario(gameWorld, VisualDuelScenarioConfig{Seed: 3, ModelPath: path})
+	if err != nil {
+		t.Fatalf("NewVisualDuelScenario() error = %v", err)
+	}
+	if _, ok := scenario.PolicyInspection(); ok {
+		t.Fatal("PolicyInspection() ok = true before the first decision")
+	}
+
+	manager := unit.NewManager(gameWorld)
+	defer manager.Close()
+	scenario.SeedUnits(manager)
+	scenario.Update(1, manager)
+
+	inspection, ok := scenario.PolicyInspection()
+	if !ok || len(inspection.Candidates) == 0 {
+		t.Fatalf("PolicyInspection() = %+v, %v, want the scored candidates", inspection, ok)
+	}
+	if len(inspection.Observation.LocalOccupancyPatch) == 0 {
+		t.Fatal("inspection observation has no occupancy patch")
+	}
+	chosen := 0
+	for _, candidate := range inspection.Candidates {
+		if !candidate.Scored {
+			t.Fatalf("candidate %+v was not scored", candidate)
+		}
+		if inspection.IsChosen(candidate.Action) {
+			chosen++
+		}
+	}
+	if chosen != 1 {
+		t.Fatalf("chosen candidates = %d, want exactly one of %+v", chosen, inspection.Candidates)
+	}
+}
+
+// TestVisualDuelConsoleCommandsSwapThePolicy runs the duel's console commands the way the
+// client registry would and checks that they reach the scenario.
+func TestVisualDuelConsoleCommandsSwapThePolicy(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 64, Rows: 64, TileSize: 16})
+	scenario, err := NewVisualDuelScenario(gameWorld, VisualDuelScenarioConfig{Seed: 3})
+	if err != nil {
+		t.Fatalf("NewVisualDuelScenario() error = %v", err)
+	}
+
+	commands := make(map[string]console.Command)
+	for _, command := range scenario.ConsoleCommands() {
+		commands[command.Name] = command
+	}
+	use, ok := commands["policy use"]
+	if !ok {
+		t.Fatalf("ConsoleCommands() = %v, want policy use", commands)
+	}
+	if output, err := use.Run([]string{PolicyRandom}); err != nil || output != "policy "+PolicyRandom {
+		t.Fatalf("policy use %s = %q, %v", PolicyRandom, output, err)
+	}
+	if scenario.runtimePolicyLabel != PolicyRandom {
+		t.Fatalf("policy label = %q, want %q", scenario.runtimePolicyLabel, PolicyRandom)
+	}
+	if _, err := commands["policy load"].Run([]string{filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+		t.Fatal("policy load of a missing file error = nil")
+	}
+	if scenario.runtimePolicyLabel != PolicyRandom {
+		t.Fatalf("policy label after a failed load = %q, want %q kept", scenario.runtimePolicyLabel, PolicyRandom)
+	}
+}
@@ -1,9 +1,21 @@
 package unit
 
-// Animation describes a looped sprite animation.
+import (
+	"math"
+
+	"github.com/unng-lab/endless/pkg/assets"
+)
+
+// hitFlashTicks is how long a unit shows its hit reaction when its sprite has no hit clip to
+// time it.
+const hitFlashTicks = 12
+
+// Animation describes a sprite animation. Looping animations wrap around; HoldLast ones stop on
+// their last frame.
 type Animation struct {
 	FrameCount int
 	FrameTicks int
+	HoldLast   bool
 }
 
 // frameAt resolves the current sprite frame from a pure tick counter so animation timing stays
@@ -18,5 +30,154 @@ func (a Animation) frameAt(animationTicks int) int {
 		frameTicks = 1
 	}
 
+	if a.HoldLast {
+		return min(animationTicks/frameTicks, a.FrameCount-1)
+	}
 	return (animationTicks / frameTicks) % a.FrameCount
 }
+
+// kindAnimation returns the timing of one clip of the kind's sprite in the asset manifest. Kinds
+// without that clip get the zero Animation, which always shows the first frame.
+func kindAnimation(kind Kind, clip string) Animation {
+	frames, ok := kindClip(kind, clip)
+	if !ok {
+		return Animation{}
+	}
+
+	return Animation{FrameCount: frames.Len(), FrameTicks: frames.FrameTicks, HoldLast: frames.Hold}
+}
+
+func kindClip(kind Kind, clip string) (assets.Clip, bool) {
+	_, _, sprite := kindVisuals(kind)
+	if sprite == nil {
+		return assets.Clip{}, false
+	}
+	frames, ok := sprite.Clips[clip]
+	return frames, ok
+}
+
+// AnimationState is what a unit is visibly doing. The renderer picks the clip from it, so a
+// reviewer can read intent from the sprite: a unit winding up a shot looks different from one
+// that is merely standing, and a hit unit reacts before it recovers.
+type AnimationState uint8
+
+const (
+	AnimationIdle AnimationState = iota
+	AnimationRun
+	AnimationFireWindup
+	AnimationHit
+	AnimationDeath
+)
+
+var animationStateNames = [...]string{
+	AnimationIdle:       "idle",
+	AnimationRun:        "run",
+	AnimationFireWindup: "fire_windup",
+	AnimationHit:        "hit",
+	AnimationDeath:      "death",
+}
+
+func (s AnimationState) String() string {
+	if int(s) < len(animationStateNames) {
+		return animationStateNames[s]
+	}
+	return "unknown"
+}
+
+// animationStateClips lists the clips of each state in order of preference. Sheets rarely draw
+// every state, so a missing clip falls back to the nearest one the sheet has; the run clip every
+// unit sprite provides ends each list.
+var animationStateClips = [...][]string{
+	AnimationIdle:       {assets.ClipIdle, assets.ClipRun},
+	AnimationRun:        {assets.ClipRun},
+	AnimationFireWindup: {assets.ClipFire, assets.ClipIdle, assets.ClipRun},
+	AnimationHit:        {assets.ClipHit, assets.ClipIdle, assets.ClipRun},
+	AnimationDeath:      {assets.ClipDeath, assets.ClipIdle, assets.ClipRun},
+}
+
+// stateClip resolves the clip a kind plays for the state, after fallbacks, and its timing.
+func stateClip(kind Kind, state AnimationState) (string, Animation) {
+	for _, clip := range animationStateClips[state] {
+		if _, ok := kindClip(kind, clip); ok {
+			return clip, kindAnimation(kind, clip)
+		}
+	}
+	return assets.ClipRun, Animation{}
+}
+
+// hitReactionTicks is how long the hit state lasts: one pass of the hit clip, or the flash
+// duration for sheets without one.
+func hitReactionTicks(kind Kind) int64 {
+	if clip, ok := kindClip(kind, assets.ClipHit); ok {
+		return int64(clip.Ticks())
+	}
+	return hitFlashTicks
+}
+
+// animationStateAt derives the state from the simulation. Death wins over everything, then the
+// fire windup, which the player must be able to read, then a fresh hit, then movement.
+func (u *NonStaticUnit) animationStateAt(gameTick int64) AnimationState {
+	switch {
+	case !u.Alive():
+		return AnimationDeath
+	case u.activeOrder.hasOrder && u.activeOrder.order.kind == OrderKindFire && u.activeOrder.releasing:
+		return AnimationFireWindup
+	case u.lastHitTick > 0 && gameTick-u.lastHitTick < hitReactionTicks(u.Kind):
+		return AnimationHit
+	case u.IsMoving():
+		return AnimationRun
+	default:
+		return AnimationIdle
+	}
+}
+
+// updateAnimation advances the draw-only animation state by one visible tick and turns the unit
+// towards where it travels or, while winding up a shot, towards where it aims.
+func (u *NonStaticUnit) updateAnimation(gameTick int64) {
+	u.animationTicks++
+	state := u.animationStateAt(gameTick)
+	if state != u.animationState {
+		u.animationState = state
+		u.stateTicks = 0
+	} else {
+		u.stateTicks++
+	}
+
+	var dx, dy float64
+	switch state {
+	case AnimationFireWindup:
+		dx, dy = u.activeOrder.order.direction.X, u.activeOrder.order.direction.Y
+	case AnimationRun:
+		travel := u.travelRef()
+		if travel.active {
+			dx, dy = travel.to.X-travel.from.X, travel.to.Y-travel.from.Y
+		}
+		if math.Abs(dx) < 1e-9 && math.Abs(dy) < 1e-9 && len(u.path) > 0 {
+			dx, dy = u.path[0].X-u.Position.X, u.path[0].Y-u.Position.Y
+		}
+	}
+	if facing, ok := assets.DirectionToward(dx, dy); ok {
+		u.facing = facing
+	}
+}
+
+// AnimationState returns the state the unit was last drawn in.
+func (u *NonStaticUnit) AnimationState() AnimationState {
+	return u.animationState
+}
+
+// Facing returns the direction the sprite faces.
+func (u *NonStaticUnit) Facing() assets.Direction {
+	return u.facing
+}
+
+// AnimationClip returns the clip the current state plays and the frame within it. Looping
+// clips run on the unit's staggered animation counter so a crowd does not step in unison;
+// one-shot clips start from their first frame when the state begins.
+func (u *NonStaticUnit) AnimationClip() (string, int) {
+	clip, animation := stateClip(u.Kind, u.animationState)
+	if animation.HoldLast {
+		return clip, animation.frameAt(u.stateTicks)
+	}
+	return clip, animation.frameAt(u.animationTicks)
+}
@@ -1,8 +1,10 @@
 package unit
 
 import (
+	"cmp"
 	"fmt"
 	"math"
+	"slices"
 
 	"github.com/unng-lab/endless/pkg/geom"
 	"github.com/unng-lab/endless/pkg/world"
@@ -152,12 +154,12 @@ func (p *Projectile) Tick(gameTick int64) {
 		return
 	}
 
-	if p.sleepTime > 0 {
+	if p.sleepTimeValue() > 0 {
 		return
 	}
 
-	p.sleepTime = p.advance()
-	p.travel.remaining = p.sleepTime
+	p.setSleepTime(p.advance())
+	p.travelRef().remaining = p.sleepTimeValue()
 	if !p.IsActive() {
 		p.MarkForRemoval()
 	}
@@ -182,7 +184,8 @@ func (p *Projectile) ShouldUpdate() bool {
 // ReactToEnteredTile resolves projectile impacts at the exact point where the manager has
 // already registered the projectile inside the newly entered tile. Running the hit test here
 // keeps the projectile lifecycle local to the projectile while reusing the same tile-entry
-// event that every moving unit already goes through.
+// event that every moving unit already goes through. The target usually belongs to another
+// worker, so the damage is only queued here and applied once the workers have joined.
 func (p *Projectile) ReactToEnteredTile(m *Manager, stack *TileStack) {
 	if p == nil || p.exploding || m == nil {
 		return
@@ -193,34 +196,76 @@ func (p *Projectile) ReactToEnteredTile(m *Manager, stack *TileStack) {
 		return
 	}
 
-	if target.ApplyDamage(p.Damage) {
-		if targetUnit := target.Base(); targetUnit != nil {
+	m.queueProjectileHit(p, target)
+	p.hitOccurred = true
+	p.StartExplosion()
+}
+
+// projectileHit is one impact found by a projectile's worker and waiting for the post-tick
+// phase. The position is the impact point, taken before the projectile starts exploding.
+type projectileHit struct {
+	projectile *Projectile
+	target     Unit
+	position   geom.Point
+}
+
+func (m *Manager) queueProjectileHit(projectile *Projectile, target Unit) {
+	m.pendingHitsMu.Lock()
+	m.pendingHits = append(m.pendingHits, projectileHit{
+		projectile: projectile,
+		target:     target,
+		position:   projectile.Position,
+	})
+	m.pendingHitsMu.Unlock()
+}
+
+// applyPendingHits damages the targets of the impacts queued during the step. It runs on the
+// goroutine that drives the manager after the workers joined, so every target is settled and
+// retired by exactly one goroutine, and the hit reaction and corpse read a target whose own
+// visit has finished. Hits are applied in projectile ID order, which keeps the result
+// independent of which worker found its hit first.
+func (m *Manager) applyPendingHits() {
+	hits := m.pendingHits
+	if len(hits) == 0 {
+		return
+	}
+	slices.SortFunc(hits, func(a, b projectileHit) int {
+		return cmp.Compare(a.projectile.UnitID(), b.projectile.UnitID())
+	})
+
+	for _, hit := range hits {
+		p, target := hit.projectile, hit.target
+		if body, ok := target.(*NonStaticUnit); ok {
+			body.lastHitTick = m.lastGameTick
+		}
+		if target.ApplyDamage(p.Damage) {
+			m.recordCorpse(target)
 			m.appendCombatEvent(CombatEvent{
 				Tick:             m.lastGameTick,
 				Type:             CombatEventUnitKilled,
 				SourceUnitID:     p.OwnerID,
 				TargetUnitID:     target.UnitID(),
 				ProjectileUnitID: p.UnitID(),
-				Position:         targetUnit.Position,
+				Position:         target.Base().Position,
 				Damage:           p.Damage,
 				Killed:           true,
 			})
+			m.retireDeletedUnit(target)
 		}
-		m.retireDeletedUnit(target)
-	}
 
-	p.hitOccurred = true
-	m.appendCombatEvent(CombatEvent{
-		Tick:             m.lastGameTick,
-		Type:             CombatEventProjectileHit,
-		SourceUnitID:     p.OwnerID,
-		TargetUnitID:     target.UnitID(),
-		ProjectileUnitID: p.UnitID(),
-		Position:         p.Position,
-		Damage:           p.Damage,
-		Killed:           !target.Alive(),
-	})
-	p.StartExplosion()
+		m.appendCombatEvent(CombatEvent{
+			Tick:             m.lastGameTick,
+			Type:             CombatEventProjectileHit,
+			SourceUnitID:     p.OwnerID,
+			TargetUnitID:     target.UnitID(),
+			ProjectileUnitID: p.UnitID(),
+			Position:         hit.position,
+			Damage:           p.Damage,
+			Killed:           !target.Alive(),
+		})
+	}
+	clear(hits)
+	m.pendingHits = hits[:0]
 }
 
 // IsActive reports whether the projectile still has either a future waypoint to traverse or
@@ -230,7 +275,7 @@ func (p *Projectile) IsActive() bool {
 		return p.impactTicks < p.impactDurationTicks
 	}
 
-	return len(p.path) > 0 || p.sleepTime > 0
+	return len(p.path) > 0 || p.sleepTimeValue() > 0
 }
 
 // StartExplosion freezes the projectile at the impact point and reuses the same runtime object
@@ -243,7 +288,7 @@ func (p *Projectile) StartExplosion() {
 	p.exploding = true
 	p.impactTicks = 0
 	p.path = p.path[:0]
-	p.sleepTime = 0
+	p.setSleepTime(0)
 	p.clearTravel()
 }
 
@@ -272,7 +317,7 @@ func (p *Projectile) startTravel(target geom.Point) int {
 	distance := math.Hypot(dx, dy)
 	travelTicks := travelTicksForDistance(distance, projectileSpeedPerTick)
 
-	p.travel = travelState{
+	*p.travelRef() = travelState{
 		from:            p.RenderPosition(),
 		to:              target,
 		duration:        travelTicks,
@@ -280,7 +325,7 @@ func (p *Projectile) startTravel(target geom.Point) int {
 		visualRemaining: travelTicks,
 		active:          true,
 	}
-	p.Position = target
+	p.setPosition(target)
 	p.path = p.path[1:]
 
 	return travelTicks
@@ -0,0 +1,176 @@
+package unit
+
+import (
+	"fmt"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// CommandType identifies which public manager mutation one recorded Command reproduces.
+type CommandType string
+
+const (
+	CommandAddUnit      CommandType = "add_unit"
+	CommandMoveOrder    CommandType = "move_order"
+	CommandFireOrder    CommandType = "fire_order"
+	CommandRemoveUnit   CommandType = "remove_unit"
+	CommandTeleportUnit CommandType = "teleport_unit"
+	CommandStopOrder    CommandType = "stop_order"
+	CommandHoldOrder    CommandType = "hold_order"
+)
+
+// Command is one externally issued manager mutation. Tick stores the last completed Update
+// tick at the moment of the call, so replaying every command with Tick == t right after
+// Update(t) restores the original interleaving of commands and simulation steps. Point holds
+// the move target, the fire direction or the teleport destination depending on Type, Unit is
+// set only for spawns, Reason only for removals and Hold only for hold orders.
+type Command struct {
+	Tick   int64         `json:"tick"`
+	Type   CommandType   `json:"type"`
+	UnitID int64         `json:"unit_id,omitempty"`
+	Point  geom.Point    `json:"point"`
+	Unit   *UnitSpec     `json:"unit,omitempty"`
+	Reason RemovalReason `json:"reason,omitempty"`
+	Hold   bool          `json:"hold,omitempty"`
+}
+
+// UnitSpec is the serializable description of one externally spawned unit. It keeps only the
+// fields a constructor cannot derive from the kind, which is enough to rebuild scenario spawns
+// because every scenario creates units through the stock constructors.
+type UnitSpec struct {
+	ID              int64      `json:"id"`
+	Kind            Kind       `json:"kind"`
+	Position        geom.Point `json:"position"`
+	SpawnPosition   geom.Point `json:"spawn_position"`
+	Team            Team       `json:"team,omitempty"`
+	Health          int        `json:"health"`
+	MaxHealth       int        `json:"max_health"`
+	AnimationOffset int        `json:"animation_offset,omitempty"`
+}
+
+// SpecForUnit captures the spawn description of a unit that is about to be registered.
+// Projectiles are reported as unsupported because they are created only by fire orders inside
+// the simulation and therefore never appear in the external command stream.
+func SpecForUnit(body Unit) (UnitSpec, bool) {
+	switch current := body.(type) {
+	case *NonStaticUnit:
+		return UnitSpec{
+			ID:              current.ID,
+			Kind:            current.Kind,
+			Position:        current.Position,
+			SpawnPosition:   current.SpawnPosition,
+			Team:            current.Team,
+			Health:          current.Health,
+			MaxHealth:       current.MaxHealth,
+			AnimationOffset: current.animationTicks,
+		}, true
+	case *StaticUnit:
+		return UnitSpec{
+			ID:            current.ID,
+			Kind:          current.Kind,
+			Position:      current.Position,
+			SpawnPosition: current.SpawnPosition,
+			Team:          current.Team,
+			Health:        current.Health,
+			MaxHealth:     current.MaxHealth,
+		}, true
+	default:
+		return UnitSpec{}, false
+	}
+}
+
+// NewUnitFromSpec rebuilds a unit through the stock constructor for its kind and then applies
+// the recorded overrides, so replayed spawns pick up the same runtime defaults as live ones.
+// Zero overrides keep the constructor values, which lets hand-written specs for SpawnUnit name
+// only the kind and position.
+func NewUnitFromSpec(spec UnitSpec) (Unit, error) {
+	switch spec.Kind {
+	case KindRunner, KindRunnerFocused:
+		runner := NewRunner(spec.Position, spec.Kind == KindRunnerFocused, spec.AnimationOffset)
+		runner.ID = spec.ID
+		runner.Team = spec.Team
+		applySpecOverrides(spec, &runner.SpawnPosition, &runner.Health, &runner.MaxHealth)
+		return runner, nil
+	case KindWall, KindBarricade:
+		static := NewWall(spec.Position)
+		if spec.Kind == KindBarricade {
+			static = NewBarricade(spec.Position)
+		}
+		static.ID = spec.ID
+		static.Team = spec.Team
+		applySpecOverrides(spec, &static.SpawnPosition, &static.Health, &static.MaxHealth)
+		return static, nil
+	default:
+		return nil, fmt.Errorf("unsupported unit kind %q", spec.Kind)
+	}
+}
+
+func applySpecOverrides(spec UnitSpec, spawnPosition *geom.Point, health, maxHealth *int) {
+	if spec.SpawnPosition != (geom.Point{}) {
+		*spawnPosition = spec.SpawnPosition
+	}
+	if spec.MaxHealth != 0 {
+		*maxHealth = spec.MaxHealth
+	}
+	if spec.Health != 0 {
+		*health = spec.Health
+	}
+}
+
+// SetCommandRecorder installs a callback that observes every AddUnit, SpawnUnit, RemoveUnit,
+// TeleportUnit, IssueMoveOrder, IssueFireOrder, IssueStopOrder and IssueHoldOrder call in issue
+// order. Failed orders are recorded as well because they still consume order IDs and emit
+// failure reports that a faithful replay must reproduce; rejected spawns and removals are not,
+// because they leave no trace. Passing nil disables recording.
+func (m *Manager) SetCommandRecorder(recorder func(Command)) {
+	if m == nil {
+		return
+	}
+
+	m.commandRecorder = recorder
+}
+
+// ApplyCommand re-issues one recorded command through the same public API that produced it.
+// Order validation errors are returned to the caller, which lets replay tooling tell a
+// rejected order apart from a malformed command while still keeping the simulation in sync.
+func (m *Manager) ApplyCommand(command Command) error {
+	if m == nil {
+		return fmt.Errorf("unit manager is not initialized")
+	}
+
+	switch command.Type {
+	case CommandAddUnit:
+		if command.Unit == nil {
+			return fmt.Errorf("add_unit command at tick %d has no unit spec", command.Tick)
+		}
+		body, err := NewUnitFromSpec(*command.Unit)
+		if err != nil {
+			return err
+		}
+		m.AddUnit(body)
+		return nil
+	case CommandMoveOrder:
+		return m.IssueMoveOrder(command.UnitID, command.Point)
+	case CommandFireOrder:
+		return m.IssueFireOrder(command.UnitID, command.Point)
+	case CommandRemoveUnit:
+		return m.RemoveUnit(command.UnitID, command.Reason)
+	case CommandTeleportUnit:
+		return m.TeleportUnit(command.UnitID, command.Point)
+	case CommandStopOrder:
+		return m.IssueStopOrder(command.UnitID)
+	case CommandHoldOrder:
+		return m.IssueHoldOrder(command.UnitID, command.Hold)
+	default:
+		return fmt.Errorf("unsupported command type %q", command.Type)
+	}
+}
+
+func (m *Manager) recordCommand(command Command) {
+	if m == nil || m.commandRecorder == nil {
+		return
+	}
+
+	command.Tick = m.lastGameTick
+	m.commandRecorder(command)
+}
@@ -42,15 +42,115 @@ type Unit interface {
 // identical across runners, obstacles and projectiles.
 type BaseUnit struct {
 	Position geom.Point
+	// Team survives respawns; per-team respawn policies key on it. It is set before the unit
+	// is added, since the manager copies it into its storage at registration.
+	Team Team
 
 	path            []geom.Point
 	sleepTime       int
 	lastUpdateTick  int64
 	lastVisibleTick int64
 	travel          travelState
-	updateSleeping  bool
-	pendingRemoval  bool
-	removalHandled  bool
+	flags           slotFlags
+	// removalReason is set by RemoveUnit before the unit is retired; an empty value means the
+	// unit left through combat or projectile expiry.
+	removalReason RemovalReason
+
+	// columns and slot bind a registered unit to the manager's struct-of-arrays storage. While
+	// bound, the sleep timer, travel state and lifecycle flags live in the column slot and the
+	// local fields above only hold the values from before registration or after release.
+	columns *unitColumns
+	slot    int
+}
+
+// sleepTimeValue resolves the current sleep budget from the bound column or the local field.
+func (s *BaseUnit) sleepTimeValue() int {
+	if s.columns != nil {
+		s.columns.settle(s.slot)
+		return int(s.columns.sleepTime[s.slot])
+	}
+	return s.sleepTime
+}
+
+// setSleepTime replaces the sleep budget. A bound unit whose new budget ends before its next
+// scheduled visit asks the scheduler to look at it on the next step.
+func (s *BaseUnit) setSleepTime(ticks int) {
+	if s.columns != nil {
+		s.columns.settle(s.slot)
+		s.columns.sleepTime[s.slot] = int32(ticks)
+		s.columns.wakeIfNeeded(s.slot)
+		return
+	}
+	s.sleepTime = ticks
+}
+
+// travelRef returns the live travel state. The pointer is only valid until the next unit is
+// registered, because registration may grow the column slices.
+func (s *BaseUnit) travelRef() *travelState {
+	if s.columns != nil {
+		s.columns.settle(s.slot)
+		return &s.columns.travel[s.slot]
+	}
+	return &s.travel
+}
+
+func (s *BaseUnit) hasFlag(flag slotFlags) bool {
+	if s.columns != nil {
+		return s.columns.flags[s.slot]&flag != 0
+	}
+	return s.flags&flag != 0
+}
+
+func (s *BaseUnit) setFlag(flag slotFlags, enabled bool) {
+	if s.columns == nil {
+		if enabled {
+			s.flags |= flag
+		} else {
+			s.flags &^= flag
+		}
+		return
+	}
+
+	s.columns.settle(s.slot)
+	if enabled {
+		s.columns.flags[s.slot] |= flag
+	} else {
+		s.columns.flags[s.slot] &^= flag
+	}
+	s.columns.wakeIfNeeded(s.slot)
+}
+
+// isMovingBeforeStep reports IsMoving as it stood at the end of the previous manager step.
+// Movement events compare it with the state after the visit; reading IsMoving directly would
+// already include this step's countdown and miss the stop edge of a finished segment.
+func (s *BaseUnit) isMovingBeforeStep() bool {
+	if s.columns == nil {
+		return s.IsMoving()
+	}
+
+	s.columns.settleTo(s.slot, s.columns.now()-1)
+	travel := s.columns.travel[s.slot]
+	return len(s.path) > 0 || (travel.active && travel.remaining > 0)
+}
+
+// sleepEndedThisStep reports whether a bound unit's sleep ran out on the current manager step.
+func (s *BaseUnit) sleepEndedThisStep() bool {
+	return s.columns != nil && s.columns.sleepEndedNow(s.slot)
+}
+
+// setPosition moves the unit and keeps the mirrored position column in sync.
+func (s *BaseUnit) setPosition(position geom.Point) {
+	s.Position = position
+	if s.columns != nil {
+		s.columns.positions[s.slot] = position
+	}
+}
+
+// syncHealth mirrors the concrete unit's health into the column after every change.
+func (s *BaseUnit) syncHealth(health int) {
+	if s.columns != nil {
+		s.columns.health[s.slot] = int32(health)
+	}
 }
 
 func (s BaseUnit) TilePosition(tileSize float64) (int, int) {
@@ -68,8 +168,8 @@ func (s BaseUnit) TilePosition(tileSize float64) (int, int) {
 // segment sleep budget has actually elapsed, otherwise queued reroutes look like they started
 // before the unit arrived at the next cell center.
 func (s BaseUnit) ReachedPosition() geom.Point {
-	if s.travel.active && s.travel.remaining > 0 {
-		return s.travel.from
+	if travel := s.travelRef(); travel.active && travel.remaining > 0 {
+		return travel.from
 	}
 
 	return s.Position
@@ -91,7 +191,8 @@ func (s BaseUnit) HasPath() bool {
 }
 
 func (s BaseUnit) IsMoving() bool {
-	return len(s.path) > 0 || (s.travel.active && s.travel.remaining > 0)
+	travel := s.travelRef()
+	return len(s.path) > 0 || (travel.active && travel.remaining > 0)
 }
 
 func (s BaseUnit) PathLen() int {
@@ -99,7 +200,7 @@ func (s BaseUnit) PathLen() int {
 }
 
 func (s BaseUnit) SleepTime() int {
-	return s.sleepTime
+	return s.sleepTimeValue()
 }
 
 func (s BaseUnit) LastUpdateTick() int64 {
@@ -109,13 +210,13 @@ func (s BaseUnit) LastUpdateTick() int64 {
 // UpdateSleeping reports whether the manager must skip this unit during the main update
 // pass until some external code explicitly wakes it again.
 func (s BaseUnit) UpdateSleeping() bool {
-	return s.updateSleeping
+	return s.hasFlag(slotUpdateSleeping)
 }
 
 func (s BaseUnit) Destination() (geom.Point, bool) {
 	if len(s.path) == 0 {
-		if s.travel.active {
-			return s.travel.to, true
+		if travel := s.travelRef(); travel.active {
+			return travel.to, true
 		}
 		return geom.Point{}, false
 	}
@@ -129,15 +230,28 @@ func (s BaseUnit) Destination() (geom.Point, bool) {
 // rendering we interpolate back from the segment origin to the destination using the
 // remaining tick budget stored in travel.
 func (s BaseUnit) RenderPosition() geom.Point {
-	if !s.travel.active || s.travel.duration <= 0 {
+	return s.InterpolatedRenderPosition(0)
+}
+
+// InterpolatedRenderPosition extends RenderPosition with the fraction of the next simulation
+// tick that has already elapsed in wall-clock time. Fixed-timestep launchers pass that
+// fraction so slow simulation speeds still move sprites smoothly between two ticks; the value
+// only shifts the drawn position and never feeds back into gameplay state.
+func (s BaseUnit) InterpolatedRenderPosition(alpha float64) geom.Point {
+	travel := s.travelRef()
+	if !travel.active || travel.duration <= 0 {
 		return s.Position
 	}
 
-	progress := 1 - float64(s.travel.visualRemaining)/float64(s.travel.duration)
+	remaining := float64(travel.visualRemaining)
+	if remaining > 0 {
+		remaining -= geom.ClampFloat(alpha, 0, 1)
+	}
+	progress := 1 - remaining/float64(travel.duration)
 	progress = geom.ClampFloat(progress, 0, 1)
 	return geom.Point{
-		X: s.travel.from.X + (s.travel.to.X-s.travel.from.X)*progress,
-		Y: s.travel.from.Y + (s.travel.to.Y-s.travel.from.Y)*progress,
+		X: travel.from.X + (travel.to.X-travel.from.X)*progress,
+		Y: travel.from.Y + (travel.to.Y-travel.from.Y)*progress,
 	}
 }
 
@@ -148,28 +262,33 @@ func (s *BaseUnit) consumeReachedWaypoint(target geom.Point) bool {
 		return false
 	}
 
-	s.Position = target
+	s.setPosition(target)
 	s.path = s.path[1:]
 	return true
 }
 
 func (s *BaseUnit) clearTravel() {
-	s.travel = travelState{}
+	*s.travelRef() = travelState{}
 }
 
 // StepSleep reduces the remaining logical sleep budget by one simulation tick. When the
 // budget reaches zero, the segment has visually completed as well, so the next manager pass
 // may immediately enter Tick in that same game tick without reconstructing a stale midpoint.
 func (s *BaseUnit) StepSleep() {
-	if s == nil || s.sleepTime <= 0 {
+	if s == nil {
+		return
+	}
+	sleepTime := s.sleepTimeValue()
+	if sleepTime <= 0 {
 		return
 	}
 
-	s.sleepTime--
-	s.travel.remaining = s.sleepTime
-	if s.sleepTime == 0 {
-		s.travel.remaining = 0
-		s.travel.visualRemaining = 0
+	sleepTime--
+	s.setSleepTime(sleepTime)
+	travel := s.travelRef()
+	travel.remaining = sleepTime
+	if sleepTime == 0 {
+		travel.visualRemaining = 0
 	}
 }
 
@@ -182,58 +301,59 @@ func (s *BaseUnit) AdvanceVisibleTravel(gameTick int64) {
 	}
 
 	s.lastVisibleTick = gameTick
-	if !s.travel.active || s.travel.duration <= 0 {
+	travel := s.travelRef()
+	if !travel.active || travel.duration <= 0 {
 		return
 	}
 
-	if s.travel.visualRemaining > s.travel.remaining {
-		s.travel.visualRemaining = s.travel.remaining
+	if travel.visualRemaining > travel.remaining {
+		travel.visualRemaining = travel.remaining
 	}
-	if s.travel.remaining == 0 {
-		s.travel.visualRemaining = 0
+	if travel.remaining == 0 {
+		travel.visualRemaining = 0
 	}
 }
 
 // PendingRemoval reports whether the unit has already completed its gameplay lifecycle and now
 // only waits for the manager sweep to unregister it from tiles and drop it from storage.
 func (s BaseUnit) PendingRemoval() bool {
-	return s.pendingRemoval
+	return s.hasFlag(slotPendingRemoval)
 }
 
 // MarkForRemoval transitions the unit into the deferred-deletion state. The manager performs
 // the physical removal later so worker iteration never mutates shared ordered storage in place.
 func (s *BaseUnit) MarkForRemoval() {
-	s.pendingRemoval = true
-	s.removalHandled = false
+	s.setFlag(slotPendingRemoval, true)
+	s.setFlag(slotRemovalHandled, false)
 }
 
 // ClearRemovalMark reactivates a unit object that is going to be reused instead of discarded.
 // Runtime code normally calls this right before storing the unit back inside the manager.
 func (s *BaseUnit) ClearRemovalMark() {
-	s.pendingRemoval = false
-	s.removalHandled = false
+	s.setFlag(slotPendingRemoval|slotRemovalHandled, false)
+	s.removalReason = ""
 }
 
 // RemovalHandled reports whether manager-side cleanup for a deleted unit has already happened.
 // This lets worker traversal flush tile state exactly once while still leaving the slot
 // available for later overwrite by a different unit.
 func (s BaseUnit) RemovalHandled() bool {
-	return s.removalHandled
+	return s.hasFlag(slotRemovalHandled)
 }
 
 // MarkRemovalHandled records that the manager has already drained reports and removed tile
 // registration for this deleted unit, so future updates may skip the tombstone entirely.
 func (s *BaseUnit) MarkRemovalHandled() {
-	s.removalHandled = true
+	s.setFlag(slotRemovalHandled, true)
 }
 
 // WakeForUpdate leaves the eternal-sleep mode so the manager may call Tick again.
 func (s *BaseUnit) WakeForUpdate() {
-	s.updateSleeping = false
+	s.setFlag(slotUpdateSleeping, false)
 }
 
 // SleepUntilExternalWake blocks future Tick calls until some external event reactivates the
 // unit. Static units use this to stay completely out of the hot update loop by default.
 func (s *BaseUnit) SleepUntilExternalWake() {
-	s.updateSleeping = true
+	s.setFlag(slotUpdateSleeping, true)
 }
@@ -4,12 +4,14 @@ import (
 	"image"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/unng-lab/endless/pkg/assets"
 	"github.com/unng-lab/endless/pkg/camera"
+	"github.com/unng-lab/endless/pkg/geom"
 	"github.com/unng-lab/endless/pkg/world"
 )
 
@@ -25,24 +27,57 @@ type Manager struct {
 	// The flag stays on the manager so visual/debug launchers may opt in without affecting the
 	// headless RL collection path that issues the same gameplay API at much higher frequency.
 	debugExternalAPILogging bool
+	// commandRecorder observes external mutations for replay recording. It is invoked from the
+	// public API only, so projectiles spawned inside Update never reach the command stream.
+	commandRecorder func(Command)
+	// lifecycleHooks are dispatched on the caller goroutine; dispatchingLifecycle keeps hooks
+	// that spawn or remove units from starting a nested dispatch pass.
+	lifecycleHooks       []*lifecycleHookSet
+	dispatchingLifecycle bool
 
 	bufferedOrderReports map[int64][]OrderReport
 	combatEvents         []CombatEvent
-	tileStacks           map[tileKey]*TileStack
-	registeredTiles      map[int64]tileKey
-	selectedID           int64
-	nextID               int64
-	nextOrderID          int64
-	lastGameTick         int64
-
+	events               eventBus
+	tileRegistry         *tileRegistry
+	// selectedID is the primary selected unit shown in the info panel; selection holds the whole
+	// group that commands are issued to.
+	selectedID int64
+	selection  unitSelection
+	// panelHitTest replaces the built-in info panel with a client HUD, see SetPanelHitTest.
+	panelHitTest        func(geom.Point) bool
+	renderInterpolation float64
+	nextID              int64
+	nextOrderID         int64
+	lastGameTick        int64
+
+	// pathfindingCalls, pathfindingFailures and pathfindingNanos count FindPath invocations and
+	// their wall-clock cost for benchmark reports and metrics.
+	pathfindingCalls    atomic.Int64
+	pathfindingFailures atomic.Int64
+	pathfindingNanos    atomic.Int64
+	// pathSearchTracing makes move orders record their search in lastPathSearch for the debug
+	// overlay; it is off everywhere else so the expanded-tile list is never built.
+	pathSearchTracing bool
+	lastPathSearch    *PathSearch
+
+	metrics managerMetrics
+
+	scheduler       *tickScheduler
 	workers         []chan int64
 	updateWG        sync.WaitGroup
 	orderReportsMu  sync.Mutex
 	combatEventsMu  sync.Mutex
-	tileRegistryMu  sync.RWMutex
+	corpses         []unitCorpse
 	pendingSpawnsMu sync.Mutex
 	pendingSpawns   []Unit
+	pendingHitsMu   sync.Mutex
+	pendingHits     []projectileHit
 	closeOnce       sync.Once
+	// stepping is set while the update workers run; events raised meanwhile are staged and
+	// published once the workers joined.
+	stepping       bool
+	stagedEventsMu sync.Mutex
+	stagedEvents   []stagedEvent
 }
 
 // tileEntryReactiveUnit describes units whose side effects must run exactly at the moment the
@@ -68,6 +103,14 @@ type tileKey struct {
 // AddUnit so tile stacks, persistent IDs and ordered storage are initialized through the same
 // runtime path that will later be used for dynamic spawns.
 func NewManager(gameWorld world.World) *Manager {
+	return NewManagerWithWorkers(gameWorld, 0)
+}
+
+// NewManagerWithWorkers creates an empty unit manager with an explicit update worker count.
+// Zero or negative values keep the GOMAXPROCS-derived default. Determinism checks use the
+// explicit form to prove that one simulation produces the same state regardless of how the
+// ordered slots are split between workers.
+func NewManagerWithWorkers(gameWorld world.World, workerCount int) *Manager {
 	startedAt := time.Now()
 	m := &Manager{
 		world:                gameWorld,
@@ -75,21 +118,30 @@ func NewManager(gameWorld world.World) *Manager {
 		units:                newOrderedUnitMap(0),
 		bufferedOrderReports: make(map[int64][]OrderReport),
 		combatEvents:         make([]CombatEvent, 0),
-		tileStacks:           make(map[tileKey]*TileStack),
-		registeredTiles:      make(map[int64]tileKey),
+		tileRegistry:         newTileRegistry(),
 	}
+	m.metrics.ticks = newDurationHistogram(tickDurationBounds)
 	log.Printf("[startup] units: manager core structures allocated in %s", time.Since(startedAt))
 
 	workersStartedAt := time.Now()
-	m.startWorkers()
+	m.startWorkers(workerCount)
 	log.Printf("[startup] units: worker pool started in %s", time.Since(workersStartedAt))
 	return m
 }
 
+// ReloadAssets drops the unit sprites loaded so far; the next Draw loads them again.
+func (m *Manager) ReloadAssets() {
+	m.renderer.Reload()
+}
+
 // Draw renders every tile-registered world body in the same tile order as the visible terrain
 // pass. Callers may disable the extra visible-unit refresh when they need the draw traversal to
 // reuse the current interpolated state without advancing visible-only animation or smoothing.
 func (m *Manager) Draw(screen *ebiten.Image, cam *camera.Camera, quality assets.Quality, visible image.Rectangle, updateVisibleUnits bool) error {
+	m.renderer.interpolation = m.renderInterpolation
+	if err := m.drawCorpses(screen, cam, quality, visible); err != nil {
+		return err
+	}
 	for _, current := range m.visibleTileUnits(visible, updateVisibleUnits) {
 		if err := m.renderer.DrawUnit(screen, cam, m.world.TileSize(), quality, current); err != nil {
 			return err
@@ -99,9 +151,39 @@ func (m *Manager) Draw(screen *ebiten.Image, cam *camera.Camera, quality assets.
 	return nil
 }
 
+// SetRenderInterpolation stores the elapsed fraction of the next simulation tick that Draw uses
+// to place moving bodies between two ticks. Launchers that run exactly one tick per frame keep
+// the default zero; fixed-timestep clocks pass their accumulator remainder every frame.
+func (m *Manager) SetRenderInterpolation(alpha float64) {
+	if m == nil {
+		return
+	}
+
+	m.renderInterpolation = geom.ClampFloat(alpha, 0, 1)
+}
+
 // AddUnit registers a freshly spawned unit in the manager and returns the persistent ID that
 // the caller should use for later commands, selections or order ownership tracking.
 func (m *Manager) AddUnit(body Unit) int64 {
+	unitID := m.addUnit(body)
+	if unitID == 0 {
+		return 0
+	}
+
+	if spec, ok := SpecForUnit(body); ok && m.commandRecorder != nil {
+		m.recordCommand(Command{
+			Type:   CommandAddUnit,
+			UnitID: unitID,
+			Unit:   &spec,
+		})
+	}
+	m.dispatchLifecycleHooks()
+	return unitID
+}
+
+// addUnit performs the registration shared by external spawns and projectiles flushed at the
+// end of Update. Only the public AddUnit wrapper reports into the command stream.
+func (m *Manager) addUnit(body Unit) int64 {
 	if body == nil {
 		return 0
 	}
@@ -117,6 +199,7 @@ func (m *Manager) AddUnit(body Unit) int64 {
 	m.bindUnitRuntimeDependencies(body)
 	m.units.Set(body)
 	m.registerUnitInCurrentTile(body)
+	m.publishUnitEvent(GameplayEventUnitSpawned, body)
 
 	return body.UnitID()
 }
@@ -1,93 +1,213 @@
 package unit
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/unng-lab/endless/pkg/camera"
 	"github.com/unng-lab/endless/pkg/geom"
 )
 
+// HasSelected reports whether at least one selected unit is still alive.
 func (m *Manager) HasSelected() bool {
-	_, ok := m.selectedUnit()
-	return ok
+	if m == nil {
+		return false
+	}
+
+	for _, unitID := range m.selectionIDs() {
+		if _, ok := m.unitByID(unitID); ok {
+			return true
+		}
+	}
+	return false
 }
 
 // SelectUnitByID pins one concrete runtime object into the existing overlay and command-target
 // flow so autonomous scenarios may keep the same actor selected without requiring a mouse click.
+// It replaces any group selection.
 func (m *Manager) SelectUnitByID(unitID int64) bool {
-	if m == nil || unitID == 0 {
-		m.selectedID = 0
+	if m == nil {
+		return false
+	}
+
+	m.ClearSelection()
+	if unitID == 0 {
 		return false
 	}
 
 	selected, ok := m.unitByID(unitID)
 	if !ok || selected == nil {
-		m.selectedID = 0
 		return false
 	}
 
-	m.selectedID = unitID
+	m.applySelection([]Unit{selected}, SelectionReplace)
 	return true
 }
 
+// SelectAtScreen replaces the selection with the unit under the cursor, or clears it when the
+// cursor points at empty ground.
 func (m *Manager) SelectAtScreen(cam *camera.Camera, cursor geom.Point, screenWidth, screenHeight int) {
-	if m.PointInPanel(cam, cursor, screenWidth, screenHeight) {
-		return
-	}
-	if cam == nil {
-		m.selectedID = 0
-		return
+	m.SelectAtScreenMode(cam, cursor, screenWidth, screenHeight, SelectionReplace)
+}
+
+// PointInPanel reports whether the cursor is over the info panel, or over the client HUD when
+// one replaced it, so clicks there never select or command the units beneath.
+func (m *Manager) PointInPanel(cam *camera.Camera, cursor geom.Point, screenWidth, screenHeight int) bool {
+	if m.panelHitTest != nil {
+		return m.panelHitTest(cursor)
 	}
 
-	worldPos := cam.ScreenToWorld(cursor)
-	if !m.pointInWorld(worldPos) {
-		m.selectedID = 0
-		return
+	rect, ok := m.PanelRect(cam, screenWidth, screenHeight)
+	return ok && pointInRect(cursor, rect)
+}
+
+// CommandSelectedMove sends the selection to the target tile. A single unit walks to the tile
+// itself; a group is spread over the nearest walkable tiles around it, with the units closest to
+// the target taking the innermost slots so the group does not cross over itself. Static objects
+// in a mixed selection are skipped, and the failures of individual units are joined into the
+// returned error while the rest of the group still moves. A player move releases hold
+// position first, as it does in most strategy games.
+func (m *Manager) CommandSelectedMove(targetTileX, targetTileY int) error {
+	selected := m.selectedUnits()
+	if len(selected) == 0 {
+		return nil
+	}
+	m.releaseHolds(selected)
+	if len(selected) == 1 {
+		return m.IssueMoveOrder(selected[0].UnitID(), m.tileAnchor(targetTileX, targetTileY))
 	}
 
-	stack := m.stackAtWorldPoint(worldPos)
-	candidates := m.selectableUnitsFromStack(stack)
-	if len(candidates) == 0 {
-		m.selectedID = 0
-		return
+	movers := slices.DeleteFunc(selected, func(current Unit) bool { return !current.IsMobile() })
+	if len(movers) == 0 {
+		return fmt.Errorf("selected objects are immobile")
 	}
 
-	selected := candidates[len(candidates)-1]
-	m.selectedID = selected.UnitID()
+	target := m.tileAnchor(targetTileX, targetTileY)
+	slices.SortStableFunc(movers, func(a, b Unit) int {
+		return cmp.Or(
+			cmp.Compare(squaredDistance(a.Base().Position, target), squaredDistance(b.Base().Position, target)),
+			cmp.Compare(a.UnitID(), b.UnitID()),
+		)
+	})
+
+	slots := m.formationTiles(targetTileX, targetTileY, len(movers))
+	var errs []error
+	for index, mover := range movers {
+		destination := target
+		if index < len(slots) {
+			destination = slots[index]
+		}
+		if err := m.IssueMoveOrder(mover.UnitID(), destination); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
-func (m *Manager) PointInPanel(cam *camera.Camera, cursor geom.Point, screenWidth, screenHeight int) bool {
-	rect, ok := m.PanelRect(cam, screenWidth, screenHeight)
-	return ok && pointInRect(cursor, rect)
+// CommandSelectedStop stops every selected mobile unit, releasing hold position on the way.
+func (m *Manager) CommandSelectedStop() error {
+	movers := m.selectedMovers()
+	if len(movers) == 0 {
+		return m.noMoversError()
+	}
+
+	m.releaseHolds(movers)
+	var errs []error
+	for _, mover := range movers {
+		if err := m.IssueStopOrder(mover.UnitID()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
-func (m *Manager) CommandSelectedMove(targetTileX, targetTileY int) error {
-	selected, ok := m.selectedUnit()
-	if !ok {
-		if m.HasSelected() {
-			return fmt.Errorf("selected object is immobile")
+// CommandSelectedHold switches hold position for every selected mobile unit. The command card
+// toggles it: when every mover already holds, the hold is released, otherwise all of them hold.
+func (m *Manager) CommandSelectedHold() error {
+	movers := m.selectedMovers()
+	if len(movers) == 0 {
+		return m.noMoversError()
+	}
+
+	hold := !allHolding(movers)
+	var errs []error
+	for _, mover := range movers {
+		if err := m.IssueHoldOrder(mover.UnitID(), hold); err != nil {
+			errs = append(errs, err)
 		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Manager) selectedMovers() []Unit {
+	return slices.DeleteFunc(m.selectedUnits(), func(current Unit) bool { return !current.IsMobile() })
+}
+
+func (m *Manager) noMoversError() error {
+	if m.SelectionCount() == 0 {
 		return nil
 	}
+	return fmt.Errorf("selected objects are immobile")
+}
+
+// releaseHolds lifts hold position from the given units so a player order can move them.
+func (m *Manager) releaseHolds(units []Unit) {
+	for _, current := range units {
+		if body, ok := current.(*NonStaticUnit); ok && body.holdPosition {
+			_ = m.IssueHoldOrder(body.UnitID(), false)
+		}
+	}
+}
 
-	return m.IssueMoveOrder(selected.UnitID(), m.tileAnchor(targetTileX, targetTileY))
+func allHolding(units []Unit) bool {
+	for _, current := range units {
+		if body, ok := current.(*NonStaticUnit); !ok || !body.holdPosition {
+			return false
+		}
+	}
+	return true
 }
 
+// CommandSelectedFire makes every selected shooter fire at the world point from its own
+// position. Selected units without a weapon are skipped when the group holds at least one
+// shooter.
 func (m *Manager) CommandSelectedFire(target geom.Point) error {
-	selected, ok := m.selectedNonStatic()
-	if !ok {
-		if m.HasSelected() {
-			return fmt.Errorf("selected object cannot shoot")
+	selected := m.selectedUnits()
+	if len(selected) == 1 {
+		return m.commandFire(selected[0], target)
+	}
+
+	var errs []error
+	fired := false
+	for _, current := range selected {
+		if body, ok := current.(*NonStaticUnit); !ok || !body.CanShoot() {
+			continue
+		}
+		fired = true
+		if err := m.commandFire(current, target); err != nil {
+			errs = append(errs, err)
 		}
-		return nil
 	}
-	if !selected.CanShoot() {
-		return fmt.Errorf("unit %q cannot shoot", selected.Name())
+	if !fired && len(selected) > 0 {
+		return fmt.Errorf("selected objects cannot shoot")
 	}
+	return errors.Join(errs...)
+}
 
-	return m.IssueFireOrder(selected.UnitID(), geom.Point{
-		X: target.X - selected.Position.X,
-		Y: target.Y - selected.Position.Y,
+func (m *Manager) commandFire(selected Unit, target geom.Point) error {
+	body, ok := selected.(*NonStaticUnit)
+	if !ok {
+		return fmt.Errorf("selected object cannot shoot")
+	}
+	if !body.CanShoot() {
+		return fmt.Errorf("unit %q cannot shoot", body.Name())
+	}
+
+	return m.IssueFireOrder(body.UnitID(), geom.Point{
+		X: target.X - body.Position.X,
+		Y: target.Y - body.Position.Y,
 	})
 }
 
@@ -103,16 +223,6 @@ func (m *Manager) selectedUnit() (Unit, bool) {
 	return m.unitByID(m.selectedID)
 }
 
-func (m *Manager) selectedNonStatic() (*NonStaticUnit, bool) {
-	selected, ok := m.selectedUnit()
-	if !ok {
-		return nil, false
-	}
-
-	body, ok := selected.(*NonStaticUnit)
-	return body, ok
-}
-
 func (m *Manager) selectedVisible(cam *camera.Camera, screenWidth, screenHeight int) bool {
 	selected, ok := m.selectedUnit()
 	if !ok {
@@ -132,3 +242,9 @@ func (m *Manager) pointInWorld(position geom.Point) bool {
 		position.X <= m.world.Width() &&
 		position.Y <= m.world.Height()
 }
+
+func squaredDistance(a, b geom.Point) float64 {
+	dx := a.X - b.X
+	dy := a.Y - b.Y
+	return dx*dx + dy*dy
+}
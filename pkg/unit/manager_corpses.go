@@ -0,0 +1,83 @@
+package unit
+
+import (
+	"image"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/unng-lab/endless/pkg/assets"
+	"github.com/unng-lab/endless/pkg/camera"
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+const (
+	// corpseTipTicks is how long a body without a death clip takes to topple over.
+	corpseTipTicks = 10
+	// corpseFadeTicks is how long a body stays on the ground, fading out, after its death clip.
+	corpseFadeTicks = 60
+)
+
+// unitCorpse is the draw-only remains of a sprite unit killed in combat. Killed units leave the
+// simulation on the tick they die, so the death animation plays from this copy of what the
+// renderer needs instead of from the retired unit, whose slot may already be reused.
+type unitCorpse struct {
+	kind     Kind
+	position geom.Point
+	facing   assets.Direction
+	diedAt   int64
+}
+
+// corpseTicks is how long a corpse of the kind stays visible.
+func corpseTicks(kind Kind) int64 {
+	if clip, ok := kindClip(kind, assets.ClipDeath); ok {
+		return int64(clip.Ticks()) + corpseFadeTicks
+	}
+	return corpseTipTicks + corpseFadeTicks
+}
+
+// recordCorpse keeps the remains of a unit killed this tick. Kills are applied with the queued
+// hits after the update workers joined, so the list is only touched by the goroutine that
+// drives the manager and the dead unit's position and facing are no longer changing.
+func (m *Manager) recordCorpse(target Unit) {
+	body, ok := target.(*NonStaticUnit)
+	if !ok || !kindUsesSprite(body.Kind) {
+		return
+	}
+
+	m.corpses = append(m.corpses, unitCorpse{
+		kind:     body.Kind,
+		position: body.Position,
+		facing:   body.facing,
+		diedAt:   m.lastGameTick,
+	})
+}
+
+// pruneCorpses drops the corpses that have faded out. Update calls it every tick, so headless
+// runs that never draw do not accumulate them.
+func (m *Manager) pruneCorpses() {
+	m.corpses = slices.DeleteFunc(m.corpses, func(corpse unitCorpse) bool {
+		return m.lastGameTick-corpse.diedAt >= corpseTicks(corpse.kind)
+	})
+}
+
+// drawCorpses renders the corpses on the visible tiles. They are drawn before the living units,
+// which walk over them.
+func (m *Manager) drawCorpses(screen *ebiten.Image, cam *camera.Camera, quality assets.Quality, visible image.Rectangle) error {
+	tileSize := m.world.TileSize()
+	for _, corpse := range m.corpses {
+		elapsed := m.lastGameTick - corpse.diedAt
+		if elapsed >= corpseTicks(corpse.kind) {
+			continue
+		}
+		tile := image.Pt(int(math.Floor(corpse.position.X/tileSize)), int(math.Floor(corpse.position.Y/tileSize)))
+		if !tile.In(visible) {
+			continue
+		}
+		if err := m.renderer.drawCorpse(screen, cam, tileSize, quality, corpse, elapsed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
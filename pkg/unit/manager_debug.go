@@ -13,6 +13,11 @@ func (m *Manager) SetExternalAPIDebugLogging(enabled bool) {
 	m.debugExternalAPILogging = enabled
 }
 
+// ExternalAPIDebugLogging reports whether the external API trace is enabled.
+func (m *Manager) ExternalAPIDebugLogging() bool {
+	return m != nil && m.debugExternalAPILogging
+}
+
 // debugExternalAPILogf centralizes the log prefix for manager-level command tracing so move and
 // fire API calls stay easy to grep in one mixed runtime log stream.
 func (m *Manager) debugExternalAPILogf(format string, args ...any) {
@@ -18,15 +18,32 @@ const (
 	infoPanelMaxWidth = 480.0
 )
 
+// DrawOverlay highlights every visible selected unit and shows the info panel for the primary
+// one while it is on screen, unless a client HUD replaced the panel.
 func (m *Manager) DrawOverlay(screen *ebiten.Image, cam *camera.Camera, screenWidth, screenHeight int) {
-	if !m.selectedVisible(cam, screenWidth, screenHeight) {
+	for _, selected := range m.selectedUnits() {
+		if unitVisibleOnScreen(cam, m.world.TileSize(), screenWidth, screenHeight, selected) {
+			m.drawSelectedHighlight(screen, cam, selected)
+		}
+	}
+	if m.panelHitTest != nil || !m.selectedVisible(cam, screenWidth, screenHeight) {
 		return
 	}
 
-	m.drawSelectedHighlight(screen, cam)
 	m.drawInfoPanel(screen, cam, screenWidth, screenHeight)
 }
 
+// SetPanelHitTest hands the selection details over to a client HUD. DrawOverlay then only
+// highlights the selection, and PointInPanel asks hit instead of testing PanelRect. Passing nil
+// restores the built-in info panel.
+func (m *Manager) SetPanelHitTest(hit func(cursor geom.Point) bool) {
+	if m == nil {
+		return
+	}
+
+	m.panelHitTest = hit
+}
+
 func (m *Manager) PanelRect(cam *camera.Camera, screenWidth, screenHeight int) (geom.Rect, bool) {
 	if !m.selectedVisible(cam, screenWidth, screenHeight) {
 		return geom.Rect{}, false
@@ -45,12 +62,7 @@ func (m *Manager) PanelRect(cam *camera.Camera, screenWidth, screenHeight int) (
 	}, true
 }
 
-func (m *Manager) drawSelectedHighlight(screen *ebiten.Image, cam *camera.Camera) {
-	selected, ok := m.selectedUnit()
-	if !ok {
-		return
-	}
-
+func (m *Manager) drawSelectedHighlight(screen *ebiten.Image, cam *camera.Camera, selected Unit) {
 	rect, ok := unitScreenRect(cam, m.world.TileSize(), selected)
 	if !ok {
 		return
@@ -91,12 +103,31 @@ func (m *Manager) drawInfoPanel(screen *ebiten.Image, cam *camera.Camera, screen
 	m.drawFilledRect(screen, rect.Min.X, rect.Min.Y, rect.Max.X-rect.Min.X, rect.Max.Y-rect.Min.Y, panelColor)
 	m.drawFilledRect(screen, rect.Min.X, rect.Min.Y, rect.Max.X-rect.Min.X, 3, borderColor)
 
+	ebitenutil.DebugPrintAt(screen, m.detailsText(selected), int(rect.Min.X+16), int(rect.Min.Y+14))
+}
+
+// SelectionDetails describes the primary selected unit in the lines of the info panel, for a
+// client HUD that draws its own panel.
+func (m *Manager) SelectionDetails() (string, bool) {
+	selected, ok := m.selectedUnit()
+	if !ok {
+		return "", false
+	}
+	return m.detailsText(selected), true
+}
+
+func (m *Manager) detailsText(selected Unit) string {
 	base := selected.Base()
 	tileX, tileY := base.TilePosition(m.world.TileSize())
-	infoText := fmt.Sprintf(
-		"Object #%d: %s\nTile: (%d, %d)  World: (%.1f, %.1f)\nKind: %s  Frame: %d\nHP: %d/%d  Terrain speed: %.0f%%  Sleep: %d\n%s",
+	groupText := ""
+	if count := m.SelectionCount(); count > 1 {
+		groupText = fmt.Sprintf("  (+%d selected)", count-1)
+	}
+	return fmt.Sprintf(
+		"Object #%d: %s%s\nTile: (%d, %d)  World: (%.1f, %.1f)\nKind: %s  Frame: %d\nHP: %d/%d  Terrain speed: %.0f%%  Sleep: %d\n%s",
 		selected.UnitID(),
 		selected.Name(),
+		groupText,
 		tileX,
 		tileY,
 		base.Position.X,
@@ -109,7 +140,6 @@ func (m *Manager) drawInfoPanel(screen *ebiten.Image, cam *camera.Camera, screen
 		base.SleepTime(),
 		m.statusText(selected),
 	)
-	ebitenutil.DebugPrintAt(screen, infoText, int(rect.Min.X+16), int(rect.Min.Y+14))
 }
 
 func (m *Manager) statusText(selected Unit) string {
@@ -122,10 +152,15 @@ func (m *Manager) statusText(selected Unit) string {
 	}
 
 	if !base.IsMoving() {
-		if body, ok := selected.(*NonStaticUnit); ok && body.CanShoot() {
-			return "State: idle  " + weaponStatusText(body)
+		state := "State: idle"
+		body, ok := selected.(*NonStaticUnit)
+		if ok && body.HoldingPosition() {
+			state = "State: holding position"
 		}
-		return "State: idle"
+		if ok && body.CanShoot() {
+			return state + "  " + weaponStatusText(body)
+		}
+		return state
 	}
 
 	destination, ok := base.Destination()
@@ -156,7 +191,7 @@ func weaponStatusText(unit *NonStaticUnit) string {
 		return "Weapon: ready"
 	}
 
-	return fmt.Sprintf("Weapon: cooldown %d", unit.fireCooldownRemaining)
+	return fmt.Sprintf("Weapon: cooldown %d", unit.weaponCooldown())
 }
 
 func (m *Manager) drawFilledRect(screen *ebiten.Image, x, y, width, height float64, fill color.Color) {
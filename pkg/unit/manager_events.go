@@ -0,0 +1,355 @@
+package unit
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// GameplayEventType identifies one kind of entry in the unified gameplay event stream.
+type GameplayEventType string
+
+const (
+	GameplayEventUnitSpawned       GameplayEventType = "unit_spawned"
+	GameplayEventUnitDespawned     GameplayEventType = "unit_despawned"
+	GameplayEventMoveStarted       GameplayEventType = "move_started"
+	GameplayEventMoveStopped       GameplayEventType = "move_stopped"
+	GameplayEventTileEntered       GameplayEventType = "tile_entered"
+	GameplayEventOrder             GameplayEventType = "order"
+	GameplayEventProjectileSpawned GameplayEventType = "projectile_spawned"
+	GameplayEventProjectileHit     GameplayEventType = "projectile_hit"
+	GameplayEventProjectileExpired GameplayEventType = "projectile_expired"
+	GameplayEventUnitKilled        GameplayEventType = "unit_killed"
+)
+
+// GameplayEvent is one entry of the unified event stream. UnitID, UnitKind and Position
+// describe the unit the event is about; TileX and TileY are filled for tile entries. Order is
+// meaningful only for GameplayEventOrder, and Combat carries the full combat record for
+// projectile and kill events so subscribers do not need the legacy combat drain as well.
+// Reason is filled only for GameplayEventUnitDespawned.
+type GameplayEvent struct {
+	Tick     int64
+	Type     GameplayEventType
+	UnitID   int64
+	UnitKind Kind
+	Position geom.Point
+	TileX    int
+	TileY    int
+	Order    OrderReport
+	Combat   CombatEvent
+	Reason   RemovalReason
+}
+
+// EventFilter selects which events reach one subscription. Empty lists match everything. A
+// unit filter matches the event unit as well as the source and target of combat events, so a
+// subscriber tracking one runner also sees the projectiles that hit it.
+type EventFilter struct {
+	Types   []GameplayEventType
+	UnitIDs []int64
+}
+
+// Matches reports whether the event passes both the type and the unit constraints.
+func (f EventFilter) Matches(event GameplayEvent) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, event.Type) {
+		return false
+	}
+	if len(f.UnitIDs) == 0 {
+		return true
+	}
+
+	return slices.Contains(f.UnitIDs, event.UnitID) ||
+		(event.Combat.SourceUnitID != 0 && slices.Contains(f.UnitIDs, event.Combat.SourceUnitID)) ||
+		(event.Combat.TargetUnitID != 0 && slices.Contains(f.UnitIDs, event.Combat.TargetUnitID))
+}
+
+// EventSubscription buffers the events that matched its filter until the owner drains them.
+// Every subscription has its own queue, so the renderer, scenarios, telemetry and RL recorder
+// may all observe the same tick without taking events away from each other.
+type EventSubscription struct {
+	bus    *eventBus
+	filter EventFilter
+
+	mu     sync.Mutex
+	events []GameplayEvent
+	closed bool
+}
+
+// Drain returns and clears the buffered events in publication order. Events raised by the
+// update workers during one step are published after the step, ordered by unit ID, so the
+// order never depends on how the units were split between the workers.
+func (s *EventSubscription) Drain() []GameplayEvent {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.events) == 0 {
+		return nil
+	}
+	events := s.events
+	s.events = nil
+	return events
+}
+
+// Close detaches the subscription from the manager and drops anything still buffered.
+func (s *EventSubscription) Close() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.closed = true
+	s.events = nil
+	s.mu.Unlock()
+
+	if s.bus != nil {
+		s.bus.remove(s)
+	}
+}
+
+func (s *EventSubscription) deliver(event GameplayEvent) {
+	if !s.filter.Matches(event) {
+		return
+	}
+
+	s.mu.Lock()
+	if !s.closed {
+		s.events = append(s.events, event)
+	}
+	s.mu.Unlock()
+}
+
+// eventBus fans gameplay events out to every open subscription. The subscriber count is kept
+// in an atomic so the hot update path skips building events entirely while nobody listens.
+type eventBus struct {
+	mu            sync.RWMutex
+	subscriptions []*EventSubscription
+	active        atomic.Int32
+}
+
+func (b *eventBus) add(subscription *EventSubscription) {
+	b.mu.Lock()
+	b.subscriptions = append(b.subscriptions, subscription)
+	b.active.Store(int32(len(b.subscriptions)))
+	b.mu.Unlock()
+}
+
+func (b *eventBus) remove(subscription *EventSubscription) {
+	b.mu.Lock()
+	b.subscriptions = slices.DeleteFunc(b.subscriptions, func(current *EventSubscription) bool {
+		return current == subscription
+	})
+	b.active.Store(int32(len(b.subscriptions)))
+	b.mu.Unlock()
+}
+
+func (b *eventBus) listening() bool {
+	return b.active.Load() > 0
+}
+
+func (b *eventBus) publish(event GameplayEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, subscription := range b.subscriptions {
+		subscription.deliver(event)
+	}
+}
+
+// Subscribe opens a new filtered view of the gameplay event stream. The subscription only sees
+// events published after this call and must be closed when its owner goes away, otherwise its
+// buffer keeps growing with every matching event.
+func (m *Manager) Subscribe(filter EventFilter) *EventSubscription {
+	if m == nil {
+		return nil
+	}
+
+	subscription := &EventSubscription{
+		bus: &m.events,
+		filter: EventFilter{
+			Types:   slices.Clone(filter.Types),
+			UnitIDs: slices.Clone(filter.UnitIDs),
+		},
+	}
+	m.events.add(subscription)
+	return subscription
+}
+
+// stagedEvent is one event raised on an update worker. Combat records are kept whole so the
+// flush can feed them to the combat drain as well as to the bus.
+type stagedEvent struct {
+	unitID int64
+	event  GameplayEvent
+	combat CombatEvent
+}
+
+// publishEvent hands one event to the subscriptions, or stages it while the workers run.
+func (m *Manager) publishEvent(event GameplayEvent) {
+	if m.stepping {
+		m.stageEvent(stagedEvent{unitID: event.UnitID, event: event})
+		return
+	}
+	m.events.publish(event)
+}
+
+func (m *Manager) stageEvent(staged stagedEvent) {
+	m.stagedEventsMu.Lock()
+	m.stagedEvents = append(m.stagedEvents, staged)
+	m.stagedEventsMu.Unlock()
+}
+
+// flushStagedEvents publishes the events the workers raised during the step. Every event a
+// worker raises is about the unit it is visiting, and one unit is visited by one worker, so a
+// stable sort by unit ID keeps each unit's own events in the order they happened.
+func (m *Manager) flushStagedEvents() {
+	staged := m.stagedEvents
+	if len(staged) == 0 {
+		return
+	}
+	slices.SortStableFunc(staged, func(a, b stagedEvent) int {
+		return cmp.Compare(a.unitID, b.unitID)
+	})
+
+	for _, current := range staged {
+		if current.combat.Type != "" {
+			m.appendCombatEvent(current.combat)
+			continue
+		}
+		m.events.publish(current.event)
+	}
+	clear(staged)
+	m.stagedEvents = staged[:0]
+}
+
+// publishUnitEvent stamps one unit-scoped event with the current tick and unit identity.
+func (m *Manager) publishUnitEvent(eventType GameplayEventType, unit Unit) {
+	if m == nil || unit == nil || !m.events.listening() {
+		return
+	}
+
+	m.publishEvent(GameplayEvent{
+		Tick:     m.lastGameTick,
+		Type:     eventType,
+		UnitID:   unit.UnitID(),
+		UnitKind: unit.UnitKind(),
+		Position: unit.Base().Position,
+	})
+}
+
+// publishUnitDespawned reports a retired unit together with the reason it left.
+func (m *Manager) publishUnitDespawned(unit Unit, reason RemovalReason) {
+	if m == nil || unit == nil || !m.events.listening() {
+		return
+	}
+
+	m.publishEvent(GameplayEvent{
+		Tick:     m.lastGameTick,
+		Type:     GameplayEventUnitDespawned,
+		UnitID:   unit.UnitID(),
+		UnitKind: unit.UnitKind(),
+		Position: unit.Base().Position,
+		Reason:   reason,
+	})
+}
+
+func (m *Manager) publishTileEntered(unit Unit, key tileKey) {
+	if m == nil || unit == nil || !m.events.listening() {
+		return
+	}
+
+	m.publishEvent(GameplayEvent{
+		Tick:     m.lastGameTick,
+		Type:     GameplayEventTileEntered,
+		UnitID:   unit.UnitID(),
+		UnitKind: unit.UnitKind(),
+		Position: unit.Base().Position,
+		TileX:    key.x,
+		TileY:    key.y,
+	})
+}
+
+// publishOrderReport forwards one order lifecycle report. The owner is nil for reports the
+// manager rejects before any unit accepted the order, such as commands for unknown IDs.
+func (m *Manager) publishOrderReport(owner Unit, report OrderReport) {
+	if m == nil {
+		return
+	}
+	m.metrics.countOrderReport(report.Status)
+	if !m.events.listening() {
+		return
+	}
+
+	event := GameplayEvent{
+		Tick:   m.lastGameTick,
+		Type:   GameplayEventOrder,
+		UnitID: report.UnitID,
+		Order:  report,
+	}
+	if owner != nil {
+		event.UnitKind = owner.UnitKind()
+		event.Position = owner.Base().Position
+	}
+	m.publishEvent(event)
+}
+
+func (m *Manager) publishCombatEvent(combat CombatEvent) {
+	if m == nil || !m.events.listening() {
+		return
+	}
+
+	event := GameplayEvent{
+		Tick:     combat.Tick,
+		Position: combat.Position,
+		Combat:   combat,
+	}
+	switch combat.Type {
+	case CombatEventProjectileSpawned:
+		event.Type = GameplayEventProjectileSpawned
+		event.UnitID = combat.ProjectileUnitID
+		event.UnitKind = KindProjectile
+	case CombatEventProjectileHit:
+		event.Type = GameplayEventProjectileHit
+		event.UnitID = combat.ProjectileUnitID
+		event.UnitKind = KindProjectile
+	case CombatEventProjectileExpired:
+		event.Type = GameplayEventProjectileExpired
+		event.UnitID = combat.ProjectileUnitID
+		event.UnitKind = KindProjectile
+	case CombatEventUnitKilled:
+		event.Type = GameplayEventUnitKilled
+		event.UnitID = combat.TargetUnitID
+	default:
+		return
+	}
+	m.publishEvent(event)
+}
+
+// unitMovingForEvents samples the movement state before a tick so publishMovementTransition can
+// report start and stop edges. Only mobile units take part, and the sample is skipped entirely
+// while nobody listens.
+func (m *Manager) unitMovingForEvents(unit Unit) bool {
+	if !m.events.listening() || !unit.IsMobile() {
+		return false
+	}
+
+	return unit.Base().isMovingBeforeStep()
+}
+
+func (m *Manager) publishMovementTransition(unit Unit, wasMoving bool) {
+	if !m.events.listening() || !unit.IsMobile() || unit.Base().PendingRemoval() {
+		return
+	}
+
+	moving := unit.Base().IsMoving()
+	switch {
+	case moving && !wasMoving:
+		m.publishUnitEvent(GameplayEventMoveStarted, unit)
+	case !moving && wasMoving:
+		m.publishUnitEvent(GameplayEventMoveStopped, unit)
+	}
+}
@@ -0,0 +1,160 @@
+package unit
+
+import (
+	"image"
+
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/pathfinding"
+)
+
+// UnitRoute is the movement intent of one mobile unit as the debug overlays draw it. Path starts
+// with the waypoint the unit is travelling to and ends at the destination of the running order;
+// Queued is the route of the order that replaces it at the next tile boundary.
+type UnitRoute struct {
+	UnitID int64
+	Start  geom.Point
+	Path   []geom.Point
+	Queued []geom.Point
+}
+
+// TileOccupancy describes one occupied tile: how many bodies its stack holds and whether any of
+// them blocks movement, which is what makes the path search route around the tile.
+type TileOccupancy struct {
+	X       int
+	Y       int
+	Units   int
+	Blocked bool
+}
+
+// ProjectileTrajectory is the remaining tile-by-tile route of one projectile in flight.
+type ProjectileTrajectory struct {
+	UnitID   int64
+	Position geom.Point
+	Path     []geom.Point
+}
+
+// PathSearch keeps the inputs and the outcome of the last traced move-order path search.
+type PathSearch struct {
+	Tick     int64
+	UnitID   int64
+	Start    pathfinding.Step
+	Goal     pathfinding.Step
+	Expanded []pathfinding.Step
+	Path     []pathfinding.Step
+	Err      error
+}
+
+// SetPathSearchTracing turns recording of the last move-order path search on or off. Tracing
+// keeps every expanded tile of the search, so it stays off unless a debug overlay asks for it;
+// switching it off also drops the recorded search.
+func (m *Manager) SetPathSearchTracing(enabled bool) {
+	if m == nil || m.pathSearchTracing == enabled {
+		return
+	}
+
+	m.pathSearchTracing = enabled
+	if !enabled {
+		m.lastPathSearch = nil
+	}
+}
+
+// LastPathSearch returns the most recent traced path search, if tracing was on when it ran.
+func (m *Manager) LastPathSearch() (PathSearch, bool) {
+	if m == nil || m.lastPathSearch == nil {
+		return PathSearch{}, false
+	}
+
+	return *m.lastPathSearch, true
+}
+
+// recordPathSearch stores one finished traced search, replacing the previous one.
+func (m *Manager) recordPathSearch(unitID int64, start, goal pathfinding.Step, trace *pathfinding.Trace, path []pathfinding.Step, err error) {
+	m.lastPathSearch = &PathSearch{
+		Tick:     m.lastGameTick,
+		UnitID:   unitID,
+		Start:    start,
+		Goal:     goal,
+		Expanded: trace.Expanded,
+		Path:     append([]pathfinding.Step(nil), path...),
+		Err:      err,
+	}
+}
+
+// UnitRoute returns the remaining route and the queued route of a mobile unit. Static bodies
+// and unknown IDs report false. It must not run concurrently with Update.
+func (m *Manager) UnitRoute(unitID int64) (UnitRoute, bool) {
+	if m == nil {
+		return UnitRoute{}, false
+	}
+
+	current, ok := m.unitByID(unitID)
+	if !ok {
+		return UnitRoute{}, false
+	}
+	body, ok := current.(*NonStaticUnit)
+	if !ok || !body.Alive() {
+		return UnitRoute{}, false
+	}
+
+	route := UnitRoute{
+		UnitID: unitID,
+		Start:  body.ReachedPosition(),
+	}
+	if body.IsMoving() {
+		route.Path = append(route.Path, body.Position)
+	}
+	route.Path = append(route.Path, body.path...)
+
+	switch {
+	case body.queuedOrder.hasOrder && body.queuedOrder.order.kind == OrderKindMove:
+		route.Queued = append(route.Queued, body.queuedOrder.order.path...)
+	case body.queuedMove.hasRoute:
+		route.Queued = append(route.Queued, body.queuedMove.path...)
+	}
+
+	return route, true
+}
+
+// AppendTileOccupancy appends one entry per occupied tile inside the tile rectangle, in
+// row-major order, and returns the extended slice. It must not run concurrently with Update.
+func (m *Manager) AppendTileOccupancy(dst []TileOccupancy, tiles image.Rectangle) []TileOccupancy {
+	if m == nil {
+		return dst
+	}
+
+	for tileY := tiles.Min.Y; tileY < tiles.Max.Y; tileY++ {
+		for tileX := tiles.Min.X; tileX < tiles.Max.X; tileX++ {
+			stack := m.tileStackAtKey(tileKey{x: tileX, y: tileY})
+			if stack.Empty() {
+				continue
+			}
+
+			dst = append(dst, TileOccupancy{
+				X:       tileX,
+				Y:       tileY,
+				Units:   stack.Len(),
+				Blocked: m.tileBlockedForMovement(tileX, tileY, 0),
+			})
+		}
+	}
+	return dst
+}
+
+// ProjectileTrajectories returns the remaining route of every projectile registered inside the
+// tile rectangle. It must not run concurrently with Update.
+func (m *Manager) ProjectileTrajectories(tiles image.Rectangle) []ProjectileTrajectory {
+	var trajectories []ProjectileTrajectory
+	for _, current := range m.visibleTileUnits(tiles, false) {
+		projectile, ok := current.(*Projectile)
+		if !ok || projectile.exploding {
+			continue
+		}
+
+		trajectories = append(trajectories, ProjectileTrajectory{
+			UnitID:   projectile.ID,
+			Position: projectile.Position,
+			Path:     append([]geom.Point(nil), projectile.path...),
+		})
+	}
+	return trajectories
+}
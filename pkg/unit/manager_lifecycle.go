@@ -0,0 +1,241 @@
+package unit
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// RemovalReason explains why a unit left the simulation. It travels with the despawn event so
+// hooks and subscribers can tell a combat death apart from a scripted cleanup.
+type RemovalReason string
+
+const (
+	// RemovalReasonKilled marks bodies whose health reached zero in combat.
+	RemovalReasonKilled RemovalReason = "killed"
+	// RemovalReasonExpired marks projectiles that finished their flight, with or without a hit.
+	RemovalReasonExpired RemovalReason = "expired"
+	// RemovalReasonScripted is the default for explicit RemoveUnit calls from scenarios.
+	RemovalReasonScripted RemovalReason = "scripted"
+)
+
+// LifecycleHooks groups the callbacks a scenario may register around unit lifetimes. OnSpawn
+// sees every registered unit including projectiles, OnDeath sees combat kills with the killer
+// in Combat.SourceUnitID, and OnRemove sees every despawn with its Reason filled in. Nil
+// callbacks are skipped.
+type LifecycleHooks struct {
+	OnSpawn  func(GameplayEvent)
+	OnDeath  func(GameplayEvent)
+	OnRemove func(GameplayEvent)
+}
+
+// lifecycleHookSet pairs one registered hook group with the event subscription that buffers
+// its events. Deaths and removals are published from update workers, so hooks never run where
+// the events happen; the manager drains the buffers on the caller goroutine instead.
+type lifecycleHookSet struct {
+	hooks        LifecycleHooks
+	subscription *EventSubscription
+}
+
+// AddLifecycleHooks registers a hook group and returns the function that removes it again.
+// Hooks run on the goroutine that drives the manager, at the end of Update and of every
+// public AddUnit, SpawnUnit and RemoveUnit call, in the order the events were published. A
+// hook may spawn or remove units itself; the resulting events are delivered in the same pass.
+func (m *Manager) AddLifecycleHooks(hooks LifecycleHooks) func() {
+	if m == nil {
+		return func() {}
+	}
+
+	set := &lifecycleHookSet{
+		hooks: hooks,
+		subscription: m.Subscribe(EventFilter{Types: []GameplayEventType{
+			GameplayEventUnitSpawned,
+			GameplayEventUnitKilled,
+			GameplayEventUnitDespawned,
+		}}),
+	}
+	m.lifecycleHooks = append(m.lifecycleHooks, set)
+	return func() {
+		set.subscription.Close()
+		m.lifecycleHooks = slices.DeleteFunc(m.lifecycleHooks, func(current *lifecycleHookSet) bool {
+			return current == set
+		})
+	}
+}
+
+// dispatchLifecycleHooks delivers every buffered lifecycle event. Nested calls from inside a
+// hook return at once because the outer loop keeps draining until all buffers stay empty.
+func (m *Manager) dispatchLifecycleHooks() {
+	if m == nil || len(m.lifecycleHooks) == 0 || m.dispatchingLifecycle {
+		return
+	}
+
+	m.dispatchingLifecycle = true
+	defer func() { m.dispatchingLifecycle = false }()
+
+	for delivered := true; delivered; {
+		delivered = false
+		for _, set := range slices.Clone(m.lifecycleHooks) {
+			for _, event := range set.subscription.Drain() {
+				delivered = true
+				set.deliver(event)
+			}
+		}
+	}
+}
+
+func (s *lifecycleHookSet) deliver(event GameplayEvent) {
+	var hook func(GameplayEvent)
+	switch event.Type {
+	case GameplayEventUnitSpawned:
+		hook = s.hooks.OnSpawn
+	case GameplayEventUnitKilled:
+		hook = s.hooks.OnDeath
+	case GameplayEventUnitDespawned:
+		hook = s.hooks.OnRemove
+	}
+	if hook != nil {
+		hook(event)
+	}
+}
+
+// SpawnUnit builds a unit from the spec through the stock constructor for its kind and
+// registers it. Zero health and spawn-position fields keep the constructor defaults. The spawn
+// is rejected when the position lies outside the world, when its tile already holds a live
+// movement blocker, or when the spec asks for an ID that is still in use.
+func (m *Manager) SpawnUnit(spec UnitSpec) (int64, error) {
+	if m == nil {
+		return 0, fmt.Errorf("unit manager is not initialized")
+	}
+
+	if err := m.validateSpawn(spec.Position, spec.ID); err != nil {
+		return 0, err
+	}
+
+	body, err := NewUnitFromSpec(spec)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.AddUnit(body), nil
+}
+
+// validateSpawn applies the SpawnUnit placement rules to a position and a requested ID, where
+// zero asks for a fresh ID.
+func (m *Manager) validateSpawn(position geom.Point, unitID int64) error {
+	tileX, tileY, ok := m.worldPointToTile(position)
+	if !ok {
+		return fmt.Errorf("spawn point %+v is outside the world", position)
+	}
+	if m.tileBlockedForMovement(tileX, tileY, 0) {
+		return fmt.Errorf("spawn tile (%d, %d) is blocked", tileX, tileY)
+	}
+	if _, exists := m.unitByID(unitID); exists {
+		return fmt.Errorf("unit %d already exists", unitID)
+	}
+
+	return nil
+}
+
+// RemoveUnit takes a live unit out of the simulation right away. Mobile units drop their path
+// and cancel tracked orders exactly like a combat death, then the unit is unregistered from its
+// tile, its slot is released and OnRemove hooks observe the given reason, which defaults to
+// RemovalReasonScripted. It must not be called while Update is running.
+func (m *Manager) RemoveUnit(unitID int64, reason RemovalReason) error {
+	if m == nil {
+		return fmt.Errorf("unit manager is not initialized")
+	}
+
+	body, ok := m.unitByID(unitID)
+	if !ok {
+		return fmt.Errorf("unit %d not found", unitID)
+	}
+	if reason == "" {
+		reason = RemovalReasonScripted
+	}
+	m.recordCommand(Command{
+		Type:   CommandRemoveUnit,
+		UnitID: unitID,
+		Reason: reason,
+	})
+
+	body.Base().removalReason = reason
+	if runner, ok := body.(*NonStaticUnit); ok {
+		runner.prepareForRemovalAfterDeath()
+	} else {
+		body.Base().MarkForRemoval()
+	}
+	m.retireDeletedUnit(body)
+	m.pruneSelection()
+
+	m.dispatchLifecycleHooks()
+	return nil
+}
+
+// TeleportUnit moves a live unit straight to the centre of the tile holding destination. A
+// mobile unit loses its path, its queued move and any in-flight segment and cancels its tracked
+// orders, so it stands still on arrival instead of walking back along the old route. The
+// destination follows the SpawnUnit placement rules, apart from the unit itself not counting as
+// a blocker. It must not be called while Update is running.
+func (m *Manager) TeleportUnit(unitID int64, destination geom.Point) error {
+	if m == nil {
+		return fmt.Errorf("unit manager is not initialized")
+	}
+
+	body, ok := m.unitByID(unitID)
+	if !ok {
+		return fmt.Errorf("unit %d not found", unitID)
+	}
+	if body.UnitKind() == KindProjectile {
+		return fmt.Errorf("unit %d is a projectile", unitID)
+	}
+	tileX, tileY, ok := m.worldPointToTile(destination)
+	if !ok {
+		return fmt.Errorf("teleport point %+v is outside the world", destination)
+	}
+	if m.tileBlockedForMovement(tileX, tileY, unitID) {
+		return fmt.Errorf("teleport tile (%d, %d) is blocked", tileX, tileY)
+	}
+	m.recordCommand(Command{
+		Type:   CommandTeleportUnit,
+		UnitID: unitID,
+		Point:  destination,
+	})
+
+	from := m.tileKeyForUnit(body)
+	if runner, ok := body.(*NonStaticUnit); ok {
+		runner.cancelTrackedOrders()
+		runner.path = runner.path[:0]
+		runner.clearQueuedMove()
+		runner.clearTravel()
+		runner.setSleepTime(0)
+	}
+	body.Base().setPosition(m.tileAnchor(tileX, tileY))
+	m.moveUnitToTile(body, from, tileKey{x: tileX, y: tileY})
+	m.debugExternalAPILogf(
+		"TeleportUnit unit=%d tick=%d from_tile=(%d, %d) to_tile=(%d, %d)",
+		unitID,
+		m.lastGameTick,
+		from.x,
+		from.y,
+		tileX,
+		tileY,
+	)
+
+	m.dispatchLifecycleHooks()
+	return nil
+}
+
+// removalReasonFor resolves the reason reported for a retired unit. Explicit removals carry
+// their own reason; everything else left through combat or, for projectiles, by expiring.
+func removalReasonFor(unit Unit) RemovalReason {
+	if reason := unit.Base().removalReason; reason != "" {
+		return reason
+	}
+	if unit.UnitKind() == KindProjectile {
+		return RemovalReasonExpired
+	}
+
+	return RemovalReasonKilled
+}
@@ -0,0 +1,199 @@
+package unit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// tickDurationBounds are the upper bounds of the tick duration histogram buckets. They cover a
+// comfortable 60 TPS tick up to a stalled multi-frame tick, with one overflow bucket on top.
+var tickDurationBounds = []time.Duration{
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2 * time.Millisecond,
+	4 * time.Millisecond,
+	8 * time.Millisecond,
+	16 * time.Millisecond,
+	32 * time.Millisecond,
+	64 * time.Millisecond,
+	128 * time.Millisecond,
+}
+
+// DurationHistogram is a fixed-bucket histogram. Counts has one entry per bound plus a final
+// overflow bucket, and each entry counts only the observations of its own bucket.
+type DurationHistogram struct {
+	Bounds []time.Duration `json:"bounds_ns"`
+	Counts []int64         `json:"counts"`
+	Count  int64           `json:"count"`
+	Sum    time.Duration   `json:"sum_ns"`
+}
+
+func newDurationHistogram(bounds []time.Duration) DurationHistogram {
+	return DurationHistogram{
+		Bounds: bounds,
+		Counts: make([]int64, len(bounds)+1),
+	}
+}
+
+func (h *DurationHistogram) observe(value time.Duration) {
+	bucket, _ := slices.BinarySearch(h.Bounds, value)
+	h.Counts[bucket]++
+	h.Count++
+	h.Sum += value
+}
+
+func (h DurationHistogram) clone() DurationHistogram {
+	h.Bounds = slices.Clone(h.Bounds)
+	h.Counts = slices.Clone(h.Counts)
+	return h
+}
+
+// MetricsSnapshot is the state of the manager metrics after the last completed Update. Gauges
+// such as unit counts describe that tick; orders, pathfinding and the tick histogram are
+// cumulative since the manager was built, so charts take rates between two samples.
+type MetricsSnapshot struct {
+	Tick           int64             `json:"tick"`
+	UnitsByKind    map[Kind]int      `json:"units_by_kind"`
+	AwakeUnits     int               `json:"awake_units"`
+	SleepingUnits  int               `json:"sleeping_units"`
+	Projectiles    int               `json:"projectiles"`
+	TileStacks     int               `json:"tile_stacks"`
+	OrdersByStatus map[string]int64  `json:"orders_by_status"`
+	Pathfinding    PathfindingStats  `json:"pathfinding"`
+	TickDuration   DurationHistogram `json:"tick_duration"`
+}
+
+// managerMetrics is the registry behind Manager.Metrics. Order reports are counted with atomics
+// because update workers emit them; everything else is collected once per Update on the
+// goroutine that drives the manager and published under the mutex, so an HTTP scrape on another
+// goroutine never walks live unit storage.
+type managerMetrics struct {
+	orderReports [OrderCanceled + 1]atomic.Int64
+
+	mu       sync.Mutex
+	snapshot MetricsSnapshot
+	ticks    DurationHistogram
+}
+
+func (m *managerMetrics) countOrderReport(status OrderStatus) {
+	if int(status) < len(m.orderReports) {
+		m.orderReports[status].Add(1)
+	}
+}
+
+// Metrics returns a copy of the metrics published by the last Update. It is safe to call from
+// any goroutine.
+func (m *Manager) Metrics() MetricsSnapshot {
+	if m == nil {
+		return MetricsSnapshot{}
+	}
+
+	m.metrics.mu.Lock()
+	defer m.metrics.mu.Unlock()
+
+	snapshot := m.metrics.snapshot
+	snapshot.UnitsByKind = maps.Clone(snapshot.UnitsByKind)
+	snapshot.OrdersByStatus = maps.Clone(snapshot.OrdersByStatus)
+	snapshot.TickDuration = m.metrics.ticks.clone()
+	return snapshot
+}
+
+// recordTickMetrics publishes the per-tick gauges together with the duration of the Update that
+// just finished. Population and tile-stack gauges come from counters kept by the storage
+// itself, so the cost does not grow with large static obstacle fields. It must run after the
+// workers finished and before the awake set is reused.
+func (m *Manager) recordTickMetrics(duration time.Duration) {
+	columns := &m.units.columns
+	unitsByKind := make(map[Kind]int, len(unitKinds))
+	live := 0
+	for index, kind := range unitKinds {
+		count := int(columns.kindCounts[index].Load())
+		unitsByKind[kind] = count
+		live += count
+	}
+
+	awake := 0
+	for _, slot := range m.scheduler.awake {
+		if flags := columns.flags[slot]; flags&slotOccupied != 0 && flags&slotPendingRemoval == 0 {
+			awake++
+		}
+	}
+
+	ordersByStatus := make(map[string]int64, len(m.metrics.orderReports))
+	for status := range m.metrics.orderReports {
+		ordersByStatus[OrderStatus(status).String()] = m.metrics.orderReports[status].Load()
+	}
+
+	m.metrics.mu.Lock()
+	defer m.metrics.mu.Unlock()
+
+	m.metrics.ticks.observe(duration)
+	m.metrics.snapshot = MetricsSnapshot{
+		Tick:           m.lastGameTick,
+		UnitsByKind:    unitsByKind,
+		AwakeUnits:     awake,
+		SleepingUnits:  max(live-awake, 0),
+		Projectiles:    unitsByKind[KindProjectile],
+		TileStacks:     int(m.tileRegistry.stacks.Load()),
+		OrdersByStatus: ordersByStatus,
+		Pathfinding:    m.PathfindingStats(),
+	}
+}
+
+// WritePrometheus renders the snapshot in the Prometheus text exposition format. Kinds and
+// statuses are written in a fixed order so consecutive scrapes diff cleanly.
+func (s MetricsSnapshot) WritePrometheus(w io.Writer) error {
+	out := bufio.NewWriter(w)
+
+	writeMetricHeader(out, "endless_tick", "gauge", "Last completed simulation tick.")
+	fmt.Fprintf(out, "endless_tick %d\n", s.Tick)
+
+	writeMetricHeader(out, "endless_units", "gauge", "Live units by kind.")
+	for _, kind := range slices.Sorted(maps.Keys(s.UnitsByKind)) {
+		fmt.Fprintf(out, "endless_units{kind=%q} %d\n", kind, s.UnitsByKind[kind])
+	}
+
+	writeMetricHeader(out, "endless_units_awake", "gauge", "Live units visited by the last update.")
+	fmt.Fprintf(out, "endless_units_awake %d\n", s.AwakeUnits)
+	writeMetricHeader(out, "endless_units_sleeping", "gauge", "Live units skipped by the last update.")
+	fmt.Fprintf(out, "endless_units_sleeping %d\n", s.SleepingUnits)
+	writeMetricHeader(out, "endless_projectiles", "gauge", "Live projectiles.")
+	fmt.Fprintf(out, "endless_projectiles %d\n", s.Projectiles)
+	writeMetricHeader(out, "endless_tile_stacks", "gauge", "Occupied tile stacks.")
+	fmt.Fprintf(out, "endless_tile_stacks %d\n", s.TileStacks)
+
+	writeMetricHeader(out, "endless_order_reports_total", "counter", "Order reports by status.")
+	for _, status := range slices.Sorted(maps.Keys(s.OrdersByStatus)) {
+		fmt.Fprintf(out, "endless_order_reports_total{status=%q} %d\n", status, s.OrdersByStatus[status])
+	}
+
+	writeMetricHeader(out, "endless_pathfinding_calls_total", "counter", "Path searches started by move orders.")
+	fmt.Fprintf(out, "endless_pathfinding_calls_total %d\n", s.Pathfinding.Calls)
+	writeMetricHeader(out, "endless_pathfinding_failures_total", "counter", "Path searches that found no route.")
+	fmt.Fprintf(out, "endless_pathfinding_failures_total %d\n", s.Pathfinding.Failures)
+	writeMetricHeader(out, "endless_pathfinding_seconds_total", "counter", "Time spent in path searches.")
+	fmt.Fprintf(out, "endless_pathfinding_seconds_total %g\n", s.Pathfinding.Time.Seconds())
+
+	writeMetricHeader(out, "endless_tick_duration_seconds", "histogram", "Wall-clock duration of manager updates.")
+	cumulative := int64(0)
+	for index, bound := range s.TickDuration.Bounds {
+		cumulative += s.TickDuration.Counts[index]
+		fmt.Fprintf(out, "endless_tick_duration_seconds_bucket{le=\"%g\"} %d\n", bound.Seconds(), cumulative)
+	}
+	fmt.Fprintf(out, "endless_tick_duration_seconds_bucket{le=\"+Inf\"} %d\n", s.TickDuration.Count)
+	fmt.Fprintf(out, "endless_tick_duration_seconds_sum %g\n", s.TickDuration.Sum.Seconds())
+	fmt.Fprintf(out, "endless_tick_duration_seconds_count %d\n", s.TickDuration.Count)
+
+	return out.Flush()
+}
+
+func writeMetricHeader(out *bufio.Writer, name, metricType, help string) {
+	fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
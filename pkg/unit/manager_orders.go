@@ -3,6 +3,7 @@ package unit
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/unng-lab/endless/pkg/geom"
 	"github.com/unng-lab/endless/pkg/pathfinding"
@@ -13,6 +14,7 @@ import (
 // route immediately so later execution can start from a stable path snapshot even if callers
 // issue another order before the unit reaches its next tile-boundary handoff point.
 func (m *Manager) IssueMoveOrder(unitID int64, targetPoint geom.Point) error {
+	m.recordCommand(Command{Type: CommandMoveOrder, UnitID: unitID, Point: targetPoint})
 	current, ok := m.unitByID(unitID)
 	if !ok {
 		report := m.failedMoveOrderReport(unitID, targetPoint)
@@ -47,6 +49,22 @@ func (m *Manager) IssueMoveOrder(unitID int64, targetPoint geom.Point) error {
 		return err
 	}
 
+	if body.holdPosition {
+		report := m.failedMoveOrderReport(unitID, targetPoint)
+		m.appendBufferedOrderReport(report)
+		err := fmt.Errorf("unit %d is holding position", unitID)
+		m.debugExternalAPILogf(
+			"IssueMoveOrder move unit=%d tick=%d target=(%.1f, %.1f) accepted=false order_id=%d err=%q",
+			unitID,
+			m.lastGameTick,
+			targetPoint.X,
+			targetPoint.Y,
+			report.OrderID,
+			err,
+		)
+		return err
+	}
+
 	targetTileX, targetTileY, ok := m.worldPointToTile(targetPoint)
 	if !ok {
 		report := m.failedMoveOrderReport(unitID, targetPoint)
@@ -72,12 +90,21 @@ func (m *Manager) IssueMoveOrder(unitID int64, targetPoint geom.Point) error {
 		manager:       m,
 		ignoredUnitID: unitID,
 	}
-	path, err := pathfinding.FindPath(
-		grid,
-		pathfinding.Step{X: pathStartTileX, Y: pathStartTileY},
-		pathfinding.Step{X: targetTileX, Y: targetTileY},
-	)
+	searchStart := pathfinding.Step{X: pathStartTileX, Y: pathStartTileY}
+	searchGoal := pathfinding.Step{X: targetTileX, Y: targetTileY}
+	var trace *pathfinding.Trace
+	if m.pathSearchTracing {
+		trace = &pathfinding.Trace{}
+	}
+	searchStartedAt := time.Now()
+	path, err := pathfinding.FindPathTraced(grid, searchStart, searchGoal, trace)
+	m.pathfindingNanos.Add(int64(time.Since(searchStartedAt)))
+	m.pathfindingCalls.Add(1)
+	if trace != nil {
+		m.recordPathSearch(unitID, searchStart, searchGoal, trace, path, err)
+	}
 	if err != nil {
+		m.pathfindingFailures.Add(1)
 		report := m.failedMoveOrderReport(unitID, canonicalTarget)
 		m.appendBufferedOrderReport(report)
 		m.debugExternalAPILogf(
@@ -126,6 +153,7 @@ func (m *Manager) IssueMoveOrder(unitID int64, targetPoint geom.Point) error {
 // IssueFireOrder accepts one delayed fire command. The direction is normalized up front so
 // queued reports and later execution use the same canonical direction vector.
 func (m *Manager) IssueFireOrder(unitID int64, direction geom.Point) error {
+	m.recordCommand(Command{Type: CommandFireOrder, UnitID: unitID, Point: direction})
 	current, ok := m.unitByID(unitID)
 	if !ok {
 		report := m.failedFireOrderReport(unitID, direction)
@@ -196,6 +224,75 @@ func (m *Manager) IssueFireOrder(unitID int64, direction geom.Point) error {
 	return nil
 }
 
+// IssueStopOrder makes one mobile unit stop where it stands. It is queued like a move order
+// without a route, so a unit between two tiles still finishes the step it started, a running
+// fire wind-up still releases its shot, and an order queued earlier is replaced.
+func (m *Manager) IssueStopOrder(unitID int64) error {
+	m.recordCommand(Command{Type: CommandStopOrder, UnitID: unitID})
+	body, err := m.mobileUnit(unitID)
+	if err != nil {
+		report := m.failedMoveOrderReport(unitID, geom.Point{})
+		m.appendBufferedOrderReport(report)
+		m.debugExternalAPILogf("IssueStopOrder unit=%d tick=%d accepted=false order_id=%d err=%q", unitID, m.lastGameTick, report.OrderID, err)
+		return err
+	}
+
+	orderID := m.queueStop(body)
+	m.debugExternalAPILogf("IssueStopOrder unit=%d tick=%d accepted=true order_id=%d", unitID, m.lastGameTick, orderID)
+	return nil
+}
+
+// IssueHoldOrder switches hold position on or off for one mobile unit. Holding stops the unit
+// like IssueStopOrder and then rejects move orders until the hold is released, which keeps it
+// in place against scripted jobs; fire orders are still accepted. Asking for the state the unit
+// is already in changes nothing and is not recorded, so a group release only records the units
+// that held. A rejected order is reported like a rejected stop.
+func (m *Manager) IssueHoldOrder(unitID int64, hold bool) error {
+	body, err := m.mobileUnit(unitID)
+	if err == nil && body.holdPosition == hold {
+		return nil
+	}
+
+	m.recordCommand(Command{Type: CommandHoldOrder, UnitID: unitID, Hold: hold})
+	if err != nil {
+		report := m.failedMoveOrderReport(unitID, geom.Point{})
+		m.appendBufferedOrderReport(report)
+		m.debugExternalAPILogf("IssueHoldOrder unit=%d tick=%d hold=%t accepted=false order_id=%d err=%q", unitID, m.lastGameTick, hold, report.OrderID, err)
+		return err
+	}
+
+	body.holdPosition = hold
+	if hold {
+		m.queueStop(body)
+	}
+	m.debugExternalAPILogf("IssueHoldOrder unit=%d tick=%d hold=%t accepted=true", unitID, m.lastGameTick, hold)
+	return nil
+}
+
+// queueStop queues a move order without a route towards the tile the unit occupies or is
+// stepping into, and returns its order ID.
+func (m *Manager) queueStop(body *NonStaticUnit) int64 {
+	order := moveOrder{
+		ID:          m.nextIssuedOrderID(),
+		UnitID:      body.UnitID(),
+		TargetPoint: body.Position,
+	}
+	body.queueMoveOrder(m.lastGameTick, order)
+	return order.ID
+}
+
+func (m *Manager) mobileUnit(unitID int64) (*NonStaticUnit, error) {
+	current, ok := m.unitByID(unitID)
+	if !ok {
+		return nil, fmt.Errorf("unit %d not found", unitID)
+	}
+	body, ok := current.(*NonStaticUnit)
+	if !ok || !body.IsMobile() {
+		return nil, fmt.Errorf("unit %d is immobile", unitID)
+	}
+	return body, nil
+}
+
 // DrainUnitOrderReports returns every order lifecycle event currently associated with one
 // concrete unit. Reports normally stay owned by the unit itself, but the manager keeps a
 // buffered tail for acceptance-time failures or for units that were already removed before
@@ -314,6 +411,8 @@ func (m *Manager) appendBufferedOrderReport(report OrderReport) {
 	}
 
 	m.appendBufferedOrderReports(report.UnitID, []OrderReport{report})
+	owner, _ := m.units.Get(report.UnitID)
+	m.publishOrderReport(owner, report)
 }
 
 // drainBufferedOrderReports returns and clears the manager-owned report tail for one unit.
@@ -17,17 +17,6 @@ func (m *Manager) tileSpeedMultiplierAt(position geom.Point) float64 {
 	return m.world.TileType(tileX, tileY).SpeedMultiplier()
 }
 
-func (m *Manager) ensureTileStackLocked(key tileKey) *TileStack {
-	stack, ok := m.tileStacks[key]
-	if ok {
-		return stack
-	}
-
-	stack = &TileStack{}
-	m.tileStacks[key] = stack
-	return stack
-}
-
 // registerUnitInCurrentTile binds a unit to the stack of the tile that matches its current
 // logical position. The helper is used for initial seeding and for units added at runtime.
 func (m *Manager) registerUnitInCurrentTile(unit Unit) {
@@ -35,12 +24,7 @@ func (m *Manager) registerUnitInCurrentTile(unit Unit) {
 		return
 	}
 
-	key := m.tileKeyForUnit(unit)
-	m.tileRegistryMu.Lock()
-	stack := m.ensureTileStackLocked(key)
-	unit.EnterTile(stack)
-	m.registeredTiles[unit.UnitID()] = key
-	m.tileRegistryMu.Unlock()
+	m.tileRegistry.register(unit, m.tileKeyForUnit(unit))
 }
 
 // bindUnitRuntimeDependencies installs manager-owned resolvers once at registration time so
@@ -62,32 +46,19 @@ func (m *Manager) bindUnitRuntimeDependencies(unit Unit) {
 	body.SetDebugRuntimeLogger(func(format string, args ...any) {
 		m.debugUnitRuntimeLogf(format, args...)
 	})
+	body.SetOrderReportSink(func(report OrderReport) {
+		m.publishOrderReport(body, report)
+	})
 }
 
-func (m *Manager) unregisterUnitFromTile(unit Unit, key tileKey) {
-	m.tileRegistryMu.Lock()
-	defer m.tileRegistryMu.Unlock()
-
-	m.unregisterUnitFromTileLocked(unit, key)
-}
-
-func (m *Manager) unregisterUnitFromTileLocked(unit Unit, key tileKey) {
-	stack := m.tileStacks[key]
-	if stack == nil {
-		return
-	}
-
-	unit.LeaveTile(stack)
-	m.dropEmptyTileStackLocked(key, stack)
-	delete(m.registeredTiles, unit.UnitID())
+// unregisterUnitFromTile drops the unit from the tile it is registered in, if any.
+func (m *Manager) unregisterUnitFromTile(unit Unit) {
+	m.tileRegistry.unregister(unit)
 }
 
-func (m *Manager) dropEmptyTileStackLocked(key tileKey, stack *TileStack) {
-	if stack == nil || !stack.Empty() {
-		return
-	}
-
-	delete(m.tileStacks, key)
+// registeredTileKey reports the tile the manager currently keeps the unit registered in.
+func (m *Manager) registeredTileKey(unitID int64) (tileKey, bool) {
+	return m.tileRegistry.registeredTile(unitID)
 }
 
 func (m *Manager) tileKeyForUnit(unit Unit) tileKey {
@@ -96,32 +67,18 @@ func (m *Manager) tileKeyForUnit(unit Unit) tileKey {
 }
 
 // moveUnitToTile applies the explicit leave/enter sequence at the moment the logical tile
-// changes. TileStack methods serialize membership edits per tile, while the registry mutex
-// protects the sparse tile map and the unit-to-tile lookup table.
+// changes. TileStack methods serialize membership edits per tile, while the sharded registry
+// locks only the unit's lookup shard and the regions of the two tiles involved.
 func (m *Manager) moveUnitToTile(unit Unit, from tileKey, to tileKey) {
 	if unit == nil || from == to || !unitUsesTileStack(unit) {
 		return
 	}
 
-	var currentStack *TileStack
-	m.tileRegistryMu.Lock()
-	if registeredKey, isRegistered := m.registeredTiles[unit.UnitID()]; isRegistered {
-		from = registeredKey
-	}
-	if from == to {
-		m.tileRegistryMu.Unlock()
+	currentStack := m.tileRegistry.move(unit, from, to)
+	if currentStack == nil {
 		return
 	}
-
-	if previousStack := m.tileStacks[from]; previousStack != nil {
-		unit.LeaveTile(previousStack)
-		m.dropEmptyTileStackLocked(from, previousStack)
-	}
-
-	currentStack = m.ensureTileStackLocked(to)
-	unit.EnterTile(currentStack)
-	m.registeredTiles[unit.UnitID()] = to
-	m.tileRegistryMu.Unlock()
+	m.publishTileEntered(unit, to)
 
 	body, ok := unit.(tileEntryReactiveUnit)
 	if !ok {
@@ -220,14 +177,17 @@ func (m *Manager) updateVisibleUnit(unit Unit) {
 
 // firstProjectileOccupant resolves hits through the tile stack the projectile has just entered.
 // Iterating the stack snapshot keeps the old "check every unit in that tile" behavior while
-// moving the broad-phase lookup away from a full scan over every unit in the scene.
+// moving the broad-phase lookup away from a full scan over every unit in the scene. Bodies
+// that entered the tile on the same step arrive in worker order, so the hit goes to the
+// lowest ID rather than to whichever of them reached the stack first.
 func (m *Manager) firstProjectileOccupant(stack *TileStack, ownerID int64) (Unit, bool) {
 	if stack == nil {
 		return nil, false
 	}
 
+	var target Unit
 	for _, unitID := range stack.UnitIDs() {
-		if unitID == ownerID {
+		if unitID == ownerID || (target != nil && unitID > target.UnitID()) {
 			continue
 		}
 
@@ -240,10 +200,10 @@ func (m *Manager) firstProjectileOccupant(stack *TileStack, ownerID int64) (Unit
 			continue
 		}
 
-		return currentUnit, true
+		target = currentUnit
 	}
 
-	return nil, false
+	return target, target != nil
 }
 
 // unitUsesTileStack centralizes which bodies participate in tile-local ordering. Projectiles
@@ -254,10 +214,7 @@ func unitUsesTileStack(unit Unit) bool {
 }
 
 func (m *Manager) tileStackAtKey(key tileKey) *TileStack {
-	m.tileRegistryMu.RLock()
-	stack := m.tileStacks[key]
-	m.tileRegistryMu.RUnlock()
-	return stack
+	return m.tileRegistry.stackAt(key)
 }
 
 // tileBlockedForMovement reports whether the specified tile currently contains a live unit that
@@ -273,21 +230,7 @@ func (m *Manager) tileBlockedForMovement(tileX, tileY int, ignoredUnitID int64)
 		return false
 	}
 
-	for _, unitID := range stack.UnitIDs() {
-		if unitID == ignoredUnitID {
-			continue
-		}
-
-		current, ok := m.unitByID(unitID)
-		if !ok || current == nil || !current.Alive() {
-			continue
-		}
-		if !current.BlocksMovement() {
-			continue
-		}
-
-		return true
-	}
-
-	return false
+	return stack.anyUnit(func(unitID int64) bool {
+		return unitID != ignoredUnitID && m.units.BlocksMovement(unitID)
+	})
 }
@@ -1,25 +1,46 @@
 package unit
 
 import (
+	"cmp"
 	"log"
 	"runtime"
+	"slices"
+	"time"
 )
 
+// Update advances every unit that has work on this step. The tick scheduler hands out only
+// the slots that are due, so sleeping mobiles and parked static bodies cost nothing until
+// their sleep ends or something wakes them. An empty manager skips only the unit pass: metrics,
+// corpses and lifecycle hooks keep advancing after the last unit is gone.
 func (m *Manager) Update(gameTick int64) {
+	startedAt := time.Now()
 	m.lastGameTick = gameTick
-	if m.units.SlotsLen() == 0 {
-		return
-	}
-
-	for i := range m.workers {
-		m.updateWG.Add(1)
-		m.workers[i] <- gameTick
+	if m.units.SlotsLen() > 0 {
+		m.updateUnits(gameTick)
 	}
-	m.updateWG.Wait()
 	m.flushPendingSpawns()
-	if _, ok := m.selectedUnit(); !ok {
-		m.selectedID = 0
+	m.pruneSelection()
+	m.pruneCorpses()
+	m.recordTickMetrics(time.Since(startedAt))
+	m.dispatchLifecycleHooks()
+}
+
+// updateUnits hands the due slots to the workers and reschedules them afterwards. A worker only
+// mutates the slots it was handed; changes to other units, such as projectile damage, are
+// queued and applied here once every worker has finished, after the events the workers raised.
+func (m *Manager) updateUnits(gameTick int64) {
+	if awake := m.scheduler.advance(&m.units.columns); len(awake) > 0 {
+		m.stepping = true
+		for i := range m.workers {
+			m.updateWG.Add(1)
+			m.workers[i] <- gameTick
+		}
+		m.updateWG.Wait()
+		m.stepping = false
 	}
+	m.flushStagedEvents()
+	m.applyPendingHits()
+	m.scheduler.rescheduleAwake(&m.units.columns)
 }
 
 // Close shuts down the background worker pool that powers unit updates. The RL headless
@@ -38,44 +59,48 @@ func (m *Manager) Close() {
 	})
 }
 
-func (m *Manager) startWorkers() {
-	workerCount := runtime.GOMAXPROCS(0) / 4
+func (m *Manager) startWorkers(workerCount int) {
+	if workerCount <= 0 {
+		workerCount = runtime.GOMAXPROCS(0) / 4
+	}
 	if workerCount < 1 {
 		workerCount = 1
 	}
 	log.Printf("[startup] units: starting %d update workers", workerCount)
 
+	m.scheduler = newTickScheduler(workerCount, m.world.TileSize())
+	m.units.columns.scheduler = m.scheduler
 	m.workers = make([]chan int64, 0, workerCount)
 	for i := range workerCount {
 		ch := make(chan int64, 1)
 		m.workers = append(m.workers, ch)
-		go m.workerRun(i, workerCount, ch)
+		go m.workerRun(i, ch)
 	}
 }
 
-func (m *Manager) workerRun(offset, workerCount int, updates <-chan int64) {
+func (m *Manager) workerRun(worker int, updates <-chan int64) {
 	for req := range updates {
-		m.processUpdates(offset, workerCount, req)
+		m.processUpdates(worker, req)
 	}
 }
 
-func (m *Manager) processUpdates(offset, workerCount int, req int64) {
+// processUpdates drains the worker's own spatial partition first and then steals batches from
+// the other partitions until every due slot of the step has been claimed.
+func (m *Manager) processUpdates(worker int, req int64) {
 	defer m.updateWG.Done()
 
-	stride := workerCount * updateBatchSize
-	for blockStart := offset * updateBatchSize; blockStart < m.units.SlotsLen(); blockStart += stride {
-		for j := 0; j < updateBatchSize; j++ {
-			idx := blockStart + j
-			if idx >= m.units.SlotsLen() {
-				break
+	partitions := len(m.scheduler.partitions)
+	for offset := range partitions {
+		partition := (worker + offset) % partitions
+		for batch := m.scheduler.takeBatch(partition); len(batch) > 0; batch = m.scheduler.takeBatch(partition) {
+			for _, slot := range batch {
+				current, ok := m.units.SlotAt(int(slot))
+				if !ok {
+					continue
+				}
+
+				m.tickUnit(current, req)
 			}
-
-			current, ok := m.units.SlotAt(idx)
-			if !ok {
-				continue
-			}
-
-			m.tickUnit(current, req)
 		}
 	}
 }
@@ -85,7 +110,14 @@ func (m *Manager) tickUnit(unit Unit, gameTick int64) {
 		return
 	}
 
-	m.advanceUnitWeaponCooldown(unit)
+	wasMoving := m.unitMovingForEvents(unit)
+	m.tickUnitState(unit, gameTick)
+	m.publishMovementTransition(unit, wasMoving)
+}
+
+// tickUnitState runs the per-unit update pipeline. tickUnit wraps it so movement start and stop
+// edges are observed once around every early return instead of at each exit separately.
+func (m *Manager) tickUnitState(unit Unit, gameTick int64) {
 	if m.retireUnitIfDeleted(unit) {
 		return
 	}
@@ -109,17 +141,6 @@ func (m *Manager) tickUnit(unit Unit, gameTick int64) {
 	m.reconcileUnitTileRegistration(unit, previousTileX, previousTileY)
 }
 
-// advanceUnitWeaponCooldown spends cooldown budget before any early returns in tickUnit so
-// weapon readiness remains tied to wall-clock simulation ticks even for sleeping units.
-func (m *Manager) advanceUnitWeaponCooldown(unit Unit) {
-	body, ok := unit.(*NonStaticUnit)
-	if !ok {
-		return
-	}
-
-	body.advanceWeaponCooldown()
-}
-
 // retireUnitIfDeleted centralizes the tombstone fast path so tickUnit can short-circuit at the
 // beginning and right after Tick with the same deferred cleanup rule.
 func (m *Manager) retireUnitIfDeleted(unit Unit) bool {
@@ -131,24 +152,25 @@ func (m *Manager) retireUnitIfDeleted(unit Unit) bool {
 	return true
 }
 
-// skipSleepingUnit advances sleep counters that are managed outside concrete Tick methods and
-// reports whether the unit should be skipped for the current manager update.
+// skipSleepingUnit reports whether the unit should be skipped for the current manager update.
+// Sleep counters settle lazily in the slot columns, so by the time a sleeping unit is visited
+// its budget already reflects the current step. A projectile whose last flight segment ended on
+// this step and has nowhere left to go is retired here instead of running one more Tick.
 func (m *Manager) skipSleepingUnit(unit Unit) bool {
 	if unit.Base().UpdateSleeping() {
 		return true
 	}
-	if unit.Base().SleepTime() == 0 {
-		return false
+	if unit.Base().SleepTime() > 0 {
+		return true
 	}
 
-	unit.Base().StepSleep()
-	if projectile, ok := unit.(*Projectile); ok && !projectile.IsActive() {
+	if projectile, ok := unit.(*Projectile); ok && projectile.sleepEndedThisStep() && !projectile.IsActive() {
 		projectile.MarkForRemoval()
 		m.retireDeletedUnit(projectile)
 		return true
 	}
 
-	return unit.Base().SleepTime() > 0
+	return false
 }
 
 // reconcileUnitTileRegistration updates tile membership only when a tick changed the logical
@@ -181,12 +203,7 @@ func (m *Manager) retireDeletedUnit(unit Unit) {
 		m.appendBufferedOrderReports(unit.UnitID(), reporter.drainOrderReports())
 	}
 
-	m.tileRegistryMu.RLock()
-	registeredKey, isRegistered := m.registeredTiles[unit.UnitID()]
-	m.tileRegistryMu.RUnlock()
-	if isRegistered {
-		m.unregisterUnitFromTile(unit, registeredKey)
-	}
+	m.unregisterUnitFromTile(unit)
 	if projectile, ok := unit.(*Projectile); ok && !projectile.hitOccurred {
 		m.appendCombatEvent(CombatEvent{
 			Tick:             m.lastGameTick,
@@ -198,6 +215,7 @@ func (m *Manager) retireDeletedUnit(unit Unit) {
 			Killed:           false,
 		})
 	}
+	m.publishUnitDespawned(unit, removalReasonFor(unit))
 	unit.Base().MarkRemovalHandled()
 	m.units.ReleaseDeletedSlot(unit.UnitID())
 }
@@ -243,8 +261,14 @@ func (m *Manager) flushPendingSpawns() {
 	m.pendingSpawns = m.pendingSpawns[:0]
 	m.pendingSpawnsMu.Unlock()
 
+	// Workers queue their shots in whatever order they finish, so the shots are registered by
+	// owner ID to hand out the same projectile IDs for every worker count.
+	slices.SortStableFunc(pending, func(a, b Unit) int {
+		return cmp.Compare(spawnOwnerID(a), spawnOwnerID(b))
+	})
+
 	for _, current := range pending {
-		unitID := m.AddUnit(current)
+		unitID := m.addUnit(current)
 		projectile, ok := current.(*Projectile)
 		if !ok {
 			continue
@@ -262,3 +286,10 @@ func (m *Manager) flushPendingSpawns() {
 		})
 	}
 }
+
+func spawnOwnerID(spawned Unit) int64 {
+	if projectile, ok := spawned.(*Projectile); ok {
+		return projectile.OwnerID
+	}
+	return 0
+}
@@ -0,0 +1,421 @@
+package unit
+
+import (
+	"image"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/unng-lab/endless/pkg/camera"
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// SelectionMode decides how a pick combines with the current selection.
+type SelectionMode int
+
+const (
+	// SelectionReplace drops the current selection and keeps only the picked units.
+	SelectionReplace SelectionMode = iota
+	// SelectionAdd keeps the current selection and adds the picked units to it.
+	SelectionAdd
+	// SelectionToggle removes picked units that are already selected and adds the others, which
+	// is what shift-click does in most strategy games.
+	SelectionToggle
+)
+
+// unitSelection is the ordered set of selected unit IDs. The order is the order in which units
+// were picked, so group commands and highlights stay deterministic, and the set answers the
+// membership checks of toggling and drawing without a scan.
+type unitSelection struct {
+	ids     []int64
+	members map[int64]struct{}
+}
+
+func (s *unitSelection) contains(unitID int64) bool {
+	_, ok := s.members[unitID]
+	return ok
+}
+
+func (s *unitSelection) add(unitID int64) {
+	if s.contains(unitID) {
+		return
+	}
+	if s.members == nil {
+		s.members = make(map[int64]struct{})
+	}
+
+	s.members[unitID] = struct{}{}
+	s.ids = append(s.ids, unitID)
+}
+
+func (s *unitSelection) remove(unitID int64) {
+	if !s.contains(unitID) {
+		return
+	}
+
+	delete(s.members, unitID)
+	s.ids = slices.DeleteFunc(s.ids, func(current int64) bool { return current == unitID })
+}
+
+func (s *unitSelection) clear() {
+	s.ids = s.ids[:0]
+	clear(s.members)
+}
+
+// SelectedIDs returns the IDs of every selected live unit in pick order. The primary unit, the
+// one the info panel describes, is always part of the result.
+func (m *Manager) SelectedIDs() []int64 {
+	if m == nil {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(m.selection.ids)+1)
+	for _, unitID := range m.selectionIDs() {
+		if _, ok := m.unitByID(unitID); ok {
+			ids = append(ids, unitID)
+		}
+	}
+	return ids
+}
+
+// SelectionCount reports how many live units are selected.
+func (m *Manager) SelectionCount() int {
+	return len(m.SelectedIDs())
+}
+
+// ClearSelection drops every selected unit.
+func (m *Manager) ClearSelection() {
+	if m == nil {
+		return
+	}
+
+	m.selection.clear()
+	m.selectedID = 0
+}
+
+// SelectAtScreenMode picks the topmost selectable unit of the tile under the cursor and combines
+// it with the current selection according to mode. Clicking empty ground only clears the
+// selection in replace mode, so a slipped shift-click never loses a carefully built group.
+func (m *Manager) SelectAtScreenMode(cam *camera.Camera, cursor geom.Point, screenWidth, screenHeight int, mode SelectionMode) {
+	if m.PointInPanel(cam, cursor, screenWidth, screenHeight) {
+		return
+	}
+
+	var picked []Unit
+	if cam != nil {
+		if worldPos := cam.ScreenToWorld(cursor); m.pointInWorld(worldPos) {
+			if candidates := m.selectableUnitsFromStack(m.stackAtWorldPoint(worldPos)); len(candidates) > 0 {
+				picked = candidates[len(candidates)-1:]
+			}
+		}
+	}
+
+	m.applySelection(picked, mode)
+}
+
+// SelectInScreenRect selects the units standing on every tile touched by the screen rectangle
+// spanned by the two corners, which may be given in any order. When the rectangle holds at least
+// one mobile unit the static obstacles inside it are left out, so dragging across a wall line
+// picks the runners rather than the bricks. It returns how many units the rectangle picked.
+func (m *Manager) SelectInScreenRect(cam *camera.Camera, from, to geom.Point, mode SelectionMode) int {
+	if m == nil || cam == nil {
+		return 0
+	}
+
+	worldFrom := cam.ScreenToWorld(from)
+	worldTo := cam.ScreenToWorld(to)
+	tileSize := m.world.TileSize()
+	minX := max(int(math.Floor(math.Min(worldFrom.X, worldTo.X)/tileSize)), 0)
+	minY := max(int(math.Floor(math.Min(worldFrom.Y, worldTo.Y)/tileSize)), 0)
+	maxX := min(int(math.Floor(math.Max(worldFrom.X, worldTo.X)/tileSize)), m.world.Columns()-1)
+	maxY := min(int(math.Floor(math.Max(worldFrom.Y, worldTo.Y)/tileSize)), m.world.Rows()-1)
+
+	var picked []Unit
+	mobile := false
+	for tileY := minY; tileY <= maxY; tileY++ {
+		for tileX := minX; tileX <= maxX; tileX++ {
+			for _, current := range m.selectableUnitsFromStack(m.tileStackAtKey(tileKey{x: tileX, y: tileY})) {
+				mobile = mobile || current.IsMobile()
+				picked = append(picked, current)
+			}
+		}
+	}
+	if mobile {
+		picked = slices.DeleteFunc(picked, func(current Unit) bool { return !current.IsMobile() })
+	}
+
+	m.applySelection(picked, mode)
+	return len(picked)
+}
+
+// SelectAllOfKind selects every live selectable unit of the kind in registration order and
+// returns how many were picked.
+func (m *Manager) SelectAllOfKind(kind Kind, mode SelectionMode) int {
+	if m == nil {
+		return 0
+	}
+
+	var picked []Unit
+	m.units.Range(func(current Unit) bool {
+		if current.UnitKind() == kind && current.Selectable() {
+			picked = append(picked, current)
+		}
+		return true
+	})
+
+	m.applySelection(picked, mode)
+	return len(picked)
+}
+
+// SelectUnits selects the live units among the given IDs, in the given order, and returns how
+// many were picked. IDs of units that died or were removed are skipped, so a stored control
+// group can be recalled long after some of its members fell.
+func (m *Manager) SelectUnits(unitIDs []int64, mode SelectionMode) int {
+	if m == nil {
+		return 0
+	}
+
+	picked := make([]Unit, 0, len(unitIDs))
+	for _, unitID := range unitIDs {
+		if current, ok := m.unitByID(unitID); ok && current.Selectable() {
+			picked = append(picked, current)
+		}
+	}
+
+	m.applySelection(picked, mode)
+	return len(picked)
+}
+
+// SelectionCenter returns the mean world position of the live selected units, which is where the
+// camera goes when the player jumps to a group.
+func (m *Manager) SelectionCenter() (geom.Point, bool) {
+	if m == nil {
+		return geom.Point{}, false
+	}
+
+	selected := m.selectedUnits()
+	if len(selected) == 0 {
+		return geom.Point{}, false
+	}
+
+	var center geom.Point
+	for _, current := range selected {
+		center.X += current.Base().Position.X
+		center.Y += current.Base().Position.Y
+	}
+	center.X /= float64(len(selected))
+	center.Y /= float64(len(selected))
+	return center, true
+}
+
+// applySelection merges picked units into the selection. The primary unit follows the last unit
+// added so the info panel shows what the player just clicked; when the primary itself is
+// toggled off, the most recently picked remaining unit takes over.
+func (m *Manager) applySelection(picked []Unit, mode SelectionMode) {
+	if mode == SelectionReplace {
+		m.ClearSelection()
+	} else {
+		m.adoptPrimarySelection()
+	}
+
+	for _, current := range picked {
+		unitID := current.UnitID()
+		if mode == SelectionToggle && m.selection.contains(unitID) {
+			m.selection.remove(unitID)
+			continue
+		}
+
+		m.selection.add(unitID)
+		m.selectedID = unitID
+	}
+
+	if !m.selection.contains(m.selectedID) {
+		m.selectedID = 0
+		if count := len(m.selection.ids); count > 0 {
+			m.selectedID = m.selection.ids[count-1]
+		}
+	}
+}
+
+// adoptPrimarySelection makes sure a primary unit pinned through SelectUnitByID or restored by
+// replay seeking is a regular member of the set before the set is extended.
+func (m *Manager) adoptPrimarySelection() {
+	if m.selectedID != 0 && !m.selection.contains(m.selectedID) {
+		m.selection.add(m.selectedID)
+	}
+}
+
+// selectionIDs returns the raw selection including units that died since the last prune. A
+// bare primary without a set counts as a one-unit selection.
+func (m *Manager) selectionIDs() []int64 {
+	if len(m.selection.ids) == 0 {
+		if m.selectedID == 0 {
+			return nil
+		}
+		return []int64{m.selectedID}
+	}
+
+	return m.selection.ids
+}
+
+// selectedUnits resolves the live selected units in pick order.
+func (m *Manager) selectedUnits() []Unit {
+	ids := m.selectionIDs()
+	selected := make([]Unit, 0, len(ids))
+	for _, unitID := range ids {
+		if current, ok := m.unitByID(unitID); ok {
+			selected = append(selected, current)
+		}
+	}
+	return selected
+}
+
+// pruneSelection drops dead units from the selection after a manager step and hands the primary
+// role to the latest surviving pick when the primary died.
+func (m *Manager) pruneSelection() {
+	m.selection.ids = slices.DeleteFunc(m.selection.ids, func(unitID int64) bool {
+		if _, ok := m.unitByID(unitID); ok {
+			return false
+		}
+		delete(m.selection.members, unitID)
+		return true
+	})
+	if _, ok := m.selectedUnit(); ok {
+		return
+	}
+
+	m.selectedID = 0
+	if count := len(m.selection.ids); count > 0 {
+		m.selectedID = m.selection.ids[count-1]
+	}
+}
+
+// formationTiles returns up to count walkable tiles around the center, nearest first, so a group
+// order spreads its units into a compact blob instead of stacking them on the clicked tile.
+// Tiles blocked by obstacles or impassable terrain are skipped and the search stops at a radius
+// that comfortably fits the group, which keeps a click inside a closed wall pocket cheap.
+func (m *Manager) formationTiles(centerX, centerY, count int) []geom.Point {
+	maxRadius := int(math.Ceil(math.Sqrt(float64(count))))*2 + 4
+	slots := make([]geom.Point, 0, count)
+	for radius := 0; radius <= maxRadius && len(slots) < count; radius++ {
+		for _, tile := range formationRing(centerX, centerY, radius) {
+			if !m.formationTileFree(tile.X, tile.Y) {
+				continue
+			}
+			slots = append(slots, m.tileAnchor(tile.X, tile.Y))
+			if len(slots) == count {
+				break
+			}
+		}
+	}
+	return slots
+}
+
+// formationRing lists the tiles at the given Chebyshev distance from the center ordered by their
+// Euclidean distance, so the edge midpoints of a ring fill before its corners.
+func formationRing(centerX, centerY, radius int) []image.Point {
+	if radius == 0 {
+		return []image.Point{{X: centerX, Y: centerY}}
+	}
+
+	ring := make([]image.Point, 0, radius*8)
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if max(absInt(dx), absInt(dy)) == radius {
+				ring = append(ring, image.Point{X: dx, Y: dy})
+			}
+		}
+	}
+	slices.SortStableFunc(ring, func(a, b image.Point) int {
+		return (a.X*a.X + a.Y*a.Y) - (b.X*b.X + b.Y*b.Y)
+	})
+	for index := range ring {
+		ring[index] = ring[index].Add(image.Point{X: centerX, Y: centerY})
+	}
+	return ring
+}
+
+func (m *Manager) formationTileFree(tileX, tileY int) bool {
+	if !m.world.InBounds(tileX, tileY) || m.tileBlockedForMovement(tileX, tileY, 0) {
+		return false
+	}
+
+	return !math.IsInf(m.world.TileType(tileX, tileY).MovementCost(), 1)
+}
+
+func absInt(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// KindCount is how many selected units share one kind.
+type KindCount struct {
+	Kind  Kind
+	Count int
+}
+
+// SelectionSummary totals a selection for the group panel of the HUD. Kinds are ordered by
+// count, the most common first, and then by name so the line does not flicker.
+type SelectionSummary struct {
+	Count     int
+	Kinds     []KindCount
+	Health    int
+	MaxHealth int
+	// Mobile counts the units that take move, stop and hold orders; Moving and Holding are
+	// subsets of it.
+	Mobile  int
+	Moving  int
+	Holding int
+	// Shooters counts the units with a weapon and WeaponsReady those that could fire now.
+	Shooters     int
+	WeaponsReady int
+}
+
+// SelectionSummary totals the live selected units.
+func (m *Manager) SelectionSummary() SelectionSummary {
+	var summary SelectionSummary
+	if m == nil {
+		return summary
+	}
+
+	kinds := make(map[Kind]int)
+	for _, current := range m.selectedUnits() {
+		summary.Count++
+		kinds[current.UnitKind()]++
+		summary.Health += current.CurrentHealth()
+		summary.MaxHealth += current.MaxHealthValue()
+		if current.IsMobile() {
+			summary.Mobile++
+			if current.Base().IsMoving() {
+				summary.Moving++
+			}
+		}
+
+		body, ok := current.(*NonStaticUnit)
+		if !ok {
+			continue
+		}
+		if body.HoldingPosition() {
+			summary.Holding++
+		}
+		if body.CanShoot() {
+			summary.Shooters++
+			if body.WeaponReady() {
+				summary.WeaponsReady++
+			}
+		}
+	}
+
+	for kind, count := range kinds {
+		summary.Kinds = append(summary.Kinds, KindCount{Kind: kind, Count: count})
+	}
+	slices.SortFunc(summary.Kinds, func(a, b KindCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(string(a.Kind), string(b.Kind))
+	})
+	return summary
+}
@@ -0,0 +1,227 @@
+package unit
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// StateHash folds every gameplay-relevant field of the simulation into one 64-bit FNV-1a
+// digest. Two managers that received the same spawn sequence and the same command stream must
+// report identical hashes after every Update, so desync checks may compare runs tick by tick
+// without serializing full snapshots.
+//
+// Units are visited in ascending ID order instead of physical slot order because slot reuse is
+// a storage detail. Tile stacks are hashed as ID sets for the same reason: bodies entering one
+// tile on the same step reach its stack in worker order, and hit resolution does not depend on
+// the stored order. Draw-only state such as animation ticks, visual travel smoothing and the
+// current selection is excluded, as are buffered order reports and combat events that callers
+// drain as output. The method must not run concurrently with Update.
+func (m *Manager) StateHash() uint64 {
+	if m == nil {
+		return 0
+	}
+
+	h := newStateHasher()
+	h.writeInt64(m.nextID)
+	h.writeInt64(m.nextOrderID)
+	h.writeInt64(m.lastGameTick)
+
+	units := make([]Unit, 0, m.units.SlotsLen())
+	for index := 0; index < m.units.SlotsLen(); index++ {
+		current, ok := m.units.SlotAt(index)
+		if !ok {
+			continue
+		}
+		units = append(units, current)
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].UnitID() < units[j].UnitID()
+	})
+	h.writeInt(len(units))
+	for _, current := range units {
+		h.writeUnit(current)
+	}
+
+	stacks := make(map[tileKey]*TileStack)
+	m.tileRegistry.rangeStacks(func(key tileKey, stack *TileStack) {
+		stacks[key] = stack
+	})
+	keys := make([]tileKey, 0, len(stacks))
+	for key := range stacks {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].y != keys[j].y {
+			return keys[i].y < keys[j].y
+		}
+		return keys[i].x < keys[j].x
+	})
+	h.writeInt(len(keys))
+	for _, key := range keys {
+		unitIDs := slices.Sorted(slices.Values(stacks[key].UnitIDs()))
+		h.writeInt(key.x)
+		h.writeInt(key.y)
+		h.writeInt(len(unitIDs))
+		for _, unitID := range unitIDs {
+			h.writeInt64(unitID)
+		}
+	}
+
+	return h.sum()
+}
+
+// stateHasher wraps the streaming hash with fixed-width little-endian encoders so every field
+// contributes the same byte layout regardless of platform word size.
+type stateHasher struct {
+	hash hash.Hash64
+	buf  [8]byte
+}
+
+func newStateHasher() *stateHasher {
+	return &stateHasher{hash: fnv.New64a()}
+}
+
+func (h *stateHasher) sum() uint64 {
+	return h.hash.Sum64()
+}
+
+func (h *stateHasher) writeUint64(value uint64) {
+	binary.LittleEndian.PutUint64(h.buf[:], value)
+	_, _ = h.hash.Write(h.buf[:])
+}
+
+func (h *stateHasher) writeInt64(value int64) {
+	h.writeUint64(uint64(value))
+}
+
+func (h *stateHasher) writeInt(value int) {
+	h.writeUint64(uint64(int64(value)))
+}
+
+func (h *stateHasher) writeFloat(value float64) {
+	h.writeUint64(math.Float64bits(value))
+}
+
+func (h *stateHasher) writeBool(value bool) {
+	if value {
+		h.writeUint64(1)
+		return
+	}
+	h.writeUint64(0)
+}
+
+func (h *stateHasher) writeString(value string) {
+	h.writeInt(len(value))
+	_, _ = h.hash.Write([]byte(value))
+}
+
+func (h *stateHasher) writePoint(point geom.Point) {
+	h.writeFloat(point.X)
+	h.writeFloat(point.Y)
+}
+
+func (h *stateHasher) writePath(path []geom.Point) {
+	h.writeInt(len(path))
+	for _, point := range path {
+		h.writePoint(point)
+	}
+}
+
+// writeUnit hashes the shared base state first and then the concrete kind-specific fields.
+// The concrete type switch mirrors the renderer dispatch so a new unit type has to be added to
+// both places explicitly instead of silently dropping out of the determinism check.
+func (h *stateHasher) writeUnit(current Unit) {
+	h.writeInt64(current.UnitID())
+	h.writeString(string(current.UnitKind()))
+	h.writeBase(current.Base())
+
+	switch body := current.(type) {
+	case *NonStaticUnit:
+		h.writePoint(body.SpawnPosition)
+		h.writeInt(body.MaxHealth)
+		h.writeInt(body.Health)
+		h.writeFloat(body.moveSpeedPerTick)
+		h.writeInt(body.weaponCooldown())
+		h.writeBool(body.holdPosition)
+		h.writeBool(body.queuedMove.hasRoute)
+		h.writePath(body.queuedMove.path)
+		h.writeBool(body.activeOrder.hasOrder)
+		h.writeBool(body.activeOrder.started)
+		h.writeBool(body.activeOrder.releasing)
+		h.writeOrder(body.activeOrder.order)
+		h.writeBool(body.queuedOrder.hasOrder)
+		h.writeOrder(body.queuedOrder.order)
+		h.writeBool(body.preparedProjectile != nil)
+		if body.preparedProjectile != nil {
+			h.writeUnregisteredProjectile(body.preparedProjectile)
+		}
+		h.writeInt(len(body.pendingProjectiles))
+		for _, projectile := range body.pendingProjectiles {
+			h.writeUnregisteredProjectile(projectile)
+		}
+	case *StaticUnit:
+		h.writePoint(body.SpawnPosition)
+		h.writeInt(body.MaxHealth)
+		h.writeInt(body.Health)
+		h.writeBool(body.blocksMovement)
+	case *Projectile:
+		h.writeProjectile(body)
+	}
+}
+
+func (h *stateHasher) writeBase(base *BaseUnit) {
+	h.writePoint(base.Position)
+	h.writePath(base.path)
+	h.writeInt(base.sleepTimeValue())
+	h.writeInt64(base.lastUpdateTick)
+	travel := base.travelRef()
+	h.writePoint(travel.from)
+	h.writePoint(travel.to)
+	h.writeInt(travel.duration)
+	h.writeInt(travel.remaining)
+	h.writeBool(travel.active)
+	h.writeBool(base.UpdateSleeping())
+	h.writeBool(base.PendingRemoval())
+	h.writeBool(base.RemovalHandled())
+}
+
+func (h *stateHasher) writeOrder(order unitOrder) {
+	h.writeInt64(order.id)
+	h.writeInt64(order.unitID)
+	h.writeInt(int(order.kind))
+	h.writePoint(order.targetPoint)
+	h.writePoint(order.direction)
+	h.writePath(order.path)
+}
+
+func (h *stateHasher) writeProjectile(projectile *Projectile) {
+	h.writeInt64(projectile.OwnerID)
+	h.writeFloat(projectile.Radius)
+	h.writeInt(projectile.Damage)
+	h.writePoint(projectile.Direction)
+	h.writeFloat(projectile.impactRadius)
+	h.writeInt(projectile.impactTicks)
+	h.writeInt(projectile.impactDurationTicks)
+	h.writeBool(projectile.exploding)
+	h.writeBool(projectile.hitOccurred)
+}
+
+// writeUnregisteredProjectile hashes a shot its owner has built but the manager has not
+// registered yet. Such a shot has no ID and is not in the unit list, so its origin and flight
+// path are hashed here together with the owner, damage and direction; two runs that fire
+// different shots on the same step then differ before the shots are spawned.
+func (h *stateHasher) writeUnregisteredProjectile(projectile *Projectile) {
+	h.writeBool(projectile != nil)
+	if projectile == nil {
+		return
+	}
+	h.writePoint(projectile.Position)
+	h.writePath(projectile.path)
+	h.writeProjectile(projectile)
+}
@@ -2,6 +2,7 @@ package unit
 
 import (
 	"math"
+	"time"
 
 	"github.com/unng-lab/endless/pkg/geom"
 )
@@ -93,6 +94,30 @@ type DuelSnapshot struct {
 	ProjectileCount  int
 }
 
+// UnitCounts summarizes the live population by broad category. Headless runners and benchmark
+// reports sample it between ticks instead of walking manager storage themselves.
+type UnitCounts struct {
+	Total       int `json:"total"`
+	Mobile      int `json:"mobile"`
+	Static      int `json:"static"`
+	Projectiles int `json:"projectiles"`
+}
+
+// UnitMarker is the position, kind and team of one live unit, which is all a map overlay needs.
+type UnitMarker struct {
+	Kind     Kind
+	Team     Team
+	Position geom.Point
+}
+
+// PathfindingStats counts the path searches started by move orders since the manager was built
+// and the wall-clock time they took.
+type PathfindingStats struct {
+	Calls    int64         `json:"calls"`
+	Failures int64         `json:"failures"`
+	Time     time.Duration `json:"time_ns"`
+}
+
 // UnitSnapshot returns one stable projection of the requested runtime object. Callers may use
 // this to persist per-step traces without coupling storage to mutable unit internals.
 func (m *Manager) UnitSnapshot(unitID int64) (UnitSnapshot, bool) {
@@ -132,7 +157,7 @@ func (m *Manager) UnitSnapshot(unitID int64) (UnitSnapshot, bool) {
 	}
 
 	snapshot.WeaponReady = body.WeaponReady()
-	snapshot.FireCooldownRemaining = body.fireCooldownRemaining
+	snapshot.FireCooldownRemaining = body.weaponCooldown()
 	if body.activeOrder.hasOrder {
 		snapshot.CurrentActiveOrderKind = body.activeOrder.order.kind
 		snapshot.CurrentActiveOrderExists = true
@@ -181,7 +206,9 @@ func (m *Manager) DuelSnapshot(shooterID, targetID int64) (DuelSnapshot, bool) {
 }
 
 // DrainCombatEvents hands callers every buffered combat-side event in emission order and clears
-// the manager-owned tail so later reads only see newly generated outcomes.
+// the manager-owned tail so later reads only see newly generated outcomes. The drain has a
+// single owner; additional observers should use Subscribe, which sees the same events without
+// consuming them.
 func (m *Manager) DrainCombatEvents() []CombatEvent {
 	if m == nil {
 		return nil
@@ -203,10 +230,71 @@ func (m *Manager) appendCombatEvent(event CombatEvent) {
 	if m == nil || event.Type == "" {
 		return
 	}
+	if m.stepping {
+		m.stageEvent(stagedEvent{unitID: event.ProjectileUnitID, combat: event})
+		return
+	}
 
 	m.combatEventsMu.Lock()
 	m.combatEvents = append(m.combatEvents, event)
 	m.combatEventsMu.Unlock()
+	m.publishCombatEvent(event)
+}
+
+// UnitCounts returns the current population split into mobile bodies, static blockers and
+// projectiles. Units already waiting for the removal sweep are skipped by the storage walk.
+func (m *Manager) UnitCounts() UnitCounts {
+	if m == nil {
+		return UnitCounts{}
+	}
+
+	counts := UnitCounts{}
+	m.units.Range(func(current Unit) bool {
+		counts.Total++
+		switch {
+		case current.UnitKind() == KindProjectile:
+			counts.Projectiles++
+		case current.IsMobile():
+			counts.Mobile++
+		default:
+			counts.Static++
+		}
+		return true
+	})
+	return counts
+}
+
+// AppendUnitMarkers appends a marker for every live unit to dst and returns the extended slice.
+// It reads the storage columns only, so the minimap can sample a hundred thousand bodies without
+// touching the unit objects, and reusing dst keeps the sampling free of allocations. It must
+// not run concurrently with Update.
+func (m *Manager) AppendUnitMarkers(dst []UnitMarker) []UnitMarker {
+	if m == nil {
+		return dst
+	}
+
+	columns := &m.units.columns
+	for slot, flags := range columns.flags {
+		if flags&slotOccupied == 0 || flags&slotPendingRemoval != 0 {
+			continue
+		}
+		dst = append(dst, UnitMarker{Kind: columns.kinds[slot], Team: columns.teams[slot], Position: columns.positions[slot]})
+	}
+	return dst
+}
+
+// PathfindingStats reports how many move orders ran a path search and how many found no route.
+// The counters are cumulative; benchmark code subtracts two samples to scope them to a window.
+func (m *Manager) PathfindingStats() PathfindingStats {
+	if m == nil {
+		return PathfindingStats{}
+	}
+
+	return PathfindingStats{
+		Calls:    m.pathfindingCalls.Load(),
+		Failures: m.pathfindingFailures.Load(),
+		Time:     time.Duration(m.pathfindingNanos.Load()),
+	}
 }
 
 func (m *Manager) projectileCount() int {
@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"image"
 	"log"
+	"math"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/unng-lab/endless/pkg/camera"
 	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/pathfinding"
 	"github.com/unng-lab/endless/pkg/world"
 )
 
@@ -151,26 +154,26 @@ func TestManagerMovesUnitBetweenTileStacksDuringUpdate(t *testing.T) {
 	m := newTestManager(gameWorld, runner)
 
 	startKey := tileKey{x: 0, y: 0}
-	if stack := m.tileStacks[startKey]; stack == nil || len(stack.UnitIDs()) != 1 {
+	if stack := m.tileStackAtKey(startKey); stack == nil || len(stack.UnitIDs()) != 1 {
 		t.Fatalf("start tile stack = %+v, want one registered unit", stack)
 	}
 
 	runner.SetPath([]geom.Point{{X: 24, Y: 8}})
 	m.Update(1)
 
-	if stack := m.tileStacks[startKey]; stack != nil && len(stack.UnitIDs()) != 0 {
+	if stack := m.tileStackAtKey(startKey); stack != nil && len(stack.UnitIDs()) != 0 {
 		t.Fatalf("start tile stack after move = %v, want empty or removed stack", stack.UnitIDs())
 	}
 
 	targetKey := tileKey{x: 1, y: 0}
-	stack := m.tileStacks[targetKey]
+	stack := m.tileStackAtKey(targetKey)
 	if stack == nil {
 		t.Fatal("expected target tile stack to be created during move")
 	}
 	if got := stack.UnitIDs(); len(got) != 1 || got[0] != runner.UnitID() {
 		t.Fatalf("target tile stack = %v, want runner id %d", got, runner.UnitID())
 	}
-	if got := m.registeredTiles[runner.UnitID()]; got != targetKey {
+	if got, _ := m.registeredTileKey(runner.UnitID()); got != targetKey {
 		t.Fatalf("registered tile = %+v, want %+v", got, targetKey)
 	}
 }
@@ -253,7 +256,7 @@ func TestManagerVisibleTileUnitsIncludeProjectileFromTileStack(t *testing.T) {
 	advanceFireOrderUntilProjectileSpawned(t, m, 1)
 	projectile := onlyProjectile(t, m)
 	startKey := tileKey{x: 1, y: 1}
-	if got := m.registeredTiles[projectile.UnitID()]; got != startKey {
+	if got, _ := m.registeredTileKey(projectile.UnitID()); got != startKey {
 		t.Fatalf("registered tile = %+v, want %+v for projectile", got, startKey)
 	}
 
@@ -336,7 +339,7 @@ func TestManagerProjectileHitsUnitOccupyingEnteredTile(t *testing.T) {
 	if !projectile.exploding {
 		t.Fatal("expected projectile to keep living as an explosion after hit")
 	}
-	if _, ok := m.registeredTiles[projectile.UnitID()]; !ok {
+	if _, ok := m.registeredTileKey(projectile.UnitID()); !ok {
 		t.Fatal("expected exploding projectile to stay registered in tile stack until animation ends")
 	}
 
@@ -344,7 +347,7 @@ func TestManagerProjectileHitsUnitOccupyingEnteredTile(t *testing.T) {
 	if projectileCount(m) != 0 {
 		t.Fatalf("projectiles = %d, want 0 after explosion animation completes", projectileCount(m))
 	}
-	if _, ok := m.registeredTiles[projectile.UnitID()]; ok {
+	if _, ok := m.registeredTileKey(projectile.UnitID()); ok {
 		t.Fatal("expected projectile tile registration to be removed after explosion animation completes")
 	}
 }
@@ -395,7 +398,7 @@ func TestManagerProjectileRemovesKilledUnitFromManager(t *testing.T) {
 	if _, ok := m.unitByID(target.UnitID()); ok {
 		t.Fatalf("unitByID(%d) = true, want killed target removed", target.UnitID())
 	}
-	if _, ok := m.registeredTiles[target.UnitID()]; ok {
+	if _, ok := m.registeredTileKey(target.UnitID()); ok {
 		t.Fatalf("registeredTiles contains %d, want removed target to be unregistered", target.UnitID())
 	}
 
@@ -426,7 +429,7 @@ func TestManagerProjectileCanDamageStaticUnit(t *testing.T) {
 	if _, ok := m.unitByID(target.UnitID()); ok {
 		t.Fatalf("unitByID(%d) = true, want killed static unit removed", target.UnitID())
 	}
-	if _, ok := m.registeredTiles[target.UnitID()]; ok {
+	if _, ok := m.registeredTileKey(target.UnitID()); ok {
 		t.Fatalf("registeredTiles contains %d, want removed static unit to be unregistered", target.UnitID())
 	}
 }
@@ -780,7 +783,7 @@ func TestManagerFlushesTileLeaveForLastDeletedUnit(t *testing.T) {
 
 	m.Update(1)
 
-	if _, ok := m.registeredTiles[runner.UnitID()]; ok {
+	if _, ok := m.registeredTileKey(runner.UnitID()); ok {
 		t.Fatalf("registeredTiles contains %d, want deleted last unit removed from tile registry", runner.UnitID())
 	}
 	if _, ok := m.unitByID(runner.UnitID()); ok {
@@ -1102,8 +1105,8 @@ func projectileCount(m *Manager) int {
 func assertTickOrderExpectation(t *testing.T, runner *NonStaticUnit, tileSize float64, expectation tickOrderExpectation, tick int64, stage string) {
 	t.Helper()
 
-	if runner.sleepTime != expectation.sleepTime {
-		t.Fatalf("%s tick %d sleepTime = %d, want %d", stage, tick, runner.sleepTime, expectation.sleepTime)
+	if runner.sleepTimeValue() != expectation.sleepTime {
+		t.Fatalf("%s tick %d sleepTime = %d, want %d", stage, tick, runner.sleepTimeValue(), expectation.sleepTime)
 	}
 	if len(runner.path) != expectation.pathLen {
 		t.Fatalf("%s tick %d path len = %d, want %d", stage, tick, len(runner.path), expectation.pathLen)
@@ -1310,6 +1313,830 @@ func TestManagerDuelSnapshotReportsQueuedFireOrderAndCooldown(t *testing.T) {
 	}
 }
 
+func TestManagerStateHashMatchesAcrossWorkerCounts(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	build := func(workerCount int) *Manager {
+		m := NewManagerWithWorkers(gameWorld, workerCount)
+		for index := 0; index < 40; index++ {
+			m.AddUnit(NewWall(geom.Point{X: float64(index%8)*16 + 8, Y: float64(20+index/8)*16 + 8}))
+		}
+		m.AddUnit(NewRunner(geom.Point{X: 24, Y: 24}, false, 0))
+		m.AddUnit(NewRunner(geom.Point{X: 120, Y: 24}, true, 3))
+		return m
+	}
+
+	single := build(1)
+	defer single.Close()
+	parallel := build(4)
+	defer parallel.Close()
+
+	shooterID := int64(41)
+	targetID := int64(42)
+	for _, m := range []*Manager{single, parallel} {
+		if err := m.IssueMoveOrder(targetID, geom.Point{X: 120, Y: 120}); err != nil {
+			t.Fatalf("IssueMoveOrder() error = %v", err)
+		}
+		if err := m.IssueFireOrder(shooterID, geom.Point{X: 1, Y: 0}); err != nil {
+			t.Fatalf("IssueFireOrder() error = %v", err)
+		}
+	}
+
+	if single.StateHash() != parallel.StateHash() {
+		t.Fatal("expected identical hashes before the first update")
+	}
+	for tick := int64(1); tick <= 60; tick++ {
+		single.Update(tick)
+		parallel.Update(tick)
+		if got, want := parallel.StateHash(), single.StateHash(); got != want {
+			t.Fatalf("tick %d: StateHash() = %x, want %x", tick, got, want)
+		}
+	}
+}
+
+// TestManagerCrossfireMatchesAcrossWorkerCounts fires at walls in several regions and at one
+// runner from both sides at once, so projectiles handled by different workers damage the same
+// targets on the same step. The queued hits must leave every worker count in the same state,
+// and the staged events must come out in the same order.
+func TestManagerCrossfireMatchesAcrossWorkerCounts(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 96, Rows: 96, TileSize: 16})
+	tileCenter := func(x, y int) geom.Point {
+		return geom.Point{X: float64(x)*16 + 8, Y: float64(y)*16 + 8}
+	}
+	type volley struct {
+		shooterID int64
+		direction geom.Point
+	}
+	build := func(workerCount int) (*Manager, []volley) {
+		m := NewManagerWithWorkers(gameWorld, workerCount)
+		var volleys []volley
+		for region := 0; region < 5; region++ {
+			x, y := 4+region*18, 8+region*16
+			shooterID := m.AddUnit(NewRunner(tileCenter(x, y), false, 0))
+			m.AddUnit(NewWall(tileCenter(x+3, y)))
+			volleys = append(volleys, volley{shooterID: shooterID, direction: geom.Point{X: 1, Y: 0}})
+		}
+		west := m.AddUnit(NewRunner(tileCenter(40, 88), false, 0))
+		m.AddUnit(NewRunner(tileCenter(44, 88), true, 0))
+		east := m.AddUnit(NewRunner(tileCenter(48, 88), false, 0))
+		volleys = append(volleys,
+			volley{shooterID: west, direction: geom.Point{X: 1, Y: 0}},
+			volley{shooterID: east, direction: geom.Point{X: -1, Y: 0}},
+		)
+		return m, volleys
+	}
+
+	single, volleys := build(1)
+	defer single.Close()
+	parallel, _ := build(4)
+	defer parallel.Close()
+	singleEvents := single.Subscribe(EventFilter{})
+	parallelEvents := parallel.Subscribe(EventFilter{})
+
+	kills := 0
+	for tick := int64(1); tick <= 240; tick++ {
+		if tick%20 == 1 {
+			for _, m := range []*Manager{single, parallel} {
+				for _, shot := range volleys {
+					if _, ok := m.units.Get(shot.shooterID); ok {
+						_ = m.IssueFireOrder(shot.shooterID, shot.direction)
+					}
+				}
+			}
+		}
+		single.Update(tick)
+		parallel.Update(tick)
+		if got, want := parallel.StateHash(), single.StateHash(); got != want {
+			t.Fatalf("tick %d: StateHash() = %x, want %x", tick, got, want)
+		}
+		combat := single.DrainCombatEvents()
+		if got := parallel.DrainCombatEvents(); !slices.Equal(got, combat) {
+			t.Fatalf("tick %d: DrainCombatEvents() = %+v, want %+v", tick, got, combat)
+		}
+		if got, want := parallelEvents.Drain(), singleEvents.Drain(); !slices.Equal(got, want) {
+			t.Fatalf("tick %d: subscription events = %+v, want %+v", tick, got, want)
+		}
+		for _, event := range combat {
+			if event.Type == CombatEventUnitKilled {
+				kills++
+			}
+		}
+	}
+	if kills == 0 {
+		t.Fatal("crossfire killed nothing, want walls and the runner in the middle to die")
+	}
+}
+
+func TestManagerStateHashChangesWithGameplayStateOnly(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	runner := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	m := newTestManager(gameWorld, runner)
+	defer m.Close()
+
+	initial := m.StateHash()
+	if !m.SelectUnitByID(runner.UnitID()) {
+		t.Fatal("SelectUnitByID() = false, want true")
+	}
+	runner.UpdateVisible(1)
+	if m.StateHash() != initial {
+		t.Fatal("expected selection and animation state to stay outside the state hash")
+	}
+
+	if err := m.IssueMoveOrder(runner.UnitID(), geom.Point{X: 88, Y: 24}); err != nil {
+		t.Fatalf("IssueMoveOrder() error = %v", err)
+	}
+	if m.StateHash() == initial {
+		t.Fatal("expected accepted move order to change the state hash")
+	}
+}
+
+func TestManagerStateHashCoversPendingProjectileSpecs(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	hashWithShot := func(direction geom.Point, damage int) uint64 {
+		shooter := NewRunner(geom.Point{X: 88, Y: 88}, false, 0)
+		m := newTestManager(gameWorld, shooter)
+		defer m.Close()
+
+		projectile, err := newProjectile(shooter, direction, gameWorld)
+		if err != nil {
+			t.Fatalf("newProjectile() error = %v", err)
+		}
+		projectile.Damage = damage
+		shooter.pendingProjectiles = append(shooter.pendingProjectiles, projectile)
+		return m.StateHash()
+	}
+
+	east := hashWithShot(geom.Point{X: 1, Y: 0}, 1)
+	if hashWithShot(geom.Point{X: 0, Y: 1}, 1) == east {
+		t.Fatal("pending shots with different targets hash the same")
+	}
+	if hashWithShot(geom.Point{X: 1, Y: 0}, 2) == east {
+		t.Fatal("pending shots with different damage hash the same")
+	}
+	if hashWithShot(geom.Point{X: 1, Y: 0}, 1) != east {
+		t.Fatal("identical pending shots hash differently")
+	}
+}
+
+func TestManagerSubscriptionsObserveSameEventsIndependently(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	m := NewManager(gameWorld)
+	defer m.Close()
+
+	everything := m.Subscribe(EventFilter{})
+	combat := m.Subscribe(EventFilter{Types: []GameplayEventType{
+		GameplayEventProjectileSpawned,
+		GameplayEventProjectileHit,
+		GameplayEventUnitKilled,
+	}})
+	defer everything.Close()
+	defer combat.Close()
+
+	shooter := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	target := NewRunner(geom.Point{X: 37, Y: 28}, false, 0)
+	target.Health = 1
+	m.AddUnit(shooter)
+	m.AddUnit(target)
+
+	if err := m.IssueFireOrder(shooter.UnitID(), geom.Point{X: 1, Y: 0}); err != nil {
+		t.Fatalf("IssueFireOrder() error = %v", err)
+	}
+
+	allEvents := make([]GameplayEvent, 0)
+	combatEvents := make([]GameplayEvent, 0)
+	for tick := int64(1); tick <= 40; tick++ {
+		m.Update(tick)
+		allEvents = append(allEvents, everything.Drain()...)
+		combatEvents = append(combatEvents, combat.Drain()...)
+	}
+
+	assertGameplayEventTypesPresent(t, allEvents,
+		GameplayEventUnitSpawned,
+		GameplayEventOrder,
+		GameplayEventProjectileSpawned,
+		GameplayEventTileEntered,
+		GameplayEventProjectileHit,
+		GameplayEventUnitKilled,
+		GameplayEventUnitDespawned,
+	)
+	assertGameplayEventTypesPresent(t, combatEvents,
+		GameplayEventProjectileSpawned,
+		GameplayEventProjectileHit,
+		GameplayEventUnitKilled,
+	)
+	for _, event := range combatEvents {
+		if event.Type == GameplayEventOrder || event.Type == GameplayEventTileEntered {
+			t.Fatalf("filtered subscription received %s event", event.Type)
+		}
+	}
+	if len(m.DrainCombatEvents()) == 0 {
+		t.Fatal("expected subscriptions to leave the legacy combat drain untouched")
+	}
+}
+
+func TestManagerSubscriptionReportsMoveStartAndStopForFilteredUnit(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	mover := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	idle := NewRunner(geom.Point{X: 120, Y: 120}, false, 0)
+	m := newTestManager(gameWorld, mover, idle)
+	defer m.Close()
+
+	subscription := m.Subscribe(EventFilter{UnitIDs: []int64{mover.UnitID()}})
+	if err := m.IssueMoveOrder(mover.UnitID(), geom.Point{X: 56, Y: 24}); err != nil {
+		t.Fatalf("IssueMoveOrder() error = %v", err)
+	}
+	if err := m.IssueMoveOrder(idle.UnitID(), geom.Point{X: 152, Y: 120}); err != nil {
+		t.Fatalf("IssueMoveOrder() error = %v", err)
+	}
+
+	events := make([]GameplayEvent, 0)
+	for tick := int64(1); tick <= 120; tick++ {
+		m.Update(tick)
+		events = append(events, subscription.Drain()...)
+	}
+
+	assertGameplayEventTypesPresent(t, events, GameplayEventMoveStarted, GameplayEventTileEntered, GameplayEventMoveStopped)
+	for _, event := range events {
+		if event.UnitID != mover.UnitID() {
+			t.Fatalf("event %s for unit %d leaked through unit filter", event.Type, event.UnitID)
+		}
+	}
+
+	subscription.Close()
+	if err := m.IssueMoveOrder(mover.UnitID(), geom.Point{X: 24, Y: 24}); err != nil {
+		t.Fatalf("IssueMoveOrder() error = %v", err)
+	}
+	if events := subscription.Drain(); len(events) != 0 {
+		t.Fatalf("closed subscription received %d events", len(events))
+	}
+}
+
+func TestManagerSpawnUnitRejectsBlockedTilesAndPointsOutsideWorld(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	wall := NewWall(geom.Point{X: 40, Y: 40})
+	m := newTestManager(gameWorld, wall)
+	defer m.Close()
+
+	if _, err := m.SpawnUnit(UnitSpec{Kind: KindRunner, Position: wall.Position}); err == nil {
+		t.Fatal("SpawnUnit() on wall tile error = nil, want blocked tile rejected")
+	}
+	if _, err := m.SpawnUnit(UnitSpec{Kind: KindRunner, Position: geom.Point{X: -8, Y: 8}}); err == nil {
+		t.Fatal("SpawnUnit() outside world error = nil, want rejection")
+	}
+	if _, err := m.SpawnUnit(UnitSpec{Kind: KindRunner, Position: geom.Point{X: 8, Y: 8}, ID: wall.UnitID()}); err == nil {
+		t.Fatal("SpawnUnit() with live ID error = nil, want duplicate ID rejected")
+	}
+
+	runnerID, err := m.SpawnUnit(UnitSpec{Kind: KindRunnerFocused, Position: geom.Point{X: 8, Y: 8}})
+	if err != nil {
+		t.Fatalf("SpawnUnit() error = %v", err)
+	}
+	body, ok := m.unitByID(runnerID)
+	if !ok {
+		t.Fatalf("unitByID(%d) = false, want spawned runner registered", runnerID)
+	}
+	runner := body.(*NonStaticUnit)
+	if runner.UnitKind() != KindRunnerFocused || runner.Health != runner.MaxHealth || runner.MaxHealth == 0 {
+		t.Fatalf("spawned runner kind=%s health=%d/%d, want focused runner with constructor health", runner.UnitKind(), runner.Health, runner.MaxHealth)
+	}
+	if runner.SpawnPosition != runner.Position {
+		t.Fatalf("SpawnPosition = %+v, want constructor default %+v", runner.SpawnPosition, runner.Position)
+	}
+	if _, ok := m.registeredTileKey(runnerID); !ok {
+		t.Fatalf("registeredTileKey(%d) = false, want spawned runner in its tile", runnerID)
+	}
+}
+
+// TestManagerLifecycleHooksReportSpawnDeathAndRemoval verifies that hooks see a combat kill
+// as death plus killed removal, the spent projectile as expired, and that a hook may respawn
+// the victim through SpawnUnit within the same dispatch pass.
+func TestManagerLifecycleHooksReportSpawnDeathAndRemoval(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	m := NewManager(gameWorld)
+	defer m.Close()
+
+	var spawned, deaths, removals []GameplayEvent
+	respawnedID := int64(0)
+	m.AddLifecycleHooks(LifecycleHooks{
+		OnSpawn: func(event GameplayEvent) { spawned = append(spawned, event) },
+		OnDeath: func(event GameplayEvent) { deaths = append(deaths, event) },
+		OnRemove: func(event GameplayEvent) {
+			removals = append(removals, event)
+			if event.Reason != RemovalReasonKilled {
+				return
+			}
+			id, err := m.SpawnUnit(UnitSpec{Kind: KindRunner, Position: geom.Point{X: 120, Y: 120}})
+			if err != nil {
+				t.Errorf("SpawnUnit() from hook error = %v", err)
+			}
+			respawnedID = id
+		},
+	})
+
+	shooterID, err := m.SpawnUnit(UnitSpec{Kind: KindRunner, Position: geom.Point{X: 24, Y: 24}})
+	if err != nil {
+		t.Fatalf("SpawnUnit() error = %v", err)
+	}
+	targetID, err := m.SpawnUnit(UnitSpec{Kind: KindRunner, Position: geom.Point{X: 37, Y: 28}, Health: 1})
+	if err != nil {
+		t.Fatalf("SpawnUnit() error = %v", err)
+	}
+	if len(spawned) != 2 {
+		t.Fatalf("OnSpawn calls after SpawnUnit = %d, want 2 delivered synchronously", len(spawned))
+	}
+
+	if err := m.IssueFireOrder(shooterID, geom.Point{X: 1, Y: 0}); err != nil {
+		t.Fatalf("IssueFireOrder() error = %v", err)
+	}
+	for tick := int64(1); tick <= 40; tick++ {
+		m.Update(tick)
+	}
+
+	if len(deaths) != 1 || deaths[0].UnitID != targetID || deaths[0].Combat.SourceUnitID != shooterID {
+		t.Fatalf("OnDeath events = %+v, want target %d killed by shooter %d", deaths, targetID, shooterID)
+	}
+	reasons := make(map[Kind]RemovalReason)
+	for _, event := range removals {
+		reasons[event.UnitKind] = event.Reason
+	}
+	if reasons[KindRunner] != RemovalReasonKilled || reasons[KindProjectile] != RemovalReasonExpired {
+		t.Fatalf("OnRemove reasons = %v, want killed runner and expired projectile", reasons)
+	}
+	if respawnedID == 0 {
+		t.Fatal("hook respawn did not run")
+	}
+	if _, ok := m.unitByID(respawnedID); !ok {
+		t.Fatalf("unitByID(%d) = false, want respawned runner registered", respawnedID)
+	}
+	if last := spawned[len(spawned)-1]; last.UnitID != respawnedID {
+		t.Fatalf("last OnSpawn unit = %d, want nested respawn %d delivered in the same pass", last.UnitID, respawnedID)
+	}
+}
+
+func TestManagerRemoveUnitCancelsOrdersAndReplaysFromRecording(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	m := NewManagerWithWorkers(gameWorld, 1)
+	defer m.Close()
+
+	var commands []Command
+	m.SetCommandRecorder(func(command Command) { commands = append(commands, command) })
+	var removals []GameplayEvent
+	m.AddLifecycleHooks(LifecycleHooks{OnRemove: func(event GameplayEvent) { removals = append(removals, event) }})
+
+	runnerID, err := m.SpawnUnit(UnitSpec{Kind: KindRunner, Position: geom.Point{X: 8, Y: 8}})
+	if err != nil {
+		t.Fatalf("SpawnUnit() error = %v", err)
+	}
+	if err := m.IssueMoveOrder(runnerID, geom.Point{X: 120, Y: 8}); err != nil {
+		t.Fatalf("IssueMoveOrder() error = %v", err)
+	}
+	m.Update(1)
+	m.Update(2)
+
+	if err := m.RemoveUnit(runnerID, ""); err != nil {
+		t.Fatalf("RemoveUnit() error = %v", err)
+	}
+	if err := m.RemoveUnit(runnerID, RemovalReasonScripted); err == nil {
+		t.Fatal("RemoveUnit() for removed unit error = nil, want not found")
+	}
+
+	if _, ok := m.unitByID(runnerID); ok {
+		t.Fatalf("unitByID(%d) = true, want removed runner gone", runnerID)
+	}
+	if _, ok := m.registeredTileKey(runnerID); ok {
+		t.Fatalf("registeredTileKey(%d) = true, want removed runner unregistered", runnerID)
+	}
+	assertOrderStatusesPresent(t, m.DrainUnitOrderReports(runnerID), OrderQueued, OrderCanceled)
+	if len(removals) != 1 || removals[0].Reason != RemovalReasonScripted {
+		t.Fatalf("OnRemove events = %+v, want one scripted removal", removals)
+	}
+
+	last := commands[len(commands)-1]
+	if last.Type != CommandRemoveUnit || last.UnitID != runnerID || last.Reason != RemovalReasonScripted || last.Tick != 2 {
+		t.Fatalf("last recorded command = %+v, want scripted remove_unit at tick 2", last)
+	}
+
+	replayed := NewManagerWithWorkers(gameWorld, 1)
+	defer replayed.Close()
+	next := 0
+	for tick := int64(0); tick <= 2; tick++ {
+		if tick > 0 {
+			replayed.Update(tick)
+		}
+		for ; next < len(commands) && commands[next].Tick == tick; next++ {
+			if err := replayed.ApplyCommand(commands[next]); err != nil {
+				t.Fatalf("ApplyCommand(%+v) error = %v", commands[next], err)
+			}
+		}
+	}
+	if got, want := replayed.StateHash(), m.StateHash(); got != want {
+		t.Fatalf("replayed StateHash() = %x, want %x", got, want)
+	}
+}
+
+func TestManagerTeleportUnitStopsRunnerAndReplaysFromRecording(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	m := NewManagerWithWorkers(gameWorld, 1)
+	defer m.Close()
+
+	var commands []Command
+	m.SetCommandRecorder(func(command Command) { commands = append(commands, command) })
+
+	runnerID, err := m.SpawnUnit(UnitSpec{Kind: KindRunner, Position: geom.Point{X: 8, Y: 8}})
+	if err != nil {
+		t.Fatalf("SpawnUnit() error = %v", err)
+	}
+	if _, err := m.SpawnUnit(UnitSpec{Kind: KindWall, Position: geom.Point{X: 200, Y: 200}}); err != nil {
+		t.Fatalf("SpawnUnit() wall error = %v", err)
+	}
+	if err := m.IssueMoveOrder(runnerID, geom.Point{X: 120, Y: 8}); err != nil {
+		t.Fatalf("IssueMoveOrder() error = %v", err)
+	}
+	m.Update(1)
+	m.Update(2)
+
+	if err := m.TeleportUnit(runnerID, geom.Point{X: 200, Y: 200}); err == nil {
+		t.Fatal("TeleportUnit() onto wall error = nil, want blocked")
+	}
+	if err := m.TeleportUnit(runnerID, geom.Point{X: -1, Y: 8}); err == nil {
+		t.Fatal("TeleportUnit() outside world error = nil, want rejection")
+	}
+	if err := m.TeleportUnit(runnerID, geom.Point{X: 70, Y: 100}); err != nil {
+		t.Fatalf("TeleportUnit() error = %v", err)
+	}
+
+	body, _ := m.unitByID(runnerID)
+	runner := body.(*NonStaticUnit)
+	if got, want := runner.Position, (geom.Point{X: 72, Y: 104}); got != want {
+		t.Fatalf("Position = %+v, want tile centre %+v", got, want)
+	}
+	if key, ok := m.registeredTileKey(runnerID); !ok || key != (tileKey{x: 4, y: 6}) {
+		t.Fatalf("registeredTileKey(%d) = %+v, %v, want tile (4, 6)", runnerID, key, ok)
+	}
+	assertOrderStatusesPresent(t, m.DrainUnitOrderReports(runnerID), OrderQueued, OrderCanceled)
+	for tick := int64(3); tick <= 6; tick++ {
+		m.Update(tick)
+	}
+	if got := runner.Position; got != (geom.Point{X: 72, Y: 104}) {
+		t.Fatalf("Position after updates = %+v, want runner to stay put", got)
+	}
+
+	last := commands[len(commands)-1]
+	if last.Type != CommandTeleportUnit || last.UnitID != runnerID || last.Tick != 2 {
+		t.Fatalf("last recorded command = %+v, want teleport_unit at tick 2", last)
+	}
+
+	replayed := NewManagerWithWorkers(gameWorld, 1)
+	defer replayed.Close()
+	next := 0
+	for tick := int64(0); tick <= 6; tick++ {
+		if tick > 0 {
+			replayed.Update(tick)
+		}
+		for ; next < len(commands) && commands[next].Tick == tick; next++ {
+			if err := replayed.ApplyCommand(commands[next]); err != nil {
+				t.Fatalf("ApplyCommand(%+v) error = %v", commands[next], err)
+			}
+		}
+	}
+	if got, want := replayed.StateHash(), m.StateHash(); got != want {
+		t.Fatalf("replayed StateHash() = %x, want %x", got, want)
+	}
+}
+
+func TestManagerMetricsSnapshotTracksPopulationOrdersAndTickDurations(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	mover := NewRunner(geom.Point{X: 8, Y: 8}, false, 0)
+	idle := NewRunner(geom.Point{X: 120, Y: 120}, false, 0)
+	m := newTestManagerWithWorkers(gameWorld, 1, mover, idle, NewWall(geom.Point{X: 200, Y: 200}))
+	defer m.Close()
+
+	if err := m.IssueMoveOrder(mover.UnitID(), geom.Point{X: 200, Y: 8}); err != nil {
+		t.Fatalf("IssueMoveOrder() error = %v", err)
+	}
+	for tick := int64(1); tick <= 5; tick++ {
+		m.Update(tick)
+	}
+
+	metrics := m.Metrics()
+	if metrics.Tick != 5 || metrics.TickDuration.Count != 5 {
+		t.Fatalf("tick=%d histogram count=%d, want both at 5", metrics.Tick, metrics.TickDuration.Count)
+	}
+	if metrics.UnitsByKind[KindRunner] != 2 || metrics.UnitsByKind[KindWall] != 1 {
+		t.Fatalf("UnitsByKind = %v, want 2 runners and 1 wall", metrics.UnitsByKind)
+	}
+	if metrics.AwakeUnits+metrics.SleepingUnits != 3 || metrics.SleepingUnits == 0 {
+		t.Fatalf("awake=%d sleeping=%d, want 3 live units with the idle ones asleep", metrics.AwakeUnits, metrics.SleepingUnits)
+	}
+	if metrics.TileStacks != 3 {
+		t.Fatalf("TileStacks = %d, want 3", metrics.TileStacks)
+	}
+	if metrics.OrdersByStatus["queued"] != 1 || metrics.OrdersByStatus["started"] != 1 {
+		t.Fatalf("OrdersByStatus = %v, want the move order queued and started", metrics.OrdersByStatus)
+	}
+	if metrics.Pathfinding.Calls != 1 || metrics.Pathfinding.Time <= 0 {
+		t.Fatalf("Pathfinding = %+v, want one timed search", metrics.Pathfinding)
+	}
+
+	var out strings.Builder
+	if err := metrics.WritePrometheus(&out); err != nil {
+		t.Fatalf("WritePrometheus() error = %v", err)
+	}
+	for _, line := range []string{
+		"endless_tick 5\n",
+		"endless_units{kind=\"runner\"} 2\n",
+		"endless_order_reports_total{status=\"queued\"} 1\n",
+		"endless_pathfinding_calls_total 1\n",
+		"endless_tick_duration_seconds_bucket{le=\"+Inf\"} 5\n",
+		"endless_tick_duration_seconds_count 5\n",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Fatalf("Prometheus output misses %q:\n%s", line, out.String())
+		}
+	}
+}
+
+// TestManagerSelectInScreenRectPrefersMobileUnitsAndShiftToggles verifies that a drag rectangle
+// over runners and a wall picks only the runners, that toggling adds and removes single units
+// while the primary follows the latest pick, and that a rectangle over walls alone selects them.
+func TestManagerSelectInScreenRectPrefersMobileUnitsAndShiftToggles(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	first := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	second := NewRunner(geom.Point{X: 40, Y: 24}, false, 0)
+	wall := NewWall(geom.Point{X: 56, Y: 24})
+	far := NewRunner(geom.Point{X: 168, Y: 168}, false, 0)
+	m := newTestManager(gameWorld, first, second, wall, far)
+	defer m.Close()
+	cam := camera.New(camera.Config{})
+
+	if got := m.SelectInScreenRect(cam, geom.Point{X: 70, Y: 40}, geom.Point{X: 2, Y: 2}, SelectionReplace); got != 2 {
+		t.Fatalf("SelectInScreenRect() = %d, want the two runners without the wall", got)
+	}
+	if ids := m.SelectedIDs(); !slices.Equal(ids, []int64{first.UnitID(), second.UnitID()}) {
+		t.Fatalf("SelectedIDs() = %v, want both runners in tile order", ids)
+	}
+
+	m.SelectAtScreenMode(cam, far.Position, 640, 640, SelectionToggle)
+	if m.SelectionCount() != 3 || m.selectedID != far.UnitID() {
+		t.Fatalf("after toggle on: count=%d primary=%d, want 3 with the far runner primary", m.SelectionCount(), m.selectedID)
+	}
+	m.SelectAtScreenMode(cam, far.Position, 640, 640, SelectionToggle)
+	if m.SelectionCount() != 2 || m.selectedID != second.UnitID() {
+		t.Fatalf("after toggle off: count=%d primary=%d, want 2 with the latest remaining pick primary", m.SelectionCount(), m.selectedID)
+	}
+
+	m.SelectInScreenRect(cam, geom.Point{X: 50, Y: 18}, geom.Point{X: 62, Y: 30}, SelectionReplace)
+	if ids := m.SelectedIDs(); !slices.Equal(ids, []int64{wall.UnitID()}) {
+		t.Fatalf("SelectedIDs() = %v, want the wall alone", ids)
+	}
+}
+
+// TestManagerCommandSelectedMoveSpreadsGroupIntoFormation verifies that a group order gives
+// every runner its own walkable tile around the target, nearest runner in the center, and that
+// walls in the selection and blocked tiles are skipped.
+func TestManagerCommandSelectedMoveSpreadsGroupIntoFormation(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	runners := []*NonStaticUnit{
+		NewRunner(geom.Point{X: 24, Y: 24}, false, 0),
+		NewRunner(geom.Point{X: 40, Y: 24}, false, 0),
+		NewRunner(geom.Point{X: 56, Y: 24}, false, 0),
+		NewRunner(geom.Point{X: 200, Y: 200}, false, 0),
+	}
+	wall := NewWall(geom.Point{X: 168, Y: 152})
+	m := newTestManager(gameWorld, runners[0], runners[1], runners[2], runners[3], wall)
+	defer m.Close()
+
+	if got := m.SelectAllOfKind(KindRunner, SelectionReplace); got != len(runners) {
+		t.Fatalf("SelectAllOfKind() = %d, want %d", got, len(runners))
+	}
+	m.applySelection([]Unit{wall}, SelectionAdd)
+
+	destinations := make(map[int64]geom.Point)
+	m.SetCommandRecorder(func(command Command) {
+		if command.Type == CommandMoveOrder {
+			destinations[command.UnitID] = command.Point
+		}
+	})
+	if err := m.CommandSelectedMove(10, 10); err != nil {
+		t.Fatalf("CommandSelectedMove() error = %v", err)
+	}
+
+	if len(destinations) != len(runners) {
+		t.Fatalf("move orders = %v, want one per runner and none for the wall", destinations)
+	}
+	if got := destinations[runners[3].UnitID()]; got != m.tileAnchor(10, 10) {
+		t.Fatalf("nearest runner destination = %+v, want the target tile center", got)
+	}
+	seen := make(map[geom.Point]bool)
+	for unitID, destination := range destinations {
+		tileX, tileY, _ := m.worldPointToTile(destination)
+		if seen[destination] || max(absInt(tileX-10), absInt(tileY-10)) > 1 || m.tileBlockedForMovement(tileX, tileY, 0) {
+			t.Fatalf("unit %d destination tile (%d, %d), want a distinct free tile next to the target", unitID, tileX, tileY)
+		}
+		seen[destination] = true
+	}
+}
+
+// TestManagerCommandSelectedFireOrdersEveryShooter verifies that a group fire order reaches
+// every selected shooter and skips the wall in the same selection.
+func TestManagerCommandSelectedFireOrdersEveryShooter(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	first := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	second := NewRunner(geom.Point{X: 24, Y: 56}, false, 0)
+	wall := NewWall(geom.Point{X: 200, Y: 200})
+	m := newTestManager(gameWorld, first, second, wall)
+	defer m.Close()
+
+	m.applySelection([]Unit{first, second, wall}, SelectionReplace)
+	var fired []int64
+	m.SetCommandRecorder(func(command Command) {
+		if command.Type == CommandFireOrder {
+			fired = append(fired, command.UnitID)
+		}
+	})
+	if err := m.CommandSelectedFire(geom.Point{X: 120, Y: 40}); err != nil {
+		t.Fatalf("CommandSelectedFire() error = %v", err)
+	}
+	if !slices.Equal(fired, []int64{first.UnitID(), second.UnitID()}) {
+		t.Fatalf("fire orders = %v, want both runners", fired)
+	}
+
+	m.applySelection([]Unit{wall}, SelectionReplace)
+	if err := m.CommandSelectedFire(geom.Point{X: 120, Y: 40}); err == nil {
+		t.Fatal("CommandSelectedFire() error = nil, want wall-only selection rejected")
+	}
+}
+
+func TestManagerStopAndHoldOrdersKeepRunnerInPlace(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	runner := NewRunner(geom.Point{X: 8, Y: 8}, false, 0)
+	m := newTestManager(gameWorld, runner)
+	defer m.Close()
+
+	if err := m.IssueMoveOrder(runner.UnitID(), geom.Point{X: 24*16 + 8, Y: 8}); err != nil {
+		t.Fatalf("IssueMoveOrder() error = %v", err)
+	}
+	for tick := int64(1); tick <= 30; tick++ {
+		m.Update(tick)
+	}
+	if err := m.IssueStopOrder(runner.UnitID()); err != nil {
+		t.Fatalf("IssueStopOrder() error = %v", err)
+	}
+	for tick := int64(31); tick <= 120; tick++ {
+		m.Update(tick)
+	}
+
+	position := runner.Base().Position
+	if runner.Base().IsMoving() || position.X >= 12*16 || math.Mod(position.X, 16) != 8 {
+		t.Fatalf("runner after stop at %+v moving=%t, want idle on an early tile centre", position, runner.Base().IsMoving())
+	}
+	assertOrderStatusesPresent(t, m.DrainUnitOrderReports(runner.UnitID()), OrderCanceled, OrderCompleted)
+
+	m.applySelection([]Unit{runner}, SelectionReplace)
+	if err := m.CommandSelectedHold(); err != nil {
+		t.Fatalf("CommandSelectedHold() error = %v", err)
+	}
+	if summary := m.SelectionSummary(); !runner.HoldingPosition() || summary.Holding != 1 || summary.Mobile != 1 {
+		t.Fatal("runner does not hold position after CommandSelectedHold()")
+	}
+	if err := m.IssueMoveOrder(runner.UnitID(), geom.Point{X: 8, Y: 8}); err == nil {
+		t.Fatal("IssueMoveOrder() error = nil, want holding runner to reject scripted moves")
+	}
+	if err := m.CommandSelectedMove(0, 0); err != nil {
+		t.Fatalf("CommandSelectedMove() error = %v", err)
+	}
+	if runner.HoldingPosition() {
+		t.Fatal("player move did not release hold position")
+	}
+}
+
+// TestManagerHoldOrdersReportFailuresAndRecordOnlyRealReleases checks that a rejected hold is
+// reported like a rejected stop, and that stopping a group releases only the unit that held.
+func TestManagerHoldOrdersReportFailuresAndRecordOnlyRealReleases(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	holder := NewRunner(geom.Point{X: 8, Y: 8}, false, 0)
+	walker := NewRunner(geom.Point{X: 40, Y: 8}, false, 0)
+	wall := NewWall(geom.Point{X: 72, Y: 8})
+	m := newTestManager(gameWorld, holder, walker, wall)
+	defer m.Close()
+
+	if err := m.IssueHoldOrder(wall.UnitID(), true); err == nil {
+		t.Fatal("IssueHoldOrder(wall) error = nil, want immobile units to be rejected")
+	}
+	assertOrderStatusesPresent(t, m.DrainUnitOrderReports(wall.UnitID()), OrderFailed)
+
+	if err := m.IssueHoldOrder(holder.UnitID(), true); err != nil {
+		t.Fatalf("IssueHoldOrder() error = %v", err)
+	}
+	var holds []Command
+	m.SetCommandRecorder(func(command Command) {
+		if command.Type == CommandHoldOrder {
+			holds = append(holds, command)
+		}
+	})
+	m.applySelection([]Unit{holder, walker}, SelectionReplace)
+	if err := m.CommandSelectedStop(); err != nil {
+		t.Fatalf("CommandSelectedStop() error = %v", err)
+	}
+	if len(holds) != 1 || holds[0].UnitID != holder.UnitID() || holds[0].Hold {
+		t.Fatalf("recorded hold commands = %+v, want one release of unit %d", holds, holder.UnitID())
+	}
+}
+
+// TestManagerSelectUnitsSkipsRemovedMembersAndCentersOnSurvivors verifies that recalling a stored
+// group selects only the members still alive and that the group center follows them.
+func TestManagerSelectUnitsSkipsRemovedMembersAndCentersOnSurvivors(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	first := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	second := NewRunner(geom.Point{X: 88, Y: 56}, false, 0)
+	removed := NewRunner(geom.Point{X: 200, Y: 200}, false, 0)
+	m := newTestManager(gameWorld, first, second, removed)
+	defer m.Close()
+
+	group := []int64{first.UnitID(), removed.UnitID(), second.UnitID()}
+	if err := m.RemoveUnit(removed.UnitID(), RemovalReasonScripted); err != nil {
+		t.Fatalf("RemoveUnit() error = %v", err)
+	}
+
+	if got := m.SelectUnits(group, SelectionReplace); got != 2 {
+		t.Fatalf("SelectUnits() = %d, want the two surviving members", got)
+	}
+	if center, ok := m.SelectionCenter(); !ok || center != (geom.Point{X: 56, Y: 40}) {
+		t.Fatalf("SelectionCenter() = %+v, %v, want the mean of the survivors", center, ok)
+	}
+}
+
+// TestManagerAppendUnitMarkersReusesBufferAndSkipsRemovedUnits verifies that the marker scan
+// reports live units with their current positions and drops units once they are removed.
+func TestManagerAppendUnitMarkersReusesBufferAndSkipsRemovedUnits(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	runner := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	runner.Team = "red"
+	wall := NewWall(geom.Point{X: 56, Y: 24})
+	m := newTestManager(gameWorld, runner, wall)
+	defer m.Close()
+
+	markers := m.AppendUnitMarkers(nil)
+	want := []UnitMarker{{Kind: KindRunner, Team: "red", Position: runner.Position}, {Kind: KindWall, Position: wall.Position}}
+	if !slices.Equal(markers, want) {
+		t.Fatalf("AppendUnitMarkers() = %+v, want %+v", markers, want)
+	}
+
+	if err := m.RemoveUnit(wall.UnitID(), RemovalReasonScripted); err != nil {
+		t.Fatalf("RemoveUnit() error = %v", err)
+	}
+	markers = m.AppendUnitMarkers(markers[:0])
+	if !slices.Equal(markers, want[:1]) {
+		t.Fatalf("AppendUnitMarkers() after removal = %+v, want the runner only", markers)
+	}
+}
+
+// TestManagerDebugInspectionReportsSearchRouteAndOccupancy verifies the data behind the debug
+// overlays: a traced search around a wall, the queued and then active route of the runner, and
+// the occupancy of the two occupied tiles.
+func TestManagerDebugInspectionReportsSearchRouteAndOccupancy(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	runner := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	wall := NewWall(geom.Point{X: 56, Y: 24})
+	m := newTestManager(gameWorld, runner, wall)
+	defer m.Close()
+
+	m.SetPathSearchTracing(true)
+	if err := m.IssueMoveOrder(runner.UnitID(), m.tileAnchor(5, 1)); err != nil {
+		t.Fatalf("IssueMoveOrder() error = %v", err)
+	}
+	search, ok := m.LastPathSearch()
+	if !ok || search.Err != nil || len(search.Expanded) == 0 || len(search.Path) == 0 {
+		t.Fatalf("LastPathSearch() = %+v, %v, want a traced successful search", search, ok)
+	}
+	if slices.Contains(search.Path, pathfinding.Step{X: 3, Y: 1}) {
+		t.Fatalf("search path %v crosses the wall tile", search.Path)
+	}
+
+	route, ok := m.UnitRoute(runner.UnitID())
+	if !ok || len(route.Path) != 0 || len(route.Queued) != len(search.Path) {
+		t.Fatalf("UnitRoute() before update = %+v, %v, want only the queued route", route, ok)
+	}
+	m.Update(1)
+	route, ok = m.UnitRoute(runner.UnitID())
+	if !ok || len(route.Path) == 0 || len(route.Queued) != 0 {
+		t.Fatalf("UnitRoute() after update = %+v, %v, want the started route", route, ok)
+	}
+	if _, ok := m.UnitRoute(wall.UnitID()); ok {
+		t.Fatal("UnitRoute() for a wall ok = true, want false")
+	}
+
+	occupancy := m.AppendTileOccupancy(nil, image.Rect(0, 0, 8, 8))
+	want := []TileOccupancy{{X: 3, Y: 1, Units: 1, Blocked: true}}
+	if got := occupancy[len(occupancy)-1:]; !slices.Equal(got, want) {
+		t.Fatalf("AppendTileOccupancy() = %+v, want the wall tile last and blocked", occupancy)
+	}
+
+	m.SetPathSearchTracing(false)
+	if _, ok := m.LastPathSearch(); ok {
+		t.Fatal("LastPathSearch() ok = true after tracing was turned off")
+	}
+}
+
 func firstOrderedUnitID(t *testing.T, units *orderedUnitMap) int64 {
 	t.Helper()
 
@@ -1327,13 +2154,59 @@ func firstOrderedUnitID(t *testing.T, units *orderedUnitMap) int64 {
 }
 
 func newTestManager(gameWorld world.World, units ...Unit) *Manager {
-	manager := NewManager(gameWorld)
+	return newTestManagerWithWorkers(gameWorld, 0, units...)
+}
+
+func newTestManagerWithWorkers(gameWorld world.World, workers int, units ...Unit) *Manager {
+	manager := NewManagerWithWorkers(gameWorld, workers)
 	for _, current := range units {
 		manager.AddUnit(current)
 	}
 	return manager
 }
 
+// BenchmarkManagerUpdate10k measures one manager step over 10k bodies: 2000 runners patrolling
+// their own rows and 8000 walls parked in a block below them. Every tick re-routes one in 64
+// runners, so pathfinding stays a small, steady share of the step.
+func BenchmarkManagerUpdate10k(b *testing.B) {
+	const (
+		runnerCount = 2000
+		wallCount   = 8000
+		patrolTiles = 10
+		rerouteTick = 64
+	)
+	gameWorld := world.New(world.Config{Columns: 256, Rows: 256, TileSize: 16})
+	tileCenter := func(x, y int) geom.Point {
+		return geom.Point{X: float64(x)*16 + 8, Y: float64(y)*16 + 8}
+	}
+
+	m := NewManagerWithWorkers(gameWorld, 0)
+	defer m.Close()
+	runners := make([]int64, 0, runnerCount)
+	homes := make([]geom.Point, 0, runnerCount)
+	for index := range runnerCount {
+		home := tileCenter(index%100*2, index/100*4+2)
+		runners = append(runners, m.AddUnit(NewRunner(home, false, index)))
+		homes = append(homes, home)
+	}
+	for index := range wallCount {
+		m.AddUnit(NewWall(tileCenter(index%256, 130+index/256)))
+	}
+
+	tick := int64(0)
+	for b.Loop() {
+		tick++
+		for index := int(tick % rerouteTick); index < runnerCount; index += rerouteTick {
+			target := homes[index]
+			if tick/rerouteTick%2 == 0 {
+				target.X += patrolTiles * 16
+			}
+			_ = m.IssueMoveOrder(runners[index], target)
+		}
+		m.Update(tick)
+	}
+}
+
 func containsCombatEventType(events []CombatEvent, eventType CombatEventType) bool {
 	for _, event := range events {
 		if event.Type == eventType {
@@ -1344,6 +2217,23 @@ func containsCombatEventType(events []CombatEvent, eventType CombatEventType) bo
 	return false
 }
 
+func assertGameplayEventTypesPresent(t *testing.T, events []GameplayEvent, eventTypes ...GameplayEventType) {
+	t.Helper()
+
+	for _, eventType := range eventTypes {
+		found := false
+		for _, event := range events {
+			if event.Type == eventType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("expected %s event in %d collected events", eventType, len(events))
+		}
+	}
+}
+
 func assertCombatEventTypesPresent(t *testing.T, events []CombatEvent, eventTypes ...CombatEventType) {
 	t.Helper()
 
@@ -1,6 +1,9 @@
 package unit
 
-import "github.com/unng-lab/endless/pkg/geom"
+import (
+	"github.com/unng-lab/endless/pkg/assets"
+	"github.com/unng-lab/endless/pkg/geom"
+)
 
 type NonStaticUnit struct {
 	BaseUnit
@@ -10,14 +13,25 @@ type NonStaticUnit struct {
 	MaxHealth     int
 	Health        int
 
-	animation             Animation
-	animationTicks        int
-	moveSpeedPerTick      float64
-	speedAt               func(geom.Point) float64
+	animationTicks int
+	// animationState, stateTicks and facing are draw-only and advance with UpdateVisible;
+	// lastHitTick is the game tick of the latest hit the unit survived.
+	animationState   AnimationState
+	stateTicks       int
+	facing           assets.Direction
+	lastHitTick      int64
+	moveSpeedPerTick float64
+	speedAt          func(geom.Point) float64
+	// fireCooldownRemaining only holds the cooldown while the unit is not registered; bound
+	// units keep it in the slot columns, see weaponCooldown.
 	fireCooldownRemaining int
 
 	projectileBuilder func(*NonStaticUnit, geom.Point) (*Projectile, error)
 	debugRuntimeLogf  func(string, ...any)
+	orderReportSink   func(OrderReport)
+
+	// holdPosition makes the manager reject move orders until a hold order releases it.
+	holdPosition bool
 
 	queuedMove         queuedMoveCommand
 	activeOrder        activeOrderState
@@ -61,8 +75,8 @@ func NewRunner(position geom.Point, focused bool, animationTickOffset int) *NonS
 		Kind:             kind,
 		MaxHealth:        3,
 		Health:           3,
-		animation:        runnerAnimation,
-		animationTicks:   normalizeAnimationOffset(animationTickOffset, runnerAnimation),
+		animationTicks:   normalizeAnimationOffset(animationTickOffset, kindAnimation(kind, assets.ClipRun)),
+		facing:           assets.DirectionEast,
 		moveSpeedPerTick: 0.8,
 	}
 }
@@ -83,8 +97,10 @@ func (u *NonStaticUnit) UnitKind() Kind {
 	return u.Kind
 }
 
+// Frame returns the frame within the clip of the current animation state.
 func (u *NonStaticUnit) Frame() int {
-	return u.animation.frameAt(u.animationTicks)
+	_, frame := u.AnimationClip()
+	return frame
 }
 
 func (u *NonStaticUnit) Name() string {
@@ -151,28 +167,36 @@ func (u *NonStaticUnit) ApplyDamage(amount int) bool {
 		return false
 	}
 
-	u.Health -= amount
+	u.Health = max(u.Health-amount, 0)
+	u.syncHealth(u.Health)
 	if u.Health > 0 {
 		return false
 	}
 
-	u.Health = 0
 	u.prepareForRemovalAfterDeath()
 	return true
 }
 
 func (u *NonStaticUnit) Respawn() {
 	u.cancelTrackedOrders()
-	u.Position = u.SpawnPosition
+	u.setPosition(u.SpawnPosition)
 	u.Health = u.MaxHealth
+	u.syncHealth(u.Health)
 	u.path = u.path[:0]
-	u.sleepTime = 0
-	u.fireCooldownRemaining = 0
+	u.setSleepTime(0)
+	u.setWeaponCooldown(0)
 	u.clearQueuedMove()
 	u.clearTravel()
+	u.lastHitTick = 0
+	u.holdPosition = false
 	u.ClearRemovalMark()
 }
 
+// HoldingPosition reports whether the unit is holding position and rejects move orders.
+func (u *NonStaticUnit) HoldingPosition() bool {
+	return u.holdPosition
+}
+
 func (u *NonStaticUnit) Selectable() bool {
 	return u.Alive()
 }
@@ -209,3 +233,10 @@ func (u *NonStaticUnit) SetProjectileBuilder(builder func(*NonStaticUnit, geom.P
 func (u *NonStaticUnit) SetDebugRuntimeLogger(logger func(string, ...any)) {
 	u.debugRuntimeLogf = logger
 }
+
+// SetOrderReportSink binds a manager-owned observer that sees every order report at the moment
+// it is emitted. The unit still buffers the report for DrainUnitOrderReports; the sink only
+// feeds the shared gameplay event stream.
+func (u *NonStaticUnit) SetOrderReportSink(sink func(OrderReport)) {
+	u.orderReportSink = sink
+}
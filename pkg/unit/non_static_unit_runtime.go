@@ -20,7 +20,7 @@ func (u *NonStaticUnit) Tick(gameTick int64) {
 		return
 	}
 
-	if u.sleepTime > 0 {
+	if u.sleepTimeValue() > 0 {
 		return
 	}
 
@@ -28,17 +28,17 @@ func (u *NonStaticUnit) Tick(gameTick int64) {
 	u.finishInterruptedMoveOrderAtTileBoundary(gameTick)
 	u.startQueuedOrderIfReady(gameTick)
 	if u.activeOrder.hasOrder && u.activeOrder.order.kind == OrderKindFire && u.activeOrder.releasing {
-		if u.sleepTime == 0 {
+		if u.sleepTimeValue() == 0 {
 			u.releasePreparedFireOrder()
 		}
-		u.travel.remaining = u.sleepTime
+		u.travelRef().remaining = u.sleepTimeValue()
 		return
 	}
 
 	u.promoteQueuedMoveIfReady()
-	u.sleepTime = u.advance(gameTick)
+	u.setSleepTime(u.advance(gameTick))
 	u.completeMoveOrderIfFinished()
-	u.travel.remaining = u.sleepTime
+	u.travelRef().remaining = u.sleepTimeValue()
 }
 
 // UpdateVisible advances only the render-facing state for a visible unit. The manager calls
@@ -49,7 +49,7 @@ func (u *NonStaticUnit) UpdateVisible(gameTick int64) {
 		return
 	}
 
-	u.animationTicks++
+	u.updateAnimation(gameTick)
 	u.AdvanceVisibleTravel(gameTick)
 }
 
@@ -86,7 +86,7 @@ func (u *NonStaticUnit) QueueMoveCommand(path []geom.Point) {
 	}
 
 	u.cancelTrackedOrders()
-	if u.sleepTime > 0 {
+	if u.sleepTimeValue() > 0 {
 		u.queueNextMove(path)
 		return
 	}
@@ -100,13 +100,13 @@ func (u *NonStaticUnit) QueueMoveCommand(path []geom.Point) {
 func (u *NonStaticUnit) setPathWithoutOrderReset(path []geom.Point) {
 	u.path = append(u.path[:0], path...)
 	u.clearQueuedMove()
-	u.sleepTime = 0
+	u.setSleepTime(0)
 }
 
 // promoteQueuedMoveIfReady swaps in the latest deferred move order once the previous travel
 // segment has fully completed and the unit is allowed to accept a new movement command.
 func (u *NonStaticUnit) promoteQueuedMoveIfReady() {
-	if u.sleepTime > 0 || !u.queuedMove.hasRoute {
+	if u.sleepTimeValue() > 0 || !u.queuedMove.hasRoute {
 		return
 	}
 
@@ -132,26 +132,36 @@ func (u *NonStaticUnit) clearQueuedMove() {
 func (u *NonStaticUnit) prepareForRemovalAfterDeath() {
 	u.cancelTrackedOrders()
 	u.path = u.path[:0]
-	u.sleepTime = 0
-	u.fireCooldownRemaining = 0
+	u.setSleepTime(0)
+	u.setWeaponCooldown(0)
 	u.clearQueuedMove()
 	u.clearTravel()
 	u.MarkForRemoval()
 }
 
-// advanceWeaponCooldown spends one simulation tick from the remaining fire cooldown even when
-// the unit is otherwise asleep between path steps. This keeps weapon readiness tied to real
-// game ticks instead of only to active Tick callbacks.
-func (u *NonStaticUnit) advanceWeaponCooldown() {
-	if u == nil || u.fireCooldownRemaining <= 0 {
-		return
+// weaponCooldown returns the remaining fire cooldown. While the unit is registered the
+// cooldown lives in the slot columns and keeps counting down on every manager step, even while
+// the unit sleeps between path steps and is not visited, so weapon readiness stays tied to
+// real game ticks instead of only to active Tick callbacks.
+func (u *NonStaticUnit) weaponCooldown() int {
+	if u.columns != nil {
+		u.columns.settle(u.slot)
+		return int(u.columns.cooldown[u.slot])
 	}
+	return u.fireCooldownRemaining
+}
 
-	u.fireCooldownRemaining--
+func (u *NonStaticUnit) setWeaponCooldown(ticks int) {
+	if u.columns != nil {
+		u.columns.settle(u.slot)
+		u.columns.cooldown[u.slot] = int32(ticks)
+		return
+	}
+	u.fireCooldownRemaining = ticks
 }
 
 func (u *NonStaticUnit) weaponReady() bool {
-	return u != nil && u.fireCooldownRemaining == 0
+	return u != nil && u.weaponCooldown() == 0
 }
 
 // advance schedules movement to the next reachable waypoint and returns how many ticks the
@@ -210,7 +220,7 @@ func (u *NonStaticUnit) startTravel(gameTick int64, target geom.Point, currentSp
 	distance := math.Hypot(dx, dy)
 	travelTicks := travelTicksForDistance(distance, currentSpeed)
 
-	u.travel = travelState{
+	*u.travelRef() = travelState{
 		from:            u.RenderPosition(),
 		to:              target,
 		duration:        travelTicks,
@@ -218,7 +228,7 @@ func (u *NonStaticUnit) startTravel(gameTick int64, target geom.Point, currentSp
 		visualRemaining: travelTicks,
 		active:          true,
 	}
-	u.Position = target
+	u.setPosition(target)
 	u.path = u.path[1:]
 	if u.debugRuntimeLogf != nil {
 		u.debugRuntimeLogf(
@@ -156,7 +156,7 @@ func (u *NonStaticUnit) enqueueOrder(gameTick int64, order unitOrder) {
 				u.queuedOrder.order.kind.String(),
 				u.activeOrder.order.id,
 				u.activeOrder.order.kind.String(),
-				u.sleepTime,
+				u.sleepTimeValue(),
 				len(u.path),
 			)
 		} else {
@@ -173,7 +173,7 @@ func (u *NonStaticUnit) enqueueOrder(gameTick int64, order unitOrder) {
 				len(order.path),
 				u.activeOrder.order.id,
 				u.activeOrder.order.kind.String(),
-				u.sleepTime,
+				u.sleepTimeValue(),
 				len(u.path),
 			)
 		}
@@ -220,7 +220,7 @@ func (u *NonStaticUnit) finishInterruptedMoveOrderAtTileBoundary(gameTick int64)
 	if !u.queuedOrder.hasOrder {
 		return
 	}
-	if len(u.path) == 0 || u.sleepTime > 0 {
+	if len(u.path) == 0 || u.sleepTimeValue() > 0 {
 		return
 	}
 
@@ -260,7 +260,7 @@ func (u *NonStaticUnit) completeMoveOrderIfFinished() {
 // startQueuedOrderIfReady promotes the latest queued order into the active slot at the moment
 // the unit is allowed to begin a new gameplay action.
 func (u *NonStaticUnit) startQueuedOrderIfReady(gameTick int64) {
-	if u.activeOrder.hasOrder || !u.queuedOrder.hasOrder || u.sleepTime > 0 {
+	if u.activeOrder.hasOrder || !u.queuedOrder.hasOrder || u.sleepTimeValue() > 0 {
 		return
 	}
 
@@ -321,8 +321,8 @@ func (u *NonStaticUnit) startFireOrder(order unitOrder) bool {
 
 	u.preparedProjectile = projectile
 	u.activeOrder.releasing = true
-	u.sleepTime = fireOrderWindupTicks
-	u.travel.remaining = u.sleepTime
+	u.setSleepTime(fireOrderWindupTicks)
+	u.travelRef().remaining = u.sleepTimeValue()
 	return true
 }
 
@@ -337,7 +337,7 @@ func (u *NonStaticUnit) releasePreparedFireOrder() {
 		u.pendingProjectiles = append(u.pendingProjectiles, u.preparedProjectile)
 	}
 	u.preparedProjectile = nil
-	u.fireCooldownRemaining = fireOrderCooldownTicks
+	u.setWeaponCooldown(fireOrderCooldownTicks)
 	u.emitOrderReport(OrderCompleted, u.activeOrder.order)
 	u.clearActiveOrder()
 }
@@ -362,12 +362,16 @@ func (u *NonStaticUnit) clearActiveOrder() {
 }
 
 func (u *NonStaticUnit) emitOrderReport(status OrderStatus, order unitOrder) {
-	u.orderReports = append(u.orderReports, OrderReport{
+	report := OrderReport{
 		OrderID:     order.id,
 		UnitID:      order.unitID,
 		Kind:        order.kind,
 		Status:      status,
 		TargetPoint: order.targetPoint,
 		Direction:   order.direction,
-	})
+	}
+	u.orderReports = append(u.orderReports, report)
+	if u.orderReportSink != nil {
+		u.orderReportSink(report)
+	}
 }
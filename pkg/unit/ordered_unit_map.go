@@ -2,11 +2,13 @@ package unit
 
 // orderedUnitMap keeps units addressable by their stable ID while preserving insertion order.
 // The ordered slice stores Unit values directly so iteration walks a dense backing array instead
-// of chasing an extra heap-allocated entry object per unit.
+// of chasing an extra heap-allocated entry object per unit. The columns hold the hot simulation
+// state of the same slots in struct-of-arrays form, with the Unit objects acting as views.
 type orderedUnitMap struct {
 	order     []Unit
 	index     map[int64]int
 	freeSlots []int
+	columns   unitColumns
 }
 
 // newOrderedUnitMap allocates the ordered lookup once so the manager can add units without
@@ -20,6 +22,7 @@ func newOrderedUnitMap(capacity int) *orderedUnitMap {
 		order:     make([]Unit, 0, capacity),
 		index:     make(map[int64]int, capacity),
 		freeSlots: make([]int, 0),
+		columns:   newUnitColumns(capacity),
 	}
 }
 
@@ -60,7 +63,9 @@ func (m *orderedUnitMap) Set(unit Unit) {
 
 	unitID := unit.UnitID()
 	if index, exists := m.index[unitID]; exists {
+		m.columns.detach(index, m.order[index])
 		m.order[index] = unit
+		m.columns.bind(index, unit)
 		return
 	}
 
@@ -68,11 +73,15 @@ func (m *orderedUnitMap) Set(unit Unit) {
 	if ok {
 		m.order[freeIndex] = unit
 		m.index[unitID] = freeIndex
+		m.columns.bind(freeIndex, unit)
 		return
 	}
 
-	m.index[unitID] = len(m.order)
+	index := len(m.order)
+	m.index[unitID] = index
 	m.order = append(m.order, unit)
+	m.columns.appendSlot()
+	m.columns.bind(index, unit)
 }
 
 // ReleaseDeletedSlot returns the physical slot of a deleted unit back to the reusable slot
@@ -93,6 +102,7 @@ func (m *orderedUnitMap) ReleaseDeletedSlot(unitID int64) {
 	}
 
 	delete(m.index, unitID)
+	m.columns.detach(index, unit)
 	m.order[index] = nil
 	m.freeSlots = append(m.freeSlots, index)
 }
@@ -116,6 +126,21 @@ func (m *orderedUnitMap) Get(unitID int64) (Unit, bool) {
 	return unit, true
 }
 
+// BlocksMovement answers the pathfinding occupancy question for one unit straight from the
+// flag and health columns, without dereferencing the unit object.
+func (m *orderedUnitMap) BlocksMovement(unitID int64) bool {
+	if m == nil || unitID == 0 {
+		return false
+	}
+
+	index, ok := m.index[unitID]
+	if !ok || index < 0 || index >= len(m.order) {
+		return false
+	}
+
+	return m.columns.blocksMovement(index)
+}
+
 // At returns the live unit at the given insertion-order slot while hiding entries that were
 // already marked deleted and only await overwrite by some future insertion.
 func (m *orderedUnitMap) At(index int) (Unit, bool) {
@@ -154,3 +154,43 @@ func TestOrderedUnitMapReusesDeletedSlot(t *testing.T) {
 		t.Fatalf("At(2) returned %p, want pre-release replacement %p", gotPreReleaseReplacement, preReleaseReplacement)
 	}
 }
+
+// TestOrderedUnitMapColumnsFollowSlotLifecycle verifies that a stored unit reads its sleep timer
+// and lifecycle flags from the slot columns, keeps them after its slot is released and reused,
+// and that the new occupant starts from its own state instead of the previous columns.
+func TestOrderedUnitMapColumnsFollowSlotLifecycle(t *testing.T) {
+	units := newOrderedUnitMap(1)
+	wall := NewWall(geom.Point{X: 8, Y: 8})
+	wall.SetUnitID(1)
+	units.Set(wall)
+
+	wall.setSleepTime(5)
+	if got := units.columns.sleepTime[0]; got != 5 {
+		t.Fatalf("sleepTime column = %d, want 5", got)
+	}
+	if units.columns.needsTick(0) {
+		t.Fatal("needsTick() for sleeping wall = true, want false")
+	}
+	if !units.BlocksMovement(1) {
+		t.Fatal("BlocksMovement(1) = false, want live wall to block")
+	}
+
+	wall.MarkForRemoval()
+	if units.BlocksMovement(1) {
+		t.Fatal("BlocksMovement(1) = true, want wall pending removal to stop blocking")
+	}
+	units.ReleaseDeletedSlot(1)
+	if wall.SleepTime() != 5 || !wall.PendingRemoval() {
+		t.Fatalf("released wall sleep=%d pending=%v, want its last column state", wall.SleepTime(), wall.PendingRemoval())
+	}
+
+	runner := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	runner.SetUnitID(2)
+	units.Set(runner)
+	if runner.SleepTime() != 0 || runner.PendingRemoval() || !units.columns.needsTick(0) {
+		t.Fatalf("reused slot sleep=%d pending=%v, want fresh runner state that needs ticks", runner.SleepTime(), runner.PendingRemoval())
+	}
+	if wall.SleepTime() != 5 {
+		t.Fatalf("released wall sleep after slot reuse = %d, want 5", wall.SleepTime())
+	}
+}
@@ -15,20 +15,18 @@ import (
 	"github.com/unng-lab/endless/pkg/geom"
 )
 
-const (
-	sheetWidth  = 256
-	sheetHeight = 96
-	frameWidth  = 32
-	frameHeight = 32
-	frameStartY = 32
-	frameCount  = 8
-)
-
+// Renderer draws unit bodies. Sprite sheets, frame rectangles and unit sizes come from the
+// asset manifest; sheets are loaded per quality on first use and frames are cached as
+// sub-images of them.
 type Renderer struct {
 	mu     sync.Mutex
-	sheets map[assets.Quality]map[Kind]*ebiten.Image
-	frames map[assets.Quality]map[Kind]map[int]*ebiten.Image
+	sheets map[assets.Quality]map[string]*ebiten.Image
+	frames map[assets.Quality]map[string]map[image.Rectangle]*ebiten.Image
 	solid  *ebiten.Image
+
+	// interpolation is the elapsed fraction of the next simulation tick for the current frame.
+	// Manager.Draw refreshes it before every traversal.
+	interpolation float64
 }
 
 func NewRenderer() *Renderer {
@@ -36,12 +34,22 @@ func NewRenderer() *Renderer {
 	solid.Fill(color.White)
 
 	return &Renderer{
-		sheets: make(map[assets.Quality]map[Kind]*ebiten.Image),
-		frames: make(map[assets.Quality]map[Kind]map[int]*ebiten.Image),
+		sheets: make(map[assets.Quality]map[string]*ebiten.Image),
+		frames: make(map[assets.Quality]map[string]map[image.Rectangle]*ebiten.Image),
 		solid:  solid,
 	}
 }
 
+// Reload forgets every loaded sheet and frame, so the next draw loads them again from the
+// current manifest and image sources.
+func (r *Renderer) Reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sheets = make(map[assets.Quality]map[string]*ebiten.Image)
+	r.frames = make(map[assets.Quality]map[string]map[image.Rectangle]*ebiten.Image)
+}
+
 // DrawUnit renders exactly one gameplay body. The manager drives the traversal order so tile
 // stacks can be walked in visible-tile order for both regular units and projectiles.
 func (r *Renderer) DrawUnit(
@@ -61,16 +69,16 @@ func (r *Renderer) DrawUnit(
 	switch body := current.(type) {
 	case *NonStaticUnit:
 		if !kindUsesSprite(body.UnitKind()) {
-			r.drawStatic(screen, camPos, scale, worldTileSize, body.UnitKind(), body.Base().RenderPosition())
+			r.drawStatic(screen, camPos, scale, worldTileSize, body.UnitKind(), r.renderPosition(body.Base()))
 		} else {
 			if err := r.drawAnimatedUnit(screen, camPos, scale, worldTileSize, quality, body); err != nil {
 				return err
 			}
 		}
-		r.drawHealthBar(screen, bodyScreenRect(cam, worldTileSize, body.UnitKind(), body.Base().RenderPosition()), body.CurrentHealth(), body.MaxHealthValue())
+		r.drawHealthBar(screen, bodyScreenRect(cam, worldTileSize, body.UnitKind(), r.renderPosition(body.Base())), body.CurrentHealth(), body.MaxHealthValue())
 	case *StaticUnit:
-		r.drawStatic(screen, camPos, scale, worldTileSize, body.UnitKind(), body.Base().RenderPosition())
-		r.drawHealthBar(screen, bodyScreenRect(cam, worldTileSize, body.UnitKind(), body.Base().RenderPosition()), body.CurrentHealth(), body.MaxHealthValue())
+		r.drawStatic(screen, camPos, scale, worldTileSize, body.UnitKind(), r.renderPosition(body.Base()))
+		r.drawHealthBar(screen, bodyScreenRect(cam, worldTileSize, body.UnitKind(), r.renderPosition(body.Base())), body.CurrentHealth(), body.MaxHealthValue())
 	case *Projectile:
 		r.drawProjectile(screen, camPos, scale, body)
 	default:
@@ -88,28 +96,100 @@ func (r *Renderer) drawAnimatedUnit(
 	quality assets.Quality,
 	body *NonStaticUnit,
 ) error {
-	metrics := kindVisualMetrics(body.UnitKind())
-	screenUnitWidth := worldTileSize * scale * metrics.widthTiles
-	screenUnitHeight := worldTileSize * scale * metrics.heightTiles
-
-	frame, err := r.frameImage(body.UnitKind(), body.Frame(), quality)
+	clip, clipFrame := body.AnimationClip()
+	frame, mirrored, err := r.frameImage(body.UnitKind(), clip, body.Facing(), clipFrame, quality)
 	if err != nil {
 		return err
 	}
 
+	op := r.spriteDrawOptions(frame, mirrored, camPos, scale, worldTileSize, body.UnitKind(), r.renderPosition(body.Base()))
+	if body.AnimationState() == AnimationHit && clip != assets.ClipHit {
+		// Sheets without a hit clip flash the unit red instead.
+		op.ColorScale.Scale(1, 0.45, 0.45, 1)
+	}
+	screen.DrawImage(frame, &op)
+	return nil
+}
+
+// spriteDrawOptions scales a frame to the kind's size on screen and stands it on the render
+// position. Mirrored frames are flipped around their own centre.
+func (r *Renderer) spriteDrawOptions(
+	frame *ebiten.Image,
+	mirrored bool,
+	camPos geom.Point,
+	scale float64,
+	worldTileSize float64,
+	kind Kind,
+	renderPos geom.Point,
+) ebiten.DrawImageOptions {
+	metrics := kindVisualMetrics(kind)
+	screenUnitWidth := worldTileSize * scale * metrics.widthTiles
+	screenUnitHeight := worldTileSize * scale * metrics.heightTiles
+
 	frameBounds := frame.Bounds()
 	frameScale := screenUnitWidth / float64(frameBounds.Dx())
-	renderPos := body.Base().RenderPosition()
 	screenX := (renderPos.X - camPos.X) * scale
 	screenY := (renderPos.Y - camPos.Y) * scale
 
 	var op ebiten.DrawImageOptions
+	if mirrored {
+		op.GeoM.Scale(-1, 1)
+		op.GeoM.Translate(float64(frameBounds.Dx()), 0)
+	}
 	op.GeoM.Scale(frameScale, frameScale)
 	op.GeoM.Translate(screenX-screenUnitWidth/2, screenY-screenUnitHeight*metrics.anchorY)
+	return op
+}
+
+// drawCorpse plays the death clip of a killed unit and then fades it out. Sheets without a
+// death clip topple the first idle frame over around the unit's feet instead, away from the
+// way the unit faced.
+func (r *Renderer) drawCorpse(
+	screen *ebiten.Image,
+	cam *camera.Camera,
+	worldTileSize float64,
+	quality assets.Quality,
+	corpse unitCorpse,
+	elapsed int64,
+) error {
+	clip, animation := stateClip(corpse.kind, AnimationDeath)
+	dyingTicks := int64(corpseTipTicks)
+	clipFrame := 0
+	if clip == assets.ClipDeath {
+		dyingTicks = int64(animation.FrameCount * animation.FrameTicks)
+		clipFrame = animation.frameAt(int(elapsed))
+	}
+	frame, mirrored, err := r.frameImage(corpse.kind, clip, corpse.facing, clipFrame, quality)
+	if err != nil {
+		return err
+	}
+
+	camPos := cam.Position()
+	scale := cam.Scale()
+	op := r.spriteDrawOptions(frame, mirrored, camPos, scale, worldTileSize, corpse.kind, corpse.position)
+	if clip != assets.ClipDeath {
+		metrics := kindVisualMetrics(corpse.kind)
+		feetX := (corpse.position.X - camPos.X) * scale
+		feetY := (corpse.position.Y-camPos.Y)*scale + worldTileSize*scale*metrics.heightTiles*(1-metrics.anchorY)
+		angle := math.Pi / 2 * geom.ClampFloat(float64(elapsed)/corpseTipTicks, 0, 1)
+		if !mirrored {
+			angle = -angle
+		}
+		op.GeoM.Translate(-feetX, -feetY)
+		op.GeoM.Rotate(angle)
+		op.GeoM.Translate(feetX, feetY)
+	}
+	fade := geom.ClampFloat(float64(elapsed-dyingTicks)/corpseFadeTicks, 0, 1)
+	op.ColorScale.ScaleAlpha(float32(1 - fade))
 	screen.DrawImage(frame, &op)
 	return nil
 }
 
+// renderPosition resolves the drawn world position of one body for the current frame.
+func (r *Renderer) renderPosition(base *BaseUnit) geom.Point {
+	return base.InterpolatedRenderPosition(r.interpolation)
+}
+
 func ScreenRect(cam *camera.Camera, worldTileSize float64, unit Unit) geom.Rect {
 	return bodyScreenRect(cam, worldTileSize, unit.UnitKind(), unit.Base().RenderPosition())
 }
@@ -179,26 +259,34 @@ type visualMetrics struct {
 	anchorY     float64
 }
 
-func kindVisualMetrics(kind Kind) visualMetrics {
-	switch kind {
-	case KindWall:
-		return visualMetrics{widthTiles: 1.15, heightTiles: 1.25, anchorY: 0.95}
-	case KindBarricade:
-		return visualMetrics{widthTiles: 1.3, heightTiles: 0.85, anchorY: 0.86}
-	case KindRunner, KindRunnerFocused:
-		fallthrough
-	default:
-		return visualMetrics{widthTiles: 2.0, heightTiles: 2.0, anchorY: 0.85}
+// defaultVisualMetrics sizes kinds the manifest does not list.
+var defaultVisualMetrics = visualMetrics{widthTiles: 2.0, heightTiles: 2.0, anchorY: 0.85}
+
+// kindVisuals looks the kind up in the asset manifest. The sprite is nil for kinds drawn from
+// built-in shapes and for every kind when the manifest failed to load.
+func kindVisuals(kind Kind) (visualMetrics, string, *assets.Sprite) {
+	manifest, err := assets.DefaultManifest()
+	if err != nil {
+		return defaultVisualMetrics, "", nil
 	}
+	visuals, ok := manifest.UnitVisuals(string(kind))
+	if !ok {
+		return defaultVisualMetrics, "", nil
+	}
+
+	metrics := visualMetrics{widthTiles: visuals.WidthTiles, heightTiles: visuals.HeightTiles, anchorY: visuals.AnchorY}
+	sprite, _ := manifest.Sprite(visuals.Sprite)
+	return metrics, visuals.Sprite, sprite
+}
+
+func kindVisualMetrics(kind Kind) visualMetrics {
+	metrics, _, _ := kindVisuals(kind)
+	return metrics
 }
 
 func kindUsesSprite(kind Kind) bool {
-	switch kind {
-	case KindRunner, KindRunnerFocused:
-		return true
-	default:
-		return false
-	}
+	_, _, sprite := kindVisuals(kind)
+	return sprite != nil
 }
 
 func (r *Renderer) drawStatic(screen *ebiten.Image, camPos geom.Point, scale, worldTileSize float64, kind Kind, renderPos geom.Point) {
@@ -256,7 +344,7 @@ func (r *Renderer) drawProjectile(screen *ebiten.Image, camPos geom.Point, scale
 		progress := geom.ClampFloat(float64(shot.impactTicks)/float64(shot.impactDurationTicks), 0, 1)
 		alpha := uint8(math.Round((1 - progress) * 220))
 		size := shot.impactRadius * 2 * scale * (1 + progress*0.8)
-		renderPos := shot.Base().RenderPosition()
+		renderPos := r.renderPosition(shot.Base())
 		screenX := (renderPos.X - camPos.X) * scale
 		screenY := (renderPos.Y - camPos.Y) * scale
 
@@ -266,7 +354,7 @@ func (r *Renderer) drawProjectile(screen *ebiten.Image, camPos geom.Point, scale
 	}
 
 	size := math.Max(2, shot.Radius*2*scale)
-	renderPos := shot.Base().RenderPosition()
+	renderPos := r.renderPosition(shot.Base())
 	screenX := (renderPos.X - camPos.X) * scale
 	screenY := (renderPos.Y - camPos.Y) * scale
 	glowSize := size * 1.8
@@ -295,106 +383,67 @@ func (r *Renderer) drawHealthBar(screen *ebiten.Image, rect geom.Rect, health, m
 	r.drawFilledRect(screen, rect.Min.X, top, fillWidth, height, fillColor)
 }
 
-func (r *Renderer) frameImage(kind Kind, frameIndex int, quality assets.Quality) (*ebiten.Image, error) {
-	if frameIndex < 0 || frameIndex >= frameCount {
-		return nil, fmt.Errorf("unit frame %d is out of range", frameIndex)
+// frameImage returns one frame of a clip of the kind's sprite for the facing, and whether it
+// has to be drawn mirrored. clipFrame counts within the clip, as NonStaticUnit.AnimationClip
+// reports it.
+func (r *Renderer) frameImage(kind Kind, clip string, facing assets.Direction, clipFrame int, quality assets.Quality) (*ebiten.Image, bool, error) {
+	_, spriteName, sprite := kindVisuals(kind)
+	if sprite == nil {
+		return nil, false, fmt.Errorf("unit kind %q has no sprite in the asset manifest", kind)
+	}
+	rect, mirrored, err := sprite.FrameRect(clip, facing, clipFrame, quality)
+	if err != nil {
+		return nil, false, err
 	}
 
-	sheet, cfg, err := r.ensureSheet(kind, quality)
+	sheet, err := r.ensureSheet(spriteName, sprite, quality)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if _, ok := r.frames[quality]; !ok {
-		r.frames[quality] = make(map[Kind]map[int]*ebiten.Image)
+		r.frames[quality] = make(map[string]map[image.Rectangle]*ebiten.Image)
 	}
-	if _, ok := r.frames[quality][kind]; !ok {
-		r.frames[quality][kind] = make(map[int]*ebiten.Image)
+	if _, ok := r.frames[quality][spriteName]; !ok {
+		r.frames[quality][spriteName] = make(map[image.Rectangle]*ebiten.Image)
 	}
-	if frame := r.frames[quality][kind][frameIndex]; frame != nil {
-		return frame, nil
+	if frame := r.frames[quality][spriteName][rect]; frame != nil {
+		return frame, mirrored, nil
 	}
 
-	x := frameIndex * cfg.frameWidth
-	rect := image.Rect(x, cfg.frameStartY, x+cfg.frameWidth, cfg.frameStartY+cfg.frameHeight)
 	if !rect.In(sheet.Bounds()) {
-		return nil, fmt.Errorf("unit frame %d exceeds sprite sheet bounds", frameIndex)
+		return nil, false, fmt.Errorf("unit frame %v exceeds sprite sheet bounds", rect)
 	}
 
 	frame := sheet.SubImage(rect).(*ebiten.Image)
-	r.frames[quality][kind][frameIndex] = frame
-	return frame, nil
-}
-
-type sheetConfig struct {
-	fileName    string
-	width       int
-	height      int
-	frameWidth  int
-	frameHeight int
-	frameStartY int
+	r.frames[quality][spriteName][rect] = frame
+	return frame, mirrored, nil
 }
 
-func (r *Renderer) ensureSheet(kind Kind, quality assets.Quality) (*ebiten.Image, sheetConfig, error) {
-	cfg, err := spriteSheetConfig(kind, quality)
+func (r *Renderer) ensureSheet(spriteName string, sprite *assets.Sprite, quality assets.Quality) (*ebiten.Image, error) {
+	fileName, width, height, err := sprite.Sheet(quality)
 	if err != nil {
-		return nil, sheetConfig{}, err
+		return nil, err
 	}
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if _, ok := r.sheets[quality]; !ok {
-		r.sheets[quality] = make(map[Kind]*ebiten.Image)
+		r.sheets[quality] = make(map[string]*ebiten.Image)
 	}
-	if sheet := r.sheets[quality][kind]; sheet != nil {
-		return sheet, cfg, nil
+	if sheet := r.sheets[quality][spriteName]; sheet != nil {
+		return sheet, nil
 	}
 
-	sheet, err := img.Img(cfg.fileName, uint64(cfg.width), uint64(cfg.height))
+	sheet, err := img.Img(fileName, uint64(width), uint64(height))
 	if err != nil {
-		return nil, sheetConfig{}, fmt.Errorf("load unit sprite sheet %q: %w", cfg.fileName, err)
+		return nil, fmt.Errorf("load unit sprite sheet %q: %w", fileName, err)
 	}
 
-	r.sheets[quality][kind] = sheet
-	return sheet, cfg, nil
-}
-
-func spriteSheetConfig(kind Kind, quality assets.Quality) (sheetConfig, error) {
-	fileName := string(kind) + ".png"
-
-	switch quality {
-	case assets.QualityLow:
-		return sheetConfig{
-			fileName:    fileName,
-			width:       sheetWidth,
-			height:      sheetHeight,
-			frameWidth:  frameWidth,
-			frameHeight: frameHeight,
-			frameStartY: frameStartY,
-		}, nil
-	case assets.QualityMedium:
-		return sheetConfig{
-			fileName:    fileName,
-			width:       sheetWidth * 2,
-			height:      sheetHeight * 2,
-			frameWidth:  frameWidth * 2,
-			frameHeight: frameHeight * 2,
-			frameStartY: frameStartY * 2,
-		}, nil
-	case assets.QualityHigh:
-		return sheetConfig{
-			fileName:    fileName,
-			width:       sheetWidth * 4,
-			height:      sheetHeight * 4,
-			frameWidth:  frameWidth * 4,
-			frameHeight: frameHeight * 4,
-			frameStartY: frameStartY * 4,
-		}, nil
-	default:
-		return sheetConfig{}, fmt.Errorf("unsupported unit sprite quality %d", quality)
-	}
+	r.sheets[quality][spriteName] = sheet
+	return sheet, nil
 }
@@ -0,0 +1,299 @@
+package unit
+
+import (
+	"math/rand"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// RespawnMode selects when and where a killed unit comes back.
+type RespawnMode string
+
+const (
+	// RespawnNone leaves killed units dead. It is the default for units without a policy.
+	RespawnNone RespawnMode = "none"
+	// RespawnAtOrigin brings the unit back at its spawn position once DelayTicks have passed.
+	RespawnAtOrigin RespawnMode = "origin"
+	// RespawnAtRandomPoint brings the unit back at a random free point from Points once
+	// DelayTicks have passed.
+	RespawnAtRandomPoint RespawnMode = "random_point"
+	// RespawnInWaves collects killed units and brings them back together at their spawn
+	// positions on the next tick that is a multiple of WaveTicks after DelayTicks have passed.
+	RespawnInWaves RespawnMode = "wave"
+)
+
+// RespawnPolicy describes the respawn rule for one unit, one team or one unit kind. DelayTicks counts
+// from the tick the unit was removed and is at least one, so a unit never comes back inside
+// the update that killed it. Lives caps how often one unit may respawn; zero means no cap.
+type RespawnPolicy struct {
+	Mode       RespawnMode
+	DelayTicks int64
+	WaveTicks  int64
+	Points     []geom.Point
+	Lives      int
+}
+
+// Respawner applies respawn policies on top of the manager lifecycle hooks. Killed units that
+// have a policy are kept aside and re-registered through AddUnit once they are due, with the
+// same ID, so the respawn shows up as a regular spawn event and in recorded command streams.
+// Scripted removals and expired projectiles are never respawned.
+//
+// Per-unit policies win over per-team policies, which win over per-kind policies. Update must
+// be called on the goroutine that drives the manager, before the manager step of the same tick,
+// the same way scenarios release their scheduled spawns.
+type Respawner struct {
+	manager *Manager
+	rng     *rand.Rand
+
+	kindPolicies map[Kind]RespawnPolicy
+	teamPolicies map[Team]RespawnPolicy
+	unitPolicies map[int64]RespawnPolicy
+	bodies       map[int64]Unit
+	respawns     map[int64]int
+	pending      []pendingRespawn
+	removeHooks  func()
+}
+
+type pendingRespawn struct {
+	body    Unit
+	policy  RespawnPolicy
+	dueTick int64
+}
+
+// NewRespawner attaches a respawner to the manager. The seed drives the random spawn point
+// choice, so one seed always picks the same points for the same sequence of deaths.
+func NewRespawner(manager *Manager, seed int64) *Respawner {
+	r := &Respawner{
+		manager:      manager,
+		rng:          rand.New(rand.NewSource(seed)),
+		kindPolicies: make(map[Kind]RespawnPolicy),
+		teamPolicies: make(map[Team]RespawnPolicy),
+		unitPolicies: make(map[int64]RespawnPolicy),
+		bodies:       make(map[int64]Unit),
+		respawns:     make(map[int64]int),
+	}
+	r.removeHooks = manager.AddLifecycleHooks(LifecycleHooks{
+		OnSpawn:  r.trackSpawn,
+		OnRemove: r.queueRemoval,
+	})
+	return r
+}
+
+// Close detaches the respawner from the manager. Units still waiting for their respawn stay
+// dead.
+func (r *Respawner) Close() {
+	if r == nil || r.removeHooks == nil {
+		return
+	}
+
+	r.removeHooks()
+	r.removeHooks = nil
+	r.pending = nil
+}
+
+// SetKindPolicy assigns the policy to every unit of the kind that has neither a policy of its
+// own nor one of its team, including units that are already registered.
+func (r *Respawner) SetKindPolicy(kind Kind, policy RespawnPolicy) {
+	if r == nil {
+		return
+	}
+
+	r.kindPolicies[kind] = policy
+	r.manager.units.Range(func(current Unit) bool {
+		if current.UnitKind() == kind {
+			r.track(current)
+		}
+		return true
+	})
+}
+
+// SetTeamPolicy assigns the policy to every unit of the team that has no policy of its own,
+// including units that are already registered. It overrides the policies of their kinds, so
+// two teams fielding the same kind can follow different rules.
+func (r *Respawner) SetTeamPolicy(team Team, policy RespawnPolicy) {
+	if r == nil {
+		return
+	}
+
+	r.teamPolicies[team] = policy
+	r.manager.units.Range(func(current Unit) bool {
+		if current.Base().Team == team {
+			r.track(current)
+		}
+		return true
+	})
+}
+
+// SetUnitPolicy assigns the policy to one unit, overriding the policies of its team and kind.
+func (r *Respawner) SetUnitPolicy(unitID int64, policy RespawnPolicy) {
+	if r == nil {
+		return
+	}
+
+	r.unitPolicies[unitID] = policy
+	if body, ok := r.manager.unitByID(unitID); ok {
+		r.track(body)
+	}
+}
+
+// RespawnsUsed reports how many times the unit has respawned so far.
+func (r *Respawner) RespawnsUsed(unitID int64) int {
+	if r == nil {
+		return 0
+	}
+
+	return r.respawns[unitID]
+}
+
+// PendingRespawns reports how many killed units are waiting for their respawn.
+func (r *Respawner) PendingRespawns() int {
+	if r == nil {
+		return 0
+	}
+
+	return len(r.pending)
+}
+
+// Update re-registers every unit whose respawn is due on this tick. A unit whose spawn point
+// is blocked, or that finds no free point in its list, stays pending and tries again on the
+// next tick.
+func (r *Respawner) Update(gameTick int64) {
+	if r == nil || len(r.pending) == 0 {
+		return
+	}
+
+	due := r.pending
+	r.pending = nil
+	for _, entry := range due {
+		if gameTick < entry.dueTick || !r.respawn(entry) {
+			r.pending = append(r.pending, entry)
+		}
+	}
+}
+
+func (r *Respawner) policyFor(body Unit) RespawnPolicy {
+	if policy, ok := r.unitPolicies[body.UnitID()]; ok {
+		return policy
+	}
+	if policy, ok := r.teamPolicies[body.Base().Team]; ok && body.Base().Team != "" {
+		return policy
+	}
+	if policy, ok := r.kindPolicies[body.UnitKind()]; ok {
+		return policy
+	}
+
+	return RespawnPolicy{Mode: RespawnNone}
+}
+
+func (r *Respawner) track(body Unit) {
+	if body.UnitKind() == KindProjectile {
+		return
+	}
+	if r.policyFor(body).Mode == RespawnNone {
+		delete(r.bodies, body.UnitID())
+		return
+	}
+
+	r.bodies[body.UnitID()] = body
+}
+
+func (r *Respawner) trackSpawn(event GameplayEvent) {
+	if body, ok := r.manager.unitByID(event.UnitID); ok {
+		r.track(body)
+	}
+}
+
+// queueRemoval keeps a killed body aside until its policy makes it due.
+func (r *Respawner) queueRemoval(event GameplayEvent) {
+	body, ok := r.bodies[event.UnitID]
+	if !ok {
+		return
+	}
+	delete(r.bodies, event.UnitID)
+	if event.Reason != RemovalReasonKilled {
+		return
+	}
+
+	policy := r.policyFor(body)
+	if policy.Mode == RespawnNone || (policy.Lives > 0 && r.respawns[event.UnitID] >= policy.Lives) {
+		return
+	}
+
+	r.pending = append(r.pending, pendingRespawn{
+		body:    body,
+		policy:  policy,
+		dueTick: respawnDueTick(policy, event.Tick),
+	})
+}
+
+// respawnDueTick resolves the first tick on which a unit removed on removedTick may return.
+func respawnDueTick(policy RespawnPolicy, removedTick int64) int64 {
+	dueTick := removedTick + max(policy.DelayTicks, 1)
+	if policy.Mode != RespawnInWaves || policy.WaveTicks <= 0 {
+		return dueTick
+	}
+
+	if remainder := dueTick % policy.WaveTicks; remainder != 0 {
+		dueTick += policy.WaveTicks - remainder
+	}
+	return dueTick
+}
+
+// respawn resets the body through its own Respawn hook and registers it again. It reports
+// false when the target position is not free yet.
+func (r *Respawner) respawn(entry pendingRespawn) bool {
+	position, ok := r.respawnPosition(entry)
+	if !ok {
+		return false
+	}
+
+	entry.body.Respawn()
+	entry.body.Base().setPosition(position)
+	r.respawns[entry.body.UnitID()]++
+	r.manager.AddUnit(entry.body)
+	return true
+}
+
+func (r *Respawner) respawnPosition(entry pendingRespawn) (geom.Point, bool) {
+	if entry.policy.Mode != RespawnAtRandomPoint {
+		position := spawnPositionOf(entry.body)
+		return position, r.manager.validateSpawn(position, entry.body.UnitID()) == nil
+	}
+
+	free := make([]geom.Point, 0, len(entry.policy.Points))
+	for _, point := range entry.policy.Points {
+		if r.pointFree(point, entry.body.UnitID()) {
+			free = append(free, point)
+		}
+	}
+	if len(free) == 0 {
+		return geom.Point{}, false
+	}
+
+	return free[r.rng.Intn(len(free))], true
+}
+
+// pointFree reports whether the point passes the spawn rules and no live unit stands in its
+// tile, so a random respawn never lands on top of another body.
+func (r *Respawner) pointFree(point geom.Point, unitID int64) bool {
+	if r.manager.validateSpawn(point, unitID) != nil {
+		return false
+	}
+
+	tileX, tileY, _ := r.manager.worldPointToTile(point)
+	return !r.manager.tileStackAtKey(tileKey{x: tileX, y: tileY}).anyUnit(func(occupantID int64) bool {
+		_, alive := r.manager.unitByID(occupantID)
+		return alive
+	})
+}
+
+func spawnPositionOf(body Unit) geom.Point {
+	switch current := body.(type) {
+	case *NonStaticUnit:
+		return current.SpawnPosition
+	case *StaticUnit:
+		return current.SpawnPosition
+	default:
+		return body.Base().Position
+	}
+}
@@ -0,0 +1,160 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// TestRespawnerBringsKilledRunnerBackAtOriginUntilLivesRunOut verifies the delay, the stable
+// ID, the spawn event and the lives cap of the origin policy.
+func TestRespawnerBringsKilledRunnerBackAtOriginUntilLivesRunOut(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	runner := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	m := newTestManager(gameWorld, runner)
+	defer m.Close()
+
+	respawner := NewRespawner(m, 1)
+	defer respawner.Close()
+	respawner.SetUnitPolicy(runner.UnitID(), RespawnPolicy{Mode: RespawnAtOrigin, DelayTicks: 5, Lives: 1})
+	spawns := m.Subscribe(EventFilter{Types: []GameplayEventType{GameplayEventUnitSpawned}})
+	defer spawns.Close()
+
+	runner.setPosition(geom.Point{X: 88, Y: 24})
+	runner.ApplyDamage(runner.MaxHealth)
+	m.Update(1)
+	for tick := int64(2); tick < 6; tick++ {
+		respawner.Update(tick)
+		m.Update(tick)
+		if _, ok := m.unitByID(runner.UnitID()); ok {
+			t.Fatalf("tick %d: runner back before its respawn delay", tick)
+		}
+	}
+
+	respawner.Update(6)
+	body, ok := m.unitByID(runner.UnitID())
+	if !ok {
+		t.Fatal("unitByID() = false, want runner respawned once its delay passed")
+	}
+	if body.Base().Position != runner.SpawnPosition || body.CurrentHealth() != runner.MaxHealth {
+		t.Fatalf("respawned at %+v with %d health, want %+v with full health", body.Base().Position, body.CurrentHealth(), runner.SpawnPosition)
+	}
+	if events := spawns.Drain(); len(events) != 1 || events[0].UnitID != runner.UnitID() {
+		t.Fatalf("spawn events = %+v, want one spawn for the respawned runner", events)
+	}
+
+	runner.ApplyDamage(runner.MaxHealth)
+	m.Update(6)
+	respawner.Update(100)
+	if _, ok := m.unitByID(runner.UnitID()); ok || respawner.PendingRespawns() != 0 {
+		t.Fatal("runner respawned again, want its single life spent")
+	}
+	if got := respawner.RespawnsUsed(runner.UnitID()); got != 1 {
+		t.Fatalf("RespawnsUsed() = %d, want 1", got)
+	}
+}
+
+// TestRespawnerRandomPointSkipsOccupiedAndBlockedPoints verifies that the random policy only
+// draws from points whose tile is empty and passes the spawn rules.
+func TestRespawnerRandomPointSkipsOccupiedAndBlockedPoints(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	runner := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	occupant := NewRunner(geom.Point{X: 104, Y: 104}, false, 0)
+	wall := NewWall(geom.Point{X: 136, Y: 104})
+	m := newTestManager(gameWorld, runner, occupant, wall)
+	defer m.Close()
+
+	free := geom.Point{X: 168, Y: 104}
+	respawner := NewRespawner(m, 7)
+	defer respawner.Close()
+	respawner.SetKindPolicy(KindRunner, RespawnPolicy{
+		Mode:   RespawnAtRandomPoint,
+		Points: []geom.Point{occupant.Position, wall.Position, {X: -8, Y: 8}, free},
+	})
+
+	runner.ApplyDamage(runner.MaxHealth)
+	m.Update(1)
+	respawner.Update(2)
+
+	if _, ok := m.unitByID(runner.UnitID()); !ok {
+		t.Fatal("unitByID() = false, want runner respawned at the only free point")
+	}
+	if runner.Position != free {
+		t.Fatalf("respawn position = %+v, want free point %+v", runner.Position, free)
+	}
+	if key, _ := m.registeredTileKey(runner.UnitID()); key != m.tileKeyForUnit(runner) {
+		t.Fatalf("registered tile = %+v, want tile of the respawn point", key)
+	}
+}
+
+// TestRespawnerWavePolicyReleasesDeadUnitsTogether verifies that units killed on different
+// ticks come back on the same wave tick, and that scripted removals are not respawned.
+func TestRespawnerWavePolicyReleasesDeadUnitsTogether(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	first := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	second := NewRunner(geom.Point{X: 56, Y: 24}, false, 0)
+	scripted := NewRunner(geom.Point{X: 88, Y: 24}, false, 0)
+	m := newTestManager(gameWorld, first, second, scripted)
+	defer m.Close()
+
+	respawner := NewRespawner(m, 1)
+	defer respawner.Close()
+	respawner.SetKindPolicy(KindRunner, RespawnPolicy{Mode: RespawnInWaves, WaveTicks: 10})
+
+	first.ApplyDamage(first.MaxHealth)
+	m.Update(1)
+	second.ApplyDamage(second.MaxHealth)
+	m.Update(4)
+	if err := m.RemoveUnit(scripted.UnitID(), RemovalReasonScripted); err != nil {
+		t.Fatalf("RemoveUnit() error = %v", err)
+	}
+
+	for tick := int64(5); tick < 10; tick++ {
+		respawner.Update(tick)
+		m.Update(tick)
+	}
+	if respawner.PendingRespawns() != 2 {
+		t.Fatalf("PendingRespawns() = %d before the wave, want 2", respawner.PendingRespawns())
+	}
+
+	respawner.Update(10)
+	for _, current := range []*NonStaticUnit{first, second} {
+		if _, ok := m.unitByID(current.UnitID()); !ok {
+			t.Fatalf("unit %d not back on the wave tick", current.UnitID())
+		}
+	}
+	if _, ok := m.unitByID(scripted.UnitID()); ok {
+		t.Fatal("scripted removal respawned, want it to stay removed")
+	}
+}
+
+// TestRespawnerTeamPolicyOverridesKindForRunnersOfOneSide verifies that two teams fielding the
+// same kind follow their own rules and that a team survives the respawn.
+func TestRespawnerTeamPolicyOverridesKindForRunnersOfOneSide(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	red := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	red.Team = "red"
+	blue := NewRunner(geom.Point{X: 56, Y: 24}, false, 0)
+	blue.Team = "blue"
+	m := newTestManager(gameWorld, red, blue)
+	defer m.Close()
+
+	respawner := NewRespawner(m, 1)
+	defer respawner.Close()
+	respawner.SetKindPolicy(KindRunner, RespawnPolicy{Mode: RespawnAtOrigin})
+	respawner.SetTeamPolicy("blue", RespawnPolicy{Mode: RespawnNone})
+
+	red.ApplyDamage(red.MaxHealth)
+	blue.ApplyDamage(blue.MaxHealth)
+	m.Update(1)
+	respawner.Update(2)
+
+	body, ok := m.unitByID(red.UnitID())
+	if !ok || body.Base().Team != "red" {
+		t.Fatal("red runner not back on its team, want the kind policy to respawn it")
+	}
+	if _, ok := m.unitByID(blue.UnitID()); ok || respawner.PendingRespawns() != 0 {
+		t.Fatal("blue runner respawned, want its team policy to keep it dead")
+	}
+}
@@ -134,20 +134,21 @@ func (s *StaticUnit) ApplyDamage(amount int) bool {
 	}
 
 	s.Wake()
-	s.Health -= amount
+	s.Health = max(s.Health-amount, 0)
+	s.syncHealth(s.Health)
 	if s.Health > 0 {
 		return false
 	}
 
-	s.Health = 0
 	s.clearTravel()
 	s.MarkForRemoval()
 	return true
 }
 
 func (s *StaticUnit) Respawn() {
-	s.Position = s.SpawnPosition
+	s.setPosition(s.SpawnPosition)
 	s.Health = s.MaxHealth
+	s.syncHealth(s.Health)
 	s.clearTravel()
 	s.Wake()
 	s.ClearRemovalMark()
@@ -0,0 +1,179 @@
+package unit
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// tickWheelSize is the number of buckets in the timing wheel. It must be a power of two;
+// slots that sleep longer than one revolution simply stay in their bucket for later laps.
+const tickWheelSize = 256
+
+// tickScheduler decides which slots the update workers visit on each manager step. Instead of
+// striding over every slot, it keeps a timing wheel keyed by the step each slot has to be
+// visited on next: mobile units are filed under the step their sleep ends, units with work on
+// every step are filed under the next step, and static bodies parked until an external wake
+// are not filed at all. State changes that make a slot due earlier, such as damage waking a
+// wall or a new order cancelling a sleep, go through requestWake.
+//
+// The due slots of a step are then split into per-worker partitions by spatial region, so
+// bodies that walk through the same part of the map are handled by the same worker and mostly
+// touch the same tile-registry shard. Workers that finish their own partition steal batches
+// from the others, which keeps one crowded region from stalling the whole step.
+type tickScheduler struct {
+	step     int64
+	tileSize float64
+	wheel    [tickWheelSize][]int32
+
+	wakeMu       sync.Mutex
+	wakeRequests []int32
+
+	// due is a bitmap over slots that deduplicates wheel entries and wake requests and yields
+	// the due slots in slot order, which keeps single-worker updates in the old slot order.
+	due        []uint64
+	awake      []int32
+	partitions [][]int32
+	cursors    []atomic.Int64
+}
+
+func newTickScheduler(partitions int, tileSize float64) *tickScheduler {
+	partitions = max(partitions, 1)
+	return &tickScheduler{
+		tileSize:   tileSize,
+		partitions: make([][]int32, partitions),
+		cursors:    make([]atomic.Int64, partitions),
+	}
+}
+
+// requestWake asks for a visit of the slot on the next step. It may be called from update
+// workers while a step is running.
+func (s *tickScheduler) requestWake(slot int) {
+	s.wakeMu.Lock()
+	s.wakeRequests = append(s.wakeRequests, int32(slot))
+	s.wakeMu.Unlock()
+}
+
+// schedule files the slot under the given step. Older entries of the slot become stale and
+// are dropped when their bucket comes up.
+func (s *tickScheduler) schedule(columns *unitColumns, slot int, step int64) {
+	columns.wakeAt[slot] = step
+	if step == tickNever {
+		return
+	}
+
+	bucket := &s.wheel[step&(tickWheelSize-1)]
+	*bucket = append(*bucket, int32(slot))
+}
+
+// advance moves to the next step, collects the slots due on it and partitions them between
+// the workers. It must run while no worker is active.
+func (s *tickScheduler) advance(columns *unitColumns) []int32 {
+	s.step++
+	step := s.step
+	slots := len(columns.flags)
+	words := (slots + 63) / 64
+	if cap(s.due) < words {
+		s.due = make([]uint64, words)
+	}
+	s.due = s.due[:words]
+	clear(s.due)
+
+	index := step & (tickWheelSize - 1)
+	bucket := s.wheel[index]
+	kept := bucket[:0]
+	for _, slot := range bucket {
+		if int(slot) >= slots {
+			continue
+		}
+		switch wakeAt := columns.wakeAt[slot]; {
+		case wakeAt == step:
+			s.markDue(int(slot))
+		case wakeAt > step && wakeAt != tickNever && wakeAt&(tickWheelSize-1) == index:
+			kept = append(kept, slot)
+		}
+	}
+	s.wheel[index] = kept
+
+	s.wakeMu.Lock()
+	for _, slot := range s.wakeRequests {
+		if int(slot) < slots {
+			s.markDue(int(slot))
+		}
+	}
+	s.wakeRequests = s.wakeRequests[:0]
+	s.wakeMu.Unlock()
+
+	s.awake = s.awake[:0]
+	for word, mask := range s.due {
+		for mask != 0 {
+			slot := word*64 + bits.TrailingZeros64(mask)
+			mask &= mask - 1
+			if !columns.needsTick(slot) {
+				columns.wakeAt[slot] = tickNever
+				continue
+			}
+			columns.wakeAt[slot] = step
+			s.awake = append(s.awake, int32(slot))
+		}
+	}
+
+	s.partition(columns)
+	return s.awake
+}
+
+func (s *tickScheduler) markDue(slot int) {
+	s.due[slot/64] |= 1 << (slot % 64)
+}
+
+// partition spreads the awake slots over the workers by the spatial region they stand in.
+func (s *tickScheduler) partition(columns *unitColumns) {
+	for index := range s.partitions {
+		s.partitions[index] = s.partitions[index][:0]
+		s.cursors[index].Store(0)
+	}
+	if len(s.partitions) == 1 {
+		s.partitions[0] = append(s.partitions[0], s.awake...)
+		return
+	}
+
+	for _, slot := range s.awake {
+		index := tileRegionShardIndex(s.tileKeyAt(columns.positions[slot])) % len(s.partitions)
+		s.partitions[index] = append(s.partitions[index], slot)
+	}
+}
+
+func (s *tickScheduler) tileKeyAt(position geom.Point) tileKey {
+	if s.tileSize <= 0 {
+		return tileKey{}
+	}
+
+	return tileKey{
+		x: int(math.Floor(position.X / s.tileSize)),
+		y: int(math.Floor(position.Y / s.tileSize)),
+	}
+}
+
+// takeBatch claims the next batch of slots from one partition. Several workers may claim from
+// the same partition concurrently; each slot is handed out exactly once per step.
+func (s *tickScheduler) takeBatch(partition int) []int32 {
+	slots := s.partitions[partition]
+	end := int(s.cursors[partition].Add(updateBatchSize))
+	start := end - updateBatchSize
+	if start >= len(slots) {
+		return nil
+	}
+
+	return slots[start:min(end, len(slots))]
+}
+
+// rescheduleAwake files every slot visited on this step under its next due step. It must run
+// after the workers finished and before released slots can be reused.
+func (s *tickScheduler) rescheduleAwake(columns *unitColumns) {
+	for _, slot := range s.awake {
+		s.schedule(columns, int(slot), columns.nextWake(int(slot)))
+	}
+}
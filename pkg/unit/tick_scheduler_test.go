@@ -0,0 +1,104 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/world"
+)
+
+// TestTickSchedulerSkipsSleepingRunnerWhileCountersKeepRunning verifies that a runner sleeping
+// between two tiles drops out of the awake set until its sleep ends, while SleepTime and the
+// weapon cooldown still count down once per step exactly like the old per-step visit did.
+func TestTickSchedulerSkipsSleepingRunnerWhileCountersKeepRunning(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	runner := NewRunner(geom.Point{X: 8, Y: 8}, false, 0)
+	m := newTestManagerWithWorkers(gameWorld, 1, runner)
+	defer m.Close()
+
+	runner.SetPath([]geom.Point{{X: 24, Y: 8}, {X: 40, Y: 8}})
+	runner.setWeaponCooldown(3)
+	m.Update(1)
+	sleep := runner.SleepTime()
+	if sleep < 3 {
+		t.Fatalf("SleepTime() after first step = %d, want a multi-tick segment", sleep)
+	}
+
+	for tick := int64(2); tick < int64(1+sleep); tick++ {
+		m.Update(tick)
+		if len(m.scheduler.awake) != 0 {
+			t.Fatalf("tick %d awake slots = %v, want sleeping runner skipped", tick, m.scheduler.awake)
+		}
+		if got, want := runner.SleepTime(), sleep-int(tick-1); got != want {
+			t.Fatalf("tick %d SleepTime() = %d, want %d", tick, got, want)
+		}
+	}
+	if got := runner.weaponCooldown(); got != 0 {
+		t.Fatalf("weaponCooldown() = %d, want it spent while the runner slept", got)
+	}
+
+	m.Update(int64(1 + sleep))
+	if len(m.scheduler.awake) != 1 || runner.LastUpdateTick() != int64(1+sleep) {
+		t.Fatalf("awake=%v lastUpdateTick=%d, want runner ticked when its sleep ended", m.scheduler.awake, runner.LastUpdateTick())
+	}
+}
+
+// TestTickSchedulerWakesSleepingRunnerForNewOrder verifies that shortening a scheduled sleep
+// from outside the update loop brings the slot forward to the next step instead of leaving it
+// parked in the wheel until the old wake step.
+func TestTickSchedulerWakesSleepingRunnerForNewOrder(t *testing.T) {
+	gameWorld := world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16})
+	runner := NewRunner(geom.Point{X: 8, Y: 8}, false, 0)
+	m := newTestManagerWithWorkers(gameWorld, 1, runner)
+	defer m.Close()
+
+	runner.SetPath([]geom.Point{{X: 24, Y: 8}})
+	m.Update(1)
+	if runner.SleepTime() <= 1 {
+		t.Fatalf("SleepTime() = %d, want the runner asleep", runner.SleepTime())
+	}
+
+	runner.setSleepTime(0)
+	m.Update(2)
+	if runner.LastUpdateTick() != 2 {
+		t.Fatalf("LastUpdateTick() = %d, want the woken runner ticked on the next step", runner.LastUpdateTick())
+	}
+}
+
+// TestTickSchedulerBatchesHandOutEverySlotOnce verifies the work-stealing contract: workers
+// draining partitions in different orders still claim each awake slot exactly once.
+func TestTickSchedulerBatchesHandOutEverySlotOnce(t *testing.T) {
+	scheduler := newTickScheduler(3, 16)
+	for partition := range scheduler.partitions {
+		for slot := range 40 {
+			scheduler.partitions[partition] = append(scheduler.partitions[partition], int32(partition*100+slot))
+		}
+	}
+
+	seen := make(map[int32]int)
+	for worker := range 3 {
+		for offset := range 3 {
+			partition := (worker + offset) % 3
+			batch := scheduler.takeBatch(partition)
+			for _, slot := range batch {
+				seen[slot]++
+			}
+		}
+	}
+	for partition := range 3 {
+		for batch := scheduler.takeBatch(partition); len(batch) > 0; batch = scheduler.takeBatch(partition) {
+			for _, slot := range batch {
+				seen[slot]++
+			}
+		}
+	}
+
+	if len(seen) != 120 {
+		t.Fatalf("claimed %d distinct slots, want 120", len(seen))
+	}
+	for slot, count := range seen {
+		if count != 1 {
+			t.Fatalf("slot %d claimed %d times, want once", slot, count)
+		}
+	}
+}
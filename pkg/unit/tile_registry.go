@@ -0,0 +1,184 @@
+package unit
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// tileRegionSize is the edge of one square spatial region in tiles. Regions are the unit of
+	// both registry locking and worker partitioning, so bodies that move inside one region keep
+	// hitting the same shard from the same worker.
+	tileRegionSize = 16
+	// tileRegistryShards must stay a power of two because shard indices are taken with a mask.
+	tileRegistryShards = 64
+)
+
+// tileRegistry replaces the single registry mutex with region shards for tile stacks and
+// unit-ID shards for the unit-to-tile lookup. A tile move locks the unit shard first and then
+// each region shard on its own, so two workers moving bodies in different regions no longer
+// serialize on one lock.
+type tileRegistry struct {
+	regions [tileRegistryShards]tileRegionShard
+	units   [tileRegistryShards]tileUnitShard
+
+	// stacks counts the non-empty stacks across all region shards for the metrics.
+	stacks atomic.Int64
+}
+
+type tileRegionShard struct {
+	mu     sync.RWMutex
+	stacks map[tileKey]*TileStack
+}
+
+type tileUnitShard struct {
+	mu    sync.Mutex
+	tiles map[int64]tileKey
+}
+
+func newTileRegistry() *tileRegistry {
+	registry := &tileRegistry{}
+	for index := range registry.regions {
+		registry.regions[index].stacks = make(map[tileKey]*TileStack)
+		registry.units[index].tiles = make(map[int64]tileKey)
+	}
+	return registry
+}
+
+// tileRegionShardIndex hashes the region that contains the tile. Neighbouring regions land in
+// different shards, which spreads a dense battlefield across every shard.
+func tileRegionShardIndex(key tileKey) int {
+	regionX := floorDiv(key.x, tileRegionSize)
+	regionY := floorDiv(key.y, tileRegionSize)
+	hash := uint32(regionX)*73856093 ^ uint32(regionY)*19349663
+	return int(hash & (tileRegistryShards - 1))
+}
+
+func floorDiv(value, divisor int) int {
+	quotient := value / divisor
+	if value%divisor != 0 && value < 0 {
+		quotient--
+	}
+	return quotient
+}
+
+func (r *tileRegistry) regionFor(key tileKey) *tileRegionShard {
+	return &r.regions[tileRegionShardIndex(key)]
+}
+
+func (r *tileRegistry) unitShardFor(unitID int64) *tileUnitShard {
+	return &r.units[uint64(unitID)&(tileRegistryShards-1)]
+}
+
+// stackAt returns the stack for one tile, or nil when nobody is registered there.
+func (r *tileRegistry) stackAt(key tileKey) *TileStack {
+	region := r.regionFor(key)
+	region.mu.RLock()
+	stack := region.stacks[key]
+	region.mu.RUnlock()
+	return stack
+}
+
+// registeredTile reports the tile a unit is currently registered in.
+func (r *tileRegistry) registeredTile(unitID int64) (tileKey, bool) {
+	shard := r.unitShardFor(unitID)
+	shard.mu.Lock()
+	key, ok := shard.tiles[unitID]
+	shard.mu.Unlock()
+	return key, ok
+}
+
+// enter adds the unit to the stack of the tile and returns that stack.
+func (r *tileRegistry) enter(unit Unit, key tileKey) *TileStack {
+	region := r.regionFor(key)
+	region.mu.Lock()
+	defer region.mu.Unlock()
+
+	stack, ok := region.stacks[key]
+	if !ok {
+		stack = &TileStack{}
+		region.stacks[key] = stack
+		r.stacks.Add(1)
+	}
+	unit.EnterTile(stack)
+	return stack
+}
+
+// leave removes the unit from the stack of the tile and drops the stack once it is empty.
+func (r *tileRegistry) leave(unit Unit, key tileKey) {
+	region := r.regionFor(key)
+	region.mu.Lock()
+	defer region.mu.Unlock()
+
+	stack := region.stacks[key]
+	if stack == nil {
+		return
+	}
+
+	unit.LeaveTile(stack)
+	if stack.Empty() {
+		delete(region.stacks, key)
+		r.stacks.Add(-1)
+	}
+}
+
+// register binds a unit that is not registered yet to the tile.
+func (r *tileRegistry) register(unit Unit, key tileKey) {
+	shard := r.unitShardFor(unit.UnitID())
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	r.enter(unit, key)
+	shard.tiles[unit.UnitID()] = key
+}
+
+// unregister removes the unit from whatever tile it is registered in and reports whether it
+// was registered at all.
+func (r *tileRegistry) unregister(unit Unit) bool {
+	shard := r.unitShardFor(unit.UnitID())
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	key, ok := shard.tiles[unit.UnitID()]
+	if !ok {
+		return false
+	}
+
+	r.leave(unit, key)
+	delete(shard.tiles, unit.UnitID())
+	return true
+}
+
+// move transfers the unit from its registered tile, or from the fallback tile when it has no
+// registration, into the target tile. It returns the entered stack, or nil when the unit was
+// already registered in the target tile.
+func (r *tileRegistry) move(unit Unit, from, to tileKey) *TileStack {
+	shard := r.unitShardFor(unit.UnitID())
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if registeredKey, ok := shard.tiles[unit.UnitID()]; ok {
+		from = registeredKey
+	}
+	if from == to {
+		return nil
+	}
+
+	r.leave(unit, from)
+	stack := r.enter(unit, to)
+	shard.tiles[unit.UnitID()] = to
+	return stack
+}
+
+// rangeStacks visits every non-empty stack. The order is unspecified; callers that need a
+// stable order sort the keys themselves.
+func (r *tileRegistry) rangeStacks(visitor func(tileKey, *TileStack)) {
+	for index := range r.regions {
+		region := &r.regions[index]
+		region.mu.RLock()
+		for key, stack := range region.stacks {
+			visitor(key, stack)
+		}
+		region.mu.RUnlock()
+	}
+}
@@ -0,0 +1,50 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// TestTileRegistryMovesUnitAcrossRegionShards verifies that a move between tiles owned by
+// different region shards leaves exactly one registration behind and drops the emptied stack.
+func TestTileRegistryMovesUnitAcrossRegionShards(t *testing.T) {
+	registry := newTileRegistry()
+	runner := NewRunner(geom.Point{X: 8, Y: 8}, false, 0)
+	runner.SetUnitID(7)
+
+	from := tileKey{x: tileRegionSize - 1, y: 0}
+	to := tileKey{x: tileRegionSize, y: 0}
+	if tileRegionShardIndex(from) == tileRegionShardIndex(to) {
+		t.Fatalf("tiles %+v and %+v share a shard, want neighbouring regions in different shards", from, to)
+	}
+
+	registry.register(runner, from)
+	if stack := registry.move(runner, tileKey{}, to); stack == nil {
+		t.Fatal("move() = nil, want the entered stack")
+	}
+	if registry.stackAt(from) != nil {
+		t.Fatalf("stackAt(%+v) != nil, want emptied stack dropped", from)
+	}
+	if got, ok := registry.registeredTile(runner.UnitID()); !ok || got != to {
+		t.Fatalf("registeredTile() = %+v, %v, want %+v", got, ok, to)
+	}
+	if stack := registry.move(runner, from, to); stack != nil {
+		t.Fatal("move() into the registered tile returned a stack, want nil")
+	}
+
+	if !registry.unregister(runner) || registry.stackAt(to) != nil {
+		t.Fatal("unregister() left the unit registered")
+	}
+}
+
+// TestTileRegionShardIndexGroupsNegativeTilesByFloor verifies that negative coordinates fall
+// into the region below zero instead of sharing region zero with the first positive tiles.
+func TestTileRegionShardIndexGroupsNegativeTilesByFloor(t *testing.T) {
+	if floorDiv(-1, tileRegionSize) != -1 || floorDiv(-tileRegionSize, tileRegionSize) != -1 || floorDiv(tileRegionSize-1, tileRegionSize) != 0 {
+		t.Fatal("floorDiv() does not round towards negative infinity")
+	}
+	if tileRegionShardIndex(tileKey{x: -1, y: 0}) != tileRegionShardIndex(tileKey{x: -tileRegionSize, y: 0}) {
+		t.Fatal("tiles of one negative region map to different shards")
+	}
+}
@@ -64,6 +64,37 @@ func (s *TileStack) UnitIDs() []int64 {
 	return append([]int64(nil), s.unitIDs...)
 }
 
+// anyUnit reports whether match accepts any unit in the stack. It walks the stack under the
+// read lock instead of copying it, so match must not touch tile stacks itself; the pathfinding
+// occupancy check uses it to avoid one allocation per explored tile.
+func (s *TileStack) anyUnit(match func(int64) bool) bool {
+	if s == nil {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, unitID := range s.unitIDs {
+		if match(unitID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of units registered in the stack.
+func (s *TileStack) Len() int {
+	if s == nil {
+		return 0
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.unitIDs)
+}
+
 // Empty reports whether the tile stack currently has no registered units and can therefore be
 // removed from the manager's sparse tile map.
 func (s *TileStack) Empty() bool {
@@ -2,6 +2,10 @@ package unit
 
 type Kind string
 
+// Team names the side a unit plays for. Teams are free-form labels chosen by the scenario, so
+// two teams may field the same unit kinds; an empty team means the unit belongs to none.
+type Team string
+
 const (
 	KindRunner        Kind = "runner"
 	KindRunnerFocused Kind = "runnerfocused"
@@ -9,8 +13,3 @@ const (
 	KindBarricade     Kind = "barricade"
 	KindProjectile    Kind = "projectile"
 )
-
-var runnerAnimation = Animation{
-	FrameCount: 8,
-	FrameTicks: 6,
-}
@@ -0,0 +1,302 @@
+package unit
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/unng-lab/endless/pkg/geom"
+)
+
+// slotFlags packs the per-slot lifecycle bits that the update workers test before they decide
+// whether a unit needs any work at all.
+type slotFlags uint8
+
+const (
+	slotOccupied slotFlags = 1 << iota
+	// slotNonStatic and slotProjectile record the body type so column scans can tell units
+	// apart without a type switch on the unit object.
+	slotNonStatic
+	slotProjectile
+	// slotBlocker marks kinds that block movement while alive; liveness comes from health.
+	slotBlocker
+	slotUpdateSleeping
+	slotPendingRemoval
+	slotRemovalHandled
+)
+
+// tickNever marks a slot that is not scheduled for any future manager step.
+const tickNever int64 = math.MaxInt64
+
+// unitColumns is the struct-of-arrays half of the unit storage. Every slice is indexed by the
+// same physical slot as orderedUnitMap.order, so a worker can scan flags and sleep timers as
+// dense arrays and only dereference the unit object when the slot actually needs a tick.
+//
+// Sleep timers, travel state and lifecycle flags are owned by the columns while a unit is
+// registered; BaseUnit reads and writes them through its slot binding. Positions and health
+// are mirrored here by the unit's own mutation paths because Position and Health remain public
+// fields that callers read directly, and the mirror lets scans such as blocking checks run
+// without touching the unit objects. The team is copied once at registration, because a unit
+// keeps its team for as long as it is registered.
+//
+// Sleep and weapon cooldown counters are settled lazily. The scheduler only visits a slot on
+// the step its sleep ends, so instead of decrementing every counter on every step the columns
+// remember the step up to which a slot was counted down and catch up on the next access. The
+// caught-up values are exactly what the old per-step countdown would have produced, which keeps
+// snapshots and state hashes independent of how often a slot was actually visited. Settling
+// writes the slot, so while the workers run only the worker that was handed the slot may read
+// its counters; changes aimed at other slots are queued and applied after the workers join.
+type unitColumns struct {
+	ids       []int64
+	kinds     []Kind
+	flags     []slotFlags
+	sleepTime []int32
+	cooldown  []int32
+	travel    []travelState
+	positions []geom.Point
+	health    []int32
+	teams     []Team
+
+	// settledAt is the scheduler step the sleep and cooldown counters were last counted to.
+	settledAt []int64
+	// sleepEndedAt is the step on which the sleep counter last reached zero.
+	sleepEndedAt []int64
+	// wakeAt is the step the scheduler will next visit the slot on, or tickNever.
+	wakeAt []int64
+
+	// scheduler supplies the current step and receives early wake requests. It stays nil for
+	// storage that is not owned by a manager, in which case counters never settle on their own.
+	scheduler *tickScheduler
+
+	// kindCounts follows bind and detach so the metrics never scan the columns. Slots are bound
+	// on the goroutine that drives the manager but released from update workers, hence atomics.
+	kindCounts [len(unitKinds) + 1]atomic.Int64
+}
+
+// unitKinds lists the kinds the per-kind counters track; anything else lands in one trailing
+// overflow counter.
+var unitKinds = [...]Kind{KindRunner, KindRunnerFocused, KindWall, KindBarricade, KindProjectile}
+
+func unitKindIndex(kind Kind) int {
+	for index, known := range unitKinds {
+		if known == kind {
+			return index
+		}
+	}
+	return len(unitKinds)
+}
+
+func newUnitColumns(capacity int) unitColumns {
+	return unitColumns{
+		ids:       make([]int64, 0, capacity),
+		kinds:     make([]Kind, 0, capacity),
+		flags:     make([]slotFlags, 0, capacity),
+		sleepTime: make([]int32, 0, capacity),
+		cooldown:  make([]int32, 0, capacity),
+		travel:    make([]travelState, 0, capacity),
+		positions: make([]geom.Point, 0, capacity),
+		health:    make([]int32, 0, capacity),
+		teams:     make([]Team, 0, capacity),
+
+		settledAt:    make([]int64, 0, capacity),
+		sleepEndedAt: make([]int64, 0, capacity),
+		wakeAt:       make([]int64, 0, capacity),
+	}
+}
+
+// appendSlot grows every column by one empty slot so the columns stay as long as the ordered
+// unit slice.
+func (c *unitColumns) appendSlot() {
+	c.ids = append(c.ids, 0)
+	c.kinds = append(c.kinds, "")
+	c.flags = append(c.flags, 0)
+	c.sleepTime = append(c.sleepTime, 0)
+	c.cooldown = append(c.cooldown, 0)
+	c.travel = append(c.travel, travelState{})
+	c.positions = append(c.positions, geom.Point{})
+	c.health = append(c.health, 0)
+	c.teams = append(c.teams, "")
+	c.settledAt = append(c.settledAt, 0)
+	c.sleepEndedAt = append(c.sleepEndedAt, -1)
+	c.wakeAt = append(c.wakeAt, tickNever)
+}
+
+// now returns the scheduler step the counters settle towards.
+func (c *unitColumns) now() int64 {
+	if c.scheduler == nil {
+		return 0
+	}
+	return c.scheduler.step
+}
+
+// bind moves the hot state of a freshly stored unit into its slot and points the unit's
+// BaseUnit at the columns. From here on the column values are authoritative.
+func (c *unitColumns) bind(slot int, unit Unit) {
+	base := unit.Base()
+	flags := slotOccupied | base.flags
+	cooldown := 0
+	switch current := unit.(type) {
+	case *NonStaticUnit:
+		flags |= slotNonStatic
+		cooldown = current.fireCooldownRemaining
+	case *Projectile:
+		flags |= slotProjectile
+	case *StaticUnit:
+		if current.blocksMovement {
+			flags |= slotBlocker
+		}
+	}
+
+	c.ids[slot] = unit.UnitID()
+	c.kinds[slot] = unit.UnitKind()
+	c.kindCounts[unitKindIndex(c.kinds[slot])].Add(1)
+	c.flags[slot] = flags
+	c.sleepTime[slot] = int32(base.sleepTime)
+	c.cooldown[slot] = int32(cooldown)
+	c.travel[slot] = base.travel
+	c.positions[slot] = base.Position
+	c.health[slot] = int32(unit.CurrentHealth())
+	c.teams[slot] = base.Team
+	c.settledAt[slot] = c.now()
+	c.sleepEndedAt[slot] = -1
+	c.wakeAt[slot] = tickNever
+
+	base.columns = c
+	base.slot = slot
+	c.wakeIfNeeded(slot)
+}
+
+// detach copies the owned state back into the unit and clears the slot. Callers still holding
+// the unit object after its slot was released, or reused by another unit, keep reading their
+// own last state instead of the new occupant's columns.
+func (c *unitColumns) detach(slot int, unit Unit) {
+	if unit != nil {
+		base := unit.Base()
+		if base.columns == c && base.slot == slot {
+			c.settle(slot)
+			if body, ok := unit.(*NonStaticUnit); ok {
+				body.fireCooldownRemaining = int(c.cooldown[slot])
+			}
+			base.sleepTime = int(c.sleepTime[slot])
+			base.travel = c.travel[slot]
+			base.flags = c.flags[slot] & unitOwnedFlags
+			base.columns = nil
+			base.slot = 0
+		}
+	}
+
+	if c.kinds[slot] != "" {
+		c.kindCounts[unitKindIndex(c.kinds[slot])].Add(-1)
+	}
+	c.ids[slot] = 0
+	c.kinds[slot] = ""
+	c.flags[slot] = 0
+	c.sleepTime[slot] = 0
+	c.cooldown[slot] = 0
+	c.travel[slot] = travelState{}
+	c.positions[slot] = geom.Point{}
+	c.health[slot] = 0
+	c.teams[slot] = ""
+	c.sleepEndedAt[slot] = -1
+	c.wakeAt[slot] = tickNever
+}
+
+// unitOwnedFlags are the lifecycle bits a BaseUnit carries itself while it is not registered.
+const unitOwnedFlags = slotUpdateSleeping | slotPendingRemoval | slotRemovalHandled
+
+// needsTick reports whether the slot can have work on some future step at all. Empty slots,
+// handled tombstones and bodies in eternal sleep are never scheduled, which is what keeps
+// large obstacle fields cheap; weapon cooldowns need no visit because they settle lazily.
+func (c *unitColumns) needsTick(slot int) bool {
+	flags := c.flags[slot]
+	if flags&slotOccupied == 0 || flags&slotRemovalHandled != 0 {
+		return false
+	}
+	if flags&slotPendingRemoval != 0 {
+		return true
+	}
+
+	return flags&slotUpdateSleeping == 0
+}
+
+// settle counts the slot's sleep and cooldown down to the current step. The rules mirror the
+// per-step visit they replace: the weapon cooldown always runs, while sleep only runs for
+// units that are neither waiting for removal nor parked until an external wake. Flag changes
+// settle first, so the flags seen here held for the whole interval being caught up.
+func (c *unitColumns) settle(slot int) {
+	c.settleTo(slot, c.now())
+}
+
+// settleTo counts the slot down to an explicit step. It never winds counters back, so asking
+// for a step the slot has already passed leaves it unchanged.
+func (c *unitColumns) settleTo(slot int, now int64) {
+	elapsed := now - c.settledAt[slot]
+	if elapsed <= 0 {
+		return
+	}
+
+	from := c.settledAt[slot]
+	c.settledAt[slot] = now
+	if cooldown := int64(c.cooldown[slot]); cooldown > 0 {
+		c.cooldown[slot] = int32(max(cooldown-elapsed, 0))
+	}
+	if c.flags[slot]&(slotPendingRemoval|slotUpdateSleeping) != 0 {
+		return
+	}
+
+	sleep := int64(c.sleepTime[slot])
+	if sleep <= 0 {
+		return
+	}
+
+	steps := min(elapsed, sleep)
+	sleep -= steps
+	c.sleepTime[slot] = int32(sleep)
+	travel := &c.travel[slot]
+	travel.remaining = int(sleep)
+	if sleep == 0 {
+		travel.visualRemaining = 0
+		c.sleepEndedAt[slot] = from + steps
+	}
+}
+
+// sleepEndedNow reports whether the slot's sleep ran out on the current step.
+func (c *unitColumns) sleepEndedNow(slot int) bool {
+	c.settle(slot)
+	return c.sleepEndedAt[slot] == c.now()
+}
+
+// nextWake returns the step the slot has to be visited on next, judged from its settled state.
+func (c *unitColumns) nextWake(slot int) int64 {
+	if !c.needsTick(slot) {
+		return tickNever
+	}
+
+	c.settle(slot)
+	now := c.now()
+	if c.flags[slot]&slotPendingRemoval == 0 && c.sleepTime[slot] > 0 {
+		return now + int64(c.sleepTime[slot])
+	}
+	return now + 1
+}
+
+// wakeIfNeeded asks the scheduler for a visit on the next step when a state change made the
+// slot due earlier than it is currently scheduled. Changes made by the slot's own visit never
+// trigger a request because the visit reschedules the slot afterwards anyway.
+func (c *unitColumns) wakeIfNeeded(slot int) {
+	if c.scheduler == nil || c.wakeAt[slot] <= c.now()+1 {
+		return
+	}
+	if c.nextWake(slot) < c.wakeAt[slot] {
+		c.scheduler.requestWake(slot)
+	}
+}
+
+// blocksMovement reports whether the unit in the slot currently blocks movement, using only
+// the flag and health columns.
+func (c *unitColumns) blocksMovement(slot int) bool {
+	flags := c.flags[slot]
+	return flags&slotOccupied != 0 &&
+		flags&slotBlocker != 0 &&
+		flags&slotPendingRemoval == 0 &&
+		c.health[slot] > 0
+}
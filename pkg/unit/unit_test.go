@@ -3,8 +3,10 @@ package unit
 import (
 	"testing"
 
+	"github.com/unng-lab/endless/pkg/assets"
 	"github.com/unng-lab/endless/pkg/camera"
 	"github.com/unng-lab/endless/pkg/geom"
+	"github.com/unng-lab/endless/pkg/world"
 )
 
 func TestUnitFollowsPathUsingSleepTicks(t *testing.T) {
@@ -77,12 +79,17 @@ func TestUnitVisibleOnScreenReturnsFalseForOffscreenUnit(t *testing.T) {
 
 func TestRunnerAnimationUsesTickOffsetsAndTickStep(t *testing.T) {
 	u := NewRunner(geom.Point{X: 8, Y: 8}, false, 7)
+	u.path = []geom.Point{{X: 24, Y: 8}}
 
+	u.UpdateVisible(1)
+	if got := u.AnimationState(); got != AnimationRun {
+		t.Fatalf("state of a runner with a path = %s, want run", got)
+	}
 	if got := u.Frame(); got != 1 {
-		t.Fatalf("initial frame from tick offset = %d, want 1", got)
+		t.Fatalf("frame after the first visible tick from tick offset = %d, want 1", got)
 	}
 
-	for tick := int64(1); tick <= 5; tick++ {
+	for tick := int64(2); tick <= 5; tick++ {
 		u.UpdateVisible(tick)
 	}
 
@@ -91,6 +98,127 @@ func TestRunnerAnimationUsesTickOffsetsAndTickStep(t *testing.T) {
 	}
 }
 
+func TestRunnerAnimationFollowsStateAndFacing(t *testing.T) {
+	u := NewRunner(geom.Point{X: 40, Y: 8}, false, 0)
+
+	u.UpdateVisible(1)
+	if clip, _ := u.AnimationClip(); u.AnimationState() != AnimationIdle || clip != assets.ClipIdle {
+		t.Fatalf("standing runner = %s/%s, want idle/idle", u.AnimationState(), clip)
+	}
+
+	u.path = []geom.Point{{X: 24, Y: 8}}
+	u.UpdateVisible(2)
+	if u.AnimationState() != AnimationRun || u.Facing() != assets.DirectionWest {
+		t.Fatalf("runner heading west = %s facing %s, want run facing west", u.AnimationState(), u.Facing())
+	}
+
+	u.path = nil
+	u.activeOrder = activeOrderState{
+		order:     unitOrder{kind: OrderKindFire, direction: geom.Point{X: 0, Y: 1}},
+		hasOrder:  true,
+		releasing: true,
+	}
+	u.UpdateVisible(3)
+	clip, frame := u.AnimationClip()
+	if u.AnimationState() != AnimationFireWindup || clip != assets.ClipFire || frame != 0 || u.Facing() != assets.DirectionSouth {
+		t.Fatalf("winding up = %s/%s frame %d facing %s, want fire_windup/fire frame 0 facing south", u.AnimationState(), clip, frame, u.Facing())
+	}
+	for tick := int64(4); tick <= 40; tick++ {
+		u.UpdateVisible(tick)
+	}
+	if _, frame := u.AnimationClip(); frame != 3 {
+		t.Fatalf("fire clip frame after a long windup = %d, want the held last frame 3", frame)
+	}
+
+	u.activeOrder = activeOrderState{}
+	u.lastHitTick = 41
+	u.UpdateVisible(41)
+	if clip, _ := u.AnimationClip(); u.AnimationState() != AnimationHit || clip != assets.ClipIdle {
+		t.Fatalf("hit runner = %s/%s, want hit played on the idle clip", u.AnimationState(), clip)
+	}
+	u.UpdateVisible(41 + hitFlashTicks)
+	if u.AnimationState() != AnimationIdle {
+		t.Fatalf("runner after the hit flash = %s, want idle", u.AnimationState())
+	}
+}
+
+func TestManagerKeepsCorpsesUntilTheyFade(t *testing.T) {
+	m := NewManagerWithWorkers(world.New(world.Config{Columns: 8, Rows: 8, TileSize: 16}), 1)
+	defer m.Close()
+	runner := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	m.AddUnit(runner)
+
+	m.lastGameTick = 5
+	runner.ApplyDamage(runner.Health)
+	m.recordCorpse(runner)
+	if len(m.corpses) != 1 {
+		t.Fatalf("corpses after a kill = %d, want 1", len(m.corpses))
+	}
+
+	m.Update(5 + corpseTicks(KindRunner) - 1)
+	if len(m.corpses) != 1 {
+		t.Fatalf("corpse pruned before it faded")
+	}
+	m.Update(5 + corpseTicks(KindRunner))
+	if len(m.corpses) != 0 {
+		t.Fatalf("corpses after fading = %d, want 0", len(m.corpses))
+	}
+}
+
+// TestManagerHitReactionAndCorpseFollowTheQueuedHits shoots a two-hit runner with several
+// workers and checks that the flinch and the corpse are recorded on the steps of the hits.
+func TestManagerHitReactionAndCorpseFollowTheQueuedHits(t *testing.T) {
+	m := NewManagerWithWorkers(world.New(world.Config{Columns: 32, Rows: 32, TileSize: 16}), 4)
+	defer m.Close()
+	shooter := NewRunner(geom.Point{X: 24, Y: 24}, false, 0)
+	target := NewRunner(geom.Point{X: 88, Y: 24}, false, 0)
+	target.Health = 2
+	m.AddUnit(shooter)
+	m.AddUnit(target)
+
+	var hits []int64
+	for tick := int64(1); tick <= 200 && len(hits) < 2; tick++ {
+		if !shooter.activeOrder.hasOrder && !shooter.queuedOrder.hasOrder {
+			if err := m.IssueFireOrder(shooter.UnitID(), geom.Point{X: 1, Y: 0}); err != nil {
+				t.Fatalf("IssueFireOrder() error = %v", err)
+			}
+		}
+		m.Update(tick)
+		for _, event := range m.DrainCombatEvents() {
+			if event.Type != CombatEventProjectileHit {
+				continue
+			}
+			hits = append(hits, event.Tick)
+			if len(hits) == 1 && target.lastHitTick != event.Tick {
+				t.Fatalf("lastHitTick after the first hit = %d, want %d", target.lastHitTick, event.Tick)
+			}
+		}
+	}
+	if len(hits) != 2 {
+		t.Fatalf("hits = %v, want two", hits)
+	}
+	if len(m.corpses) != 1 || m.corpses[0].diedAt != hits[1] || m.corpses[0].position != target.Position {
+		t.Fatalf("corpses = %+v, want one at %+v from tick %d", m.corpses, target.Position, hits[1])
+	}
+}
+
+// TestEmptyManagerUpdateStillPrunesCorpsesAndPublishesMetrics verifies that a manager without
+// units keeps its per-tick bookkeeping going.
+func TestEmptyManagerUpdateStillPrunesCorpsesAndPublishesMetrics(t *testing.T) {
+	m := NewManagerWithWorkers(world.New(world.Config{Columns: 8, Rows: 8, TileSize: 16}), 1)
+	defer m.Close()
+	m.lastGameTick = 5
+	m.recordCorpse(NewRunner(geom.Point{X: 24, Y: 24}, false, 0))
+
+	m.Update(5 + corpseTicks(KindRunner))
+	if len(m.corpses) != 0 {
+		t.Fatalf("corpses after fading = %d, want 0 with no units left", len(m.corpses))
+	}
+	if got := m.Metrics().Tick; got != 5+corpseTicks(KindRunner) {
+		t.Fatalf("Metrics().Tick = %d, want the last update tick", got)
+	}
+}
+
 func TestStaticUnitIsAlwaysImmobile(t *testing.T) {
 	u := NewWall(geom.Point{X: 8, Y: 8})
 
@@ -142,6 +270,25 @@ func TestUnitRenderPositionInterpolatesWhileSleeping(t *testing.T) {
 	}
 }
 
+func TestUnitInterpolatedRenderPositionAddsElapsedTickFraction(t *testing.T) {
+	u := NewRunner(geom.Point{X: 8, Y: 8}, false, 0)
+	u.SetPath([]geom.Point{{X: 24, Y: 8}})
+
+	u.Tick(1)
+	for tick := int64(2); tick <= 11; tick++ {
+		u.StepSleep()
+		u.UpdateVisible(tick)
+	}
+
+	got := u.InterpolatedRenderPosition(0.5)
+	if !geom.AlmostEqual(got.X, 16.4) || !geom.AlmostEqual(got.Y, 8) {
+		t.Fatalf("interpolated render position = %+v, want approximately {16.4 8}", got)
+	}
+	if u.InterpolatedRenderPosition(0) != u.RenderPosition() {
+		t.Fatal("expected zero interpolation to match RenderPosition")
+	}
+}
+
 func TestUnitQueueMoveCommandDefersRouteSwitchUntilCurrentTravelCompletes(t *testing.T) {
 	u := NewRunner(geom.Point{X: 8, Y: 8}, false, 0)
 	u.SetPath([]geom.Point{
@@ -0,0 +1,137 @@
+package world
+
+import "image/color"
+
+// EdgeMask marks the sides of a tile that border another terrain, one bit per side. The bit
+// order matches the clockwise rotation the renderer uses to derive every side from one piece.
+type EdgeMask uint8
+
+const (
+	EdgeNorth EdgeMask = 1 << iota
+	EdgeEast
+	EdgeSouth
+	EdgeWest
+)
+
+// CornerMask marks the corners of a tile that touch another terrain only diagonally, one bit
+// per corner in the same clockwise order starting at north-east.
+type CornerMask uint8
+
+const (
+	CornerNorthEast CornerMask = 1 << iota
+	CornerSouthEast
+	CornerSouthWest
+	CornerNorthWest
+)
+
+// Transition describes how one neighbouring terrain bleeds into a tile: the sides it shares
+// with the tile and the corners it only touches diagonally. A corner is left out when one of
+// its two sides is already set, because the edge piece covers it.
+type Transition struct {
+	Terrain TileType
+	Edges   EdgeMask
+	Corners CornerMask
+}
+
+// BlendPriority orders terrains for autotiling. A tile receives transitions only from
+// neighbours with a higher priority, so every border is drawn once, by the lower terrain.
+// Roads sit on top of everything and grass overgrows the bare ground, swamp and water.
+func (t TileType) BlendPriority() int {
+	switch t {
+	case TileRoad:
+		return 4
+	case TileGrass:
+		return 3
+	case TileDirt:
+		return 2
+	case TileSwamp:
+		return 1
+	case TileWater:
+		return 0
+	default:
+		return 0
+	}
+}
+
+// Tint is the colour the renderer multiplies the shared terrain texture with.
+func (t TileType) Tint() color.NRGBA {
+	switch t {
+	case TileRoad:
+		return color.NRGBA{R: 236, G: 222, B: 196, A: 255}
+	case TileDirt:
+		return color.NRGBA{R: 228, G: 205, B: 178, A: 255}
+	case TileSwamp:
+		return color.NRGBA{R: 196, G: 218, B: 182, A: 255}
+	case TileWater:
+		return color.NRGBA{R: 180, G: 210, B: 245, A: 255}
+	case TileGrass:
+		fallthrough
+	default:
+		return color.NRGBA{R: 214, G: 232, B: 204, A: 255}
+	}
+}
+
+// neighbourOffsets lists the eight neighbours clockwise from north: the even entries are the
+// sides and the odd ones the corners, so side i and corner i share bit position i/2.
+var neighbourOffsets = [8][2]int{
+	{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1},
+}
+
+// AppendTransitions appends the transitions of tile (x, y) to dst, lowest priority first so
+// drawing them in order leaves the highest terrain on top. Neighbours outside the world count as
+// the tile's own terrain, which keeps the world border free of transitions.
+func (w World) AppendTransitions(dst []Transition, x, y int) []Transition {
+	center := w.TileType(x, y)
+	var neighbours [8]TileType
+	for i, offset := range neighbourOffsets {
+		neighbours[i] = center
+		if w.InBounds(x+offset[0], y+offset[1]) {
+			neighbours[i] = w.TileType(x+offset[0], y+offset[1])
+		}
+	}
+	return appendTransitions(dst, center, neighbours)
+}
+
+// appendTransitions works out the transitions from the eight neighbour terrains, ordered as
+// neighbourOffsets. It is separate from AppendTransitions so it can be tested on hand-made
+// neighbourhoods instead of the generated terrain.
+func appendTransitions(dst []Transition, center TileType, neighbours [8]TileType) []Transition {
+	start := len(dst)
+	for _, terrain := range neighbours {
+		if terrain.BlendPriority() <= center.BlendPriority() || containsTerrain(dst[start:], terrain) {
+			continue
+		}
+
+		var transition Transition
+		transition.Terrain = terrain
+		for side := 0; side < 4; side++ {
+			if neighbours[2*side] == terrain {
+				transition.Edges |= 1 << side
+			}
+		}
+		for corner := 0; corner < 4; corner++ {
+			previousSide, nextSide := EdgeMask(1)<<corner, EdgeMask(1)<<((corner+1)%4)
+			if neighbours[2*corner+1] == terrain && transition.Edges&(previousSide|nextSide) == 0 {
+				transition.Corners |= 1 << corner
+			}
+		}
+		dst = append(dst, transition)
+	}
+
+	added := dst[start:]
+	for i := 1; i < len(added); i++ {
+		for j := i; j > 0 && added[j].Terrain.BlendPriority() < added[j-1].Terrain.BlendPriority(); j-- {
+			added[j], added[j-1] = added[j-1], added[j]
+		}
+	}
+	return dst
+}
+
+func containsTerrain(transitions []Transition, terrain TileType) bool {
+	for _, transition := range transitions {
+		if transition.Terrain == terrain {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package world
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestAppendTransitionsMasksSidesAndUncoveredCorners builds a water tile with grass along the
+// north side and both northern corners, swamp to the east and road touching only the south-west
+// corner. The grass corners are covered by the north edge and must not be reported again.
+func TestAppendTransitionsMasksSidesAndUncoveredCorners(t *testing.T) {
+	neighbours := [8]TileType{
+		TileGrass, // north
+		TileGrass, // north-east
+		TileSwamp, // east
+		TileWater, // south-east
+		TileWater, // south
+		TileRoad,  // south-west
+		TileWater, // west
+		TileGrass, // north-west
+	}
+
+	got := appendTransitions(nil, TileWater, neighbours)
+	want := []Transition{
+		{Terrain: TileSwamp, Edges: EdgeEast},
+		{Terrain: TileGrass, Edges: EdgeNorth},
+		{Terrain: TileRoad, Corners: CornerSouthWest},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("appendTransitions() = %+v, want %+v", got, want)
+	}
+
+	if got := appendTransitions(nil, TileRoad, neighbours); len(got) != 0 {
+		t.Fatalf("appendTransitions() for road = %+v, want none for the top terrain", got)
+	}
+}
+
+func TestAppendTransitionsKeepsTheWorldBorderPlain(t *testing.T) {
+	w := New(Config{Columns: 64, Rows: 64, TileSize: 16})
+	const westward = CornerNorthWest | CornerSouthWest
+	for y := 0; y < w.Rows(); y++ {
+		for _, transition := range w.AppendTransitions(nil, 0, y) {
+			if transition.Edges&EdgeWest != 0 || transition.Corners&westward != 0 {
+				t.Fatalf("tile (0, %d) transition %+v reaches past the west border", y, transition)
+			}
+		}
+	}
+}
@@ -181,20 +181,7 @@ func (w World) TileColor(x, y int) color.NRGBA {
 }
 
 func (w World) TileTint(x, y int) color.NRGBA {
-	switch w.TileType(x, y) {
-	case TileRoad:
-		return color.NRGBA{R: 236, G: 222, B: 196, A: 255}
-	case TileDirt:
-		return color.NRGBA{R: 228, G: 205, B: 178, A: 255}
-	case TileSwamp:
-		return color.NRGBA{R: 196, G: 218, B: 182, A: 255}
-	case TileWater:
-		return color.NRGBA{R: 180, G: 210, B: 245, A: 255}
-	case TileGrass:
-		fallthrough
-	default:
-		return color.NRGBA{R: 214, G: 232, B: 204, A: 255}
-	}
+	return w.TileType(x, y).Tint()
 }
 
 func (w World) TileIndex(x, y int) int {